@@ -1,11 +1,13 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gomodule/redigo/redis"
@@ -15,12 +17,23 @@ import (
 
 	"palback/internal/config"
 	handler "palback/internal/delivery/http"
+	"palback/internal/infra/breach"
 	"palback/internal/infra/email"
+	"palback/internal/infra/imaging"
+	"palback/internal/infra/oidc"
 	"palback/internal/infra/rate"
 	"palback/internal/infra/repository"
 	"palback/internal/infra/session"
 	"palback/internal/infra/storage"
 	"palback/internal/usecase"
+	"palback/internal/usecase/port"
+)
+
+const (
+	accountDeletionPurgeInterval = time.Hour
+
+	// emailDeliveryInterval Как часто проверять очередь писем
+	emailDeliveryInterval = 10 * time.Second
 )
 
 func main() {
@@ -64,30 +77,50 @@ func main() {
 	defer redisPool.Close()
 
 	// Инициализация слоёв приложения
-	_ = storage.NewMinioStorage(minioClient, cfg.MinIOBucketUserAvatars)
+	photoStorage := storage.NewMinioStorage(minioClient, cfg.MinIOBucketMain)
+	avatarStorage := storage.NewMinioStorage(minioClient, cfg.MinIOBucketUserAvatars)
+	imageProcessor := imaging.NewProcessor(int64(cfg.ImageMaxMegapixels) * 1_000_000)
 
 	redisStorage := storage.NewRedisStorage(redisPool)
 
 	mailSender := email.NewSMTPSender(cfg)
 
+	auditService := usecase.NewAuditUseCase(repository.NewAuditRepo(db))
+	auditHandler := handler.NewAuditHandler(auditService)
+
+	revisionRepo := repository.NewRevisionRepo(db)
+	revisionService := usecase.NewRevisionUseCase(auditService, revisionRepo)
+
 	countryRepo := repository.NewCountryRepo(db)
-	countryService := usecase.NewCountryUseCase(countryRepo)
+	countryService := usecase.NewCountryUseCase(revisionService, countryRepo)
 	countryHandler := handler.NewCountryHandler(countryService)
 
 	regionRepo := repository.NewRegionRepo(db)
-	regionService := usecase.NewRegionUseCase(countryService, regionRepo)
+	regionService := usecase.NewRegionUseCase(countryService, revisionService, regionRepo)
 	regionHandler := handler.NewRegionHandler(regionService)
 
 	cityTypeRepo := repository.NewCityTypeRepo(db)
 	cityTypeService := usecase.NewCityTypeUseCase(cityTypeRepo)
 	cityTypeHandler := handler.NewCityTypeHandler(cityTypeService)
 
+	cityRepo := repository.NewCityRepo(db)
+	cityService := usecase.NewCityUseCase(countryService, regionService, cityTypeService, revisionService, cityRepo)
+	cityHandler := handler.NewCityHandler(cityService)
+
 	placeTypeRepo := repository.NewPlaceTypeRepo(db)
 	placeTypeService := usecase.NewPlaceTypeUseCase(placeTypeRepo)
 	placeTypeHandler := handler.NewPlaceTypeHandler(placeTypeService)
 
-	roleRepo := repository.NewRoleRepo()
-	roleService := usecase.NewRoleUseCase(roleRepo)
+	placeRepo := repository.NewPlaceRepo(db)
+	placeService := usecase.NewPlaceUseCase(placeTypeService, cityService, revisionService, placeRepo)
+	placeHandler := handler.NewPlaceHandler(placeService)
+
+	translationService := usecase.NewTranslationUseCase(auditService, repository.NewTranslationRepo(db))
+	translationHandler := handler.NewTranslationHandler(translationService)
+
+	roleRepo := repository.NewRoleRepo(db)
+	roleService := usecase.NewRoleUseCase(auditService, roleRepo)
+	roleHandler := handler.NewRoleHandler(roleService)
 
 	userRepo := repository.NewUserRepo(db)
 
@@ -96,21 +129,139 @@ func main() {
 		log.Fatal("Ошибка инициализации аутентификатора", err)
 	}
 
-	rateLimiter := rate.NewRedigoRateLimiter(redisPool)
+	accessTokenRepo := repository.NewAccessTokenRepo(db)
+	bearerAuth := session.NewBearerAuthenticator(accessTokenRepo, userRepo)
+
+	rateLimiters := handler.RateLimiters{
+		SlidingLog:  rate.NewSlidingLogRateLimiter(redisPool),
+		TokenBucket: rate.NewTokenBucketRateLimiter(redisPool),
+	}
+
+	emailOutboxService := usecase.NewEmailOutboxUseCase(
+		auditService,
+		mailSender,
+		usecase.EmailOutboxConfig{
+			MaxAttempts: cfg.EmailMaxAttempts,
+			RetryDelay:  time.Duration(cfg.EmailRetrySeconds) * time.Second,
+		},
+		repository.NewEmailOutboxRepo(db),
+	)
+	emailOutboxHandler := handler.NewEmailOutboxHandler(emailOutboxService)
+
+	breachedPasswords, err := breach.Load(cfg.PasswordBreachedListFile)
+	if err != nil {
+		log.Fatal("Ошибка загрузки списка утекших паролей", err)
+	}
+
+	passwordPolicy := usecase.NewPasswordPolicyUseCase(usecase.PasswordPolicyConfig{
+		MinLength:      cfg.PasswordMinLength,
+		MinCharClasses: cfg.PasswordMinCharClasses,
+	}, breachedPasswords)
+
+	userService := usecase.NewUserUseCase(
+		roleService,
+		auditService,
+		passwordPolicy,
+		emailOutboxService,
+		redisStorage,
+		usecase.TwoFactorConfig{
+			Issuer:            cfg.TwoFactorIssuer,
+			RequiredForAdmins: cfg.TwoFactorRequiredForAdmins,
+		},
+		usecase.LockoutConfig{
+			MaxAttempts: cfg.LoginMaxAttempts,
+			Duration:    time.Duration(cfg.LoginLockoutMinutes) * time.Minute,
+		},
+		userRepo,
+	)
+	userProfileService := usecase.NewUserProfileUseCase(
+		cityService,
+		avatarStorage,
+		imageProcessor,
+		int64(cfg.AvatarMaxSizeMB)<<20,
+		userRepo,
+	)
+	accountDeletionService := usecase.NewAccountDeletionUseCase(
+		userProfileService,
+		auditService,
+		emailOutboxService,
+		time.Duration(cfg.AccountDeletionGraceDays)*24*time.Hour,
+		userRepo,
+	)
+	userHandler := handler.NewUserHandler(userService, userProfileService, accountDeletionService, auth, rateLimiters.SlidingLog)
+	adminUserHandler := handler.NewAdminUserHandler(userService, accountDeletionService)
+
+	externalLoginService := usecase.NewExternalLoginUseCase(
+		userService,
+		redisStorage,
+		initIdentityProviders(cfg),
+		userRepo,
+		repository.NewIdentityRepo(db),
+	)
+	externalLoginHandler := handler.NewExternalLoginHandler(externalLoginService, auth, cfg.IsProduction)
+
+	accessTokenService := usecase.NewAccessTokenUseCase(accessTokenRepo)
+	accessTokenHandler := handler.NewAccessTokenHandler(accessTokenService)
+
+	placeSubmissionRepo := repository.NewPlaceSubmissionRepo(db)
+	placeSubmissionService := usecase.NewPlaceSubmissionUseCase(
+		placeService,
+		userService,
+		revisionService,
+		emailOutboxService,
+		placeSubmissionRepo,
+	)
+	placeSubmissionHandler := handler.NewPlaceSubmissionHandler(placeSubmissionService)
 
-	userService := usecase.NewUserUseCase(roleService, mailSender, redisStorage, userRepo)
-	userHandler := handler.NewUserHandler(userService, auth, rateLimiter)
+	placePhotoRepo := repository.NewPlacePhotoRepo(db)
+	placePhotoService := usecase.NewPlacePhotoUseCase(
+		placeService,
+		userService,
+		photoStorage,
+		imageProcessor,
+		int64(cfg.PhotoMaxSizeMB)<<20,
+		placePhotoRepo,
+	)
+	placePhotoHandler := handler.NewPlacePhotoHandler(placePhotoService)
+
+	// Фоновое удаление аккаунтов, у которых истек льготный срок
+	go runPeriodically(accountDeletionPurgeInterval, func(ctx context.Context) {
+		if err := accountDeletionService.PurgeExpired(ctx); err != nil {
+			log.Println("Ошибка удаления аккаунтов:", err)
+		}
+	})
+
+	// Фоновая отправка писем из очереди
+	go runPeriodically(emailDeliveryInterval, func(ctx context.Context) {
+		if err := emailOutboxService.DeliverDue(ctx); err != nil {
+			log.Println("Ошибка отправки писем:", err)
+		}
+	})
 
 	// Инициализация рутера
 	router := handler.NewRouter(
 		cfg,
 		auth,
-		rateLimiter,
+		bearerAuth,
+		userService,
+		userService,
+		rateLimiters,
 		countryHandler,
 		regionHandler,
 		cityTypeHandler,
+		cityHandler,
 		placeTypeHandler,
+		placeHandler,
+		placeSubmissionHandler,
+		placePhotoHandler,
 		userHandler,
+		accessTokenHandler,
+		externalLoginHandler,
+		adminUserHandler,
+		roleHandler,
+		auditHandler,
+		translationHandler,
+		emailOutboxHandler,
 	)
 
 	if err := router.Start(":" + cfg.ServerPort); !errors.Is(err, http.ErrServerClosed) {
@@ -118,6 +269,45 @@ func main() {
 	}
 }
 
+// runPeriodically Выполнять задачу с заданным интервалом, первый раз сразу после запуска
+func runPeriodically(interval time.Duration, task func(ctx context.Context)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		task(context.Background())
+		<-ticker.C
+	}
+}
+
+// initIdentityProviders Подключить внешних провайдеров входа. Недоступный провайдер пропускается,
+// чтобы сервер мог работать без него.
+func initIdentityProviders(cfg *config.Config) []port.IdentityProvider {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var result []port.IdentityProvider
+
+	for _, providerCfg := range cfg.OIDCProviders {
+		provider, err := oidc.NewProvider(ctx, oidc.Config{
+			Name:         providerCfg.Name,
+			Issuer:       providerCfg.Issuer,
+			ClientID:     providerCfg.ClientID,
+			ClientSecret: providerCfg.ClientSecret,
+			RedirectURL:  strings.TrimRight(cfg.OIDCRedirectBaseURL, "/") + "/" + providerCfg.Name,
+			Scopes:       providerCfg.Scopes,
+		})
+		if err != nil {
+			log.Printf("Провайдер входа %s не подключен: %v", providerCfg.Name, err)
+			continue
+		}
+
+		result = append(result, provider)
+	}
+
+	return result
+}
+
 func initRedisPool(cfg *config.Config) *redis.Pool {
 	return &redis.Pool{
 		// Максимальное количество "простаивающих" (idle) соединений в пуле
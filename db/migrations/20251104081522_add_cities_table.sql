@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+create table cities (
+    id serial primary key,
+    country_id varchar(6) not null,
+    region_id int,
+    city_type_id int not null,
+    name varchar not null,
+    latitude double precision not null check ( latitude between -90 and 90 ),
+    longitude double precision not null check ( longitude between -180 and 180 ),
+    constraint fk_city_country foreign key (country_id) references countries(id) on update cascade,
+    constraint fk_city_region foreign key (region_id) references regions(id),
+    constraint fk_city_city_type foreign key (city_type_id) references city_types(id)
+);
+
+create index cities_country_id_idx on cities(country_id);
+create index cities_region_id_idx on cities(region_id);
+create index cities_name_idx on cities(name);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop index cities_name_idx;
+drop index cities_region_id_idx;
+drop index cities_country_id_idx;
+
+drop table cities;
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+create table places (
+    id serial primary key,
+    place_type_id int not null,
+    city_id int not null,
+    name varchar not null,
+    address varchar not null default '',
+    latitude double precision not null check ( latitude between -90 and 90 ),
+    longitude double precision not null check ( longitude between -180 and 180 ),
+    description text not null default '',
+    founding_year int,
+    status varchar(20) not null default 'active' check ( status in ('active', 'destroyed', 'restored') ),
+    created_at timestamp not null default now(),
+    updated_at timestamp not null default now(),
+    constraint fk_place_place_type foreign key (place_type_id) references place_types(id),
+    constraint fk_place_city foreign key (city_id) references cities(id)
+);
+
+create index places_city_id_idx on places(city_id);
+create index places_place_type_id_idx on places(place_type_id);
+create index places_name_idx on places(name);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop index places_name_idx;
+drop index places_place_type_id_idx;
+drop index places_city_id_idx;
+
+drop table places;
+-- +goose StatementEnd
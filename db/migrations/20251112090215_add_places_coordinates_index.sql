@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+create index places_coordinates_idx on places(latitude, longitude);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop index places_coordinates_idx;
+-- +goose StatementEnd
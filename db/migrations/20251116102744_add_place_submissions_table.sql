@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+create table place_submissions (
+    id serial primary key,
+    place_id int,
+    author_id int not null,
+    moderator_id int,
+    place_type_id int not null,
+    city_id int not null,
+    name varchar not null,
+    address varchar not null default '',
+    latitude double precision not null,
+    longitude double precision not null,
+    description text not null default '',
+    founding_year int,
+    status varchar(20) not null default 'active',
+    state varchar(20) not null default 'draft' check ( state in ('draft', 'pending', 'published', 'rejected') ),
+    reject_reason text not null default '',
+    created_at timestamp not null default now(),
+    updated_at timestamp not null default now(),
+    constraint fk_place_submission_place foreign key (place_id) references places(id) on delete cascade,
+    constraint fk_place_submission_author foreign key (author_id) references users(id) on delete cascade,
+    constraint fk_place_submission_moderator foreign key (moderator_id) references users(id) on delete set null
+);
+
+create index place_submissions_author_id_idx on place_submissions(author_id);
+create index place_submissions_state_idx on place_submissions(state);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop index place_submissions_state_idx;
+drop index place_submissions_author_id_idx;
+
+drop table place_submissions;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+create table revisions (
+    id serial primary key,
+    entity varchar(20) not null,
+    entity_id varchar not null,
+    author_id int,
+    action varchar(20) not null check ( action in ('create', 'update', 'delete', 'restore') ),
+    before jsonb,
+    after jsonb,
+    created_at timestamp not null default now(),
+    constraint fk_revision_author foreign key (author_id) references users(id) on delete set null
+);
+
+create index revisions_entity_idx on revisions(entity, entity_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop index revisions_entity_idx;
+
+drop table revisions;
+-- +goose StatementEnd
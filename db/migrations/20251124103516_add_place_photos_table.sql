@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+create table place_photos (
+    id serial primary key,
+    place_id int not null,
+    author_id int not null,
+    object_key varchar not null unique,
+    content_type varchar(50) not null,
+    size bigint not null,
+    caption varchar not null default '',
+    license varchar not null default '',
+    position int not null default 0,
+    is_cover boolean not null default false,
+    created_at timestamp not null default now(),
+    constraint fk_place_photo_place foreign key (place_id) references places(id) on delete cascade,
+    constraint fk_place_photo_author foreign key (author_id) references users(id) on delete cascade
+);
+
+create index place_photos_place_id_idx on place_photos(place_id, position);
+create unique index place_photos_cover_idx on place_photos(place_id) where is_cover;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop index place_photos_cover_idx;
+drop index place_photos_place_id_idx;
+
+drop table place_photos;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table users
+    add column display_name varchar(100) not null default '',
+    add column about text not null default '',
+    add column city_id int,
+    add column avatar_key varchar not null default '',
+    add constraint fk_user_city foreign key (city_id) references cities(id) on delete set null;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+alter table users
+    drop constraint fk_user_city,
+    drop column avatar_key,
+    drop column city_id,
+    drop column about,
+    drop column display_name;
+-- +goose StatementEnd
@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table users add column deletion_requested_at timestamp;
+
+create index users_deletion_requested_at_idx on users(deletion_requested_at) where deletion_requested_at is not null;
+
+-- После удаления пользователя его фотографии и предложенные места остаются без автора
+alter table place_photos
+    alter column author_id drop not null,
+    drop constraint fk_place_photo_author,
+    add constraint fk_place_photo_author foreign key (author_id) references users(id) on delete set null;
+
+alter table place_submissions
+    alter column author_id drop not null,
+    drop constraint fk_place_submission_author,
+    add constraint fk_place_submission_author foreign key (author_id) references users(id) on delete set null;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+delete from place_submissions where author_id is null;
+
+alter table place_submissions
+    drop constraint fk_place_submission_author,
+    add constraint fk_place_submission_author foreign key (author_id) references users(id) on delete cascade,
+    alter column author_id set not null;
+
+delete from place_photos where author_id is null;
+
+alter table place_photos
+    drop constraint fk_place_photo_author,
+    add constraint fk_place_photo_author foreign key (author_id) references users(id) on delete cascade,
+    alter column author_id set not null;
+
+drop index users_deletion_requested_at_idx;
+
+alter table users drop column deletion_requested_at;
+-- +goose StatementEnd
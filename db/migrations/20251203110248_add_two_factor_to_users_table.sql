@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table users add column totp_secret text not null default '';
+
+-- Резервные коды хранятся в виде хэшей и используются однократно
+create table user_recovery_codes (
+    id serial primary key,
+    user_id integer not null,
+    code_hash text not null,
+    used_at timestamp,
+    constraint fk_recovery_code_user foreign key (user_id) references users(id) on delete cascade,
+    constraint user_recovery_codes_user_hash_key unique (user_id, code_hash)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table user_recovery_codes;
+
+alter table users drop column totp_secret;
+-- +goose StatementEnd
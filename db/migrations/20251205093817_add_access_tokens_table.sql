@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+create table access_tokens (
+    id serial primary key,
+    user_id integer not null,
+    name text not null,
+    prefix text not null,
+    token_hash text not null,
+    scopes text[] not null,
+    created_at timestamp not null default now(),
+    last_used_at timestamp,
+    expires_at timestamp,
+    constraint fk_access_token_user foreign key (user_id) references users(id) on delete cascade,
+    constraint access_tokens_token_hash_key unique (token_hash)
+);
+
+create index access_tokens_user_id_idx on access_tokens(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table access_tokens;
+-- +goose StatementEnd
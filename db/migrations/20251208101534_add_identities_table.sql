@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+create table identities (
+    id serial primary key,
+    user_id integer not null,
+    provider text not null,
+    subject text not null,
+    email text not null default '',
+    created_at timestamp not null default now(),
+    constraint fk_identity_user foreign key (user_id) references users(id) on delete cascade,
+    constraint identities_provider_subject_key unique (provider, subject),
+    constraint identities_user_provider_key unique (user_id, provider)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table identities;
+-- +goose StatementEnd
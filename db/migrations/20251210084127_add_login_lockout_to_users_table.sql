@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table users
+    add column failed_login_count integer not null default 0,
+    add column last_failed_login_at timestamp,
+    add column locked_until timestamp;
+
+create index users_failed_login_count_idx on users(failed_login_count) where failed_login_count > 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop index users_failed_login_count_idx;
+
+alter table users
+    drop column failed_login_count,
+    drop column last_failed_login_at,
+    drop column locked_until;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table users
+    add column blocked_at timestamp;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+alter table users
+    drop column blocked_at;
+-- +goose StatementEnd
@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+create table roles (
+    id varchar(20) primary key,
+    name text not null
+);
+
+create table role_permissions (
+    role_id varchar(20) not null,
+    permission varchar(50) not null,
+    primary key (role_id, permission),
+    constraint fk_role_permission_role foreign key (role_id) references roles(id) on delete cascade
+);
+
+insert into roles (id, name) values
+    ('admin', 'администратор'),
+    ('moderator', 'модератор'),
+    ('user', 'пользователь');
+
+insert into role_permissions (role_id, permission) values
+    ('admin', 'catalog.edit'),
+    ('admin', 'places.moderate'),
+    ('admin', 'users.manage'),
+    ('moderator', 'places.moderate');
+
+alter table users
+    add constraint fk_user_role foreign key (role_id) references roles(id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+alter table users
+    drop constraint fk_user_role;
+
+drop table role_permissions;
+drop table roles;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+create table audit_log (
+    id bigserial primary key,
+    action varchar(50) not null,
+    actor_id integer,
+    ip text not null default '',
+    user_agent text not null default '',
+    entity_type varchar(30) not null default '',
+    entity_id text not null default '',
+    payload jsonb,
+    created_at timestamp not null default now(),
+    constraint fk_audit_log_actor foreign key (actor_id) references users(id) on delete set null
+);
+
+create index audit_log_created_at_idx on audit_log(created_at);
+create index audit_log_actor_id_idx on audit_log(actor_id);
+create index audit_log_entity_idx on audit_log(entity_type, entity_id);
+create index audit_log_action_idx on audit_log(action);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table audit_log;
+-- +goose StatementEnd
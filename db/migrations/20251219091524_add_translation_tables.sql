@@ -0,0 +1,71 @@
+-- +goose Up
+-- +goose StatementBegin
+insert into languages values ('en');
+
+create table country_translations (
+    country_id varchar(6) not null,
+    lang char(2) not null,
+    name varchar not null,
+    primary key (country_id, lang),
+    constraint fk_country_translation_country foreign key (country_id) references countries(id) on update cascade on delete cascade,
+    constraint fk_country_translation_lang foreign key (lang) references languages(id)
+);
+
+create table region_translations (
+    region_id int not null,
+    lang char(2) not null,
+    name varchar not null,
+    primary key (region_id, lang),
+    constraint fk_region_translation_region foreign key (region_id) references regions(id) on delete cascade,
+    constraint fk_region_translation_lang foreign key (lang) references languages(id)
+);
+
+create table city_type_translations (
+    city_type_id int not null,
+    lang char(2) not null,
+    name varchar not null,
+    short_name varchar not null default '',
+    primary key (city_type_id, lang),
+    constraint fk_city_type_translation_city_type foreign key (city_type_id) references city_types(id) on delete cascade,
+    constraint fk_city_type_translation_lang foreign key (lang) references languages(id)
+);
+
+create table city_translations (
+    city_id int not null,
+    lang char(2) not null,
+    name varchar not null,
+    primary key (city_id, lang),
+    constraint fk_city_translation_city foreign key (city_id) references cities(id) on delete cascade,
+    constraint fk_city_translation_lang foreign key (lang) references languages(id)
+);
+
+create table place_type_translations (
+    place_type_id int not null,
+    lang char(2) not null,
+    name varchar not null,
+    primary key (place_type_id, lang),
+    constraint fk_place_type_translation_place_type foreign key (place_type_id) references place_types(id) on delete cascade,
+    constraint fk_place_type_translation_lang foreign key (lang) references languages(id)
+);
+
+create table place_translations (
+    place_id int not null,
+    lang char(2) not null,
+    name varchar not null,
+    primary key (place_id, lang),
+    constraint fk_place_translation_place foreign key (place_id) references places(id) on delete cascade,
+    constraint fk_place_translation_lang foreign key (lang) references languages(id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table place_translations;
+drop table place_type_translations;
+drop table city_translations;
+drop table city_type_translations;
+drop table region_translations;
+drop table country_translations;
+
+delete from languages where id = 'en';
+-- +goose StatementEnd
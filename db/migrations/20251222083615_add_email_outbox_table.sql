@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+create table email_outbox (
+    id bigserial primary key,
+    kind varchar(30) not null,
+    to_email varchar(255) not null,
+    payload jsonb not null default '{}',
+    status varchar(10) not null default 'pending',
+    attempts integer not null default 0,
+    last_error text not null default '',
+    next_attempt_at timestamp not null default now(),
+    created_at timestamp not null default now(),
+    sent_at timestamp,
+    constraint email_outbox_status_check check (status in ('pending', 'sent', 'dead'))
+);
+
+create index email_outbox_due_idx on email_outbox(next_attempt_at) where status = 'pending';
+create index email_outbox_status_idx on email_outbox(status);
+create index email_outbox_to_email_idx on email_outbox(to_email);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table email_outbox;
+-- +goose StatementEnd
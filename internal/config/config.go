@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"github.com/joho/godotenv"
+
+	"palback/internal/pkg/locale"
 )
 
 type Config struct {
@@ -28,6 +30,12 @@ type Config struct {
 	MinIOBucketMain        string
 	MinIOBucketUserAvatars string
 
+	PhotoMaxSizeMB  int
+	AvatarMaxSizeMB int
+
+	// ImageMaxMegapixels Наибольшее разрешение загружаемых фотографий и аватаров в мегапикселях
+	ImageMaxMegapixels int
+
 	RedisAddr      string
 	RedisUsername  string
 	RedisPassword  string
@@ -35,11 +43,55 @@ type Config struct {
 
 	SessionDays int
 
+	// LoginMaxAttempts После скольких неудачных попыток входа подряд аккаунт блокируется
+	LoginMaxAttempts int
+
+	// LoginLockoutMinutes На сколько минут блокируется вход
+	LoginLockoutMinutes int
+
+	// PasswordMinLength Наименьшая длина пароля
+	PasswordMinLength int
+
+	// PasswordMinCharClasses Сколько типов символов должно быть в пароле: строчные и заглавные буквы, цифры, прочие
+	PasswordMinCharClasses int
+
+	// PasswordBreachedListFile Файл с SHA-1 хэшами паролей из утечек; если не задан, используется встроенный список
+	PasswordBreachedListFile string
+
+	// TwoFactorIssuer Название сервиса в приложении-аутентификаторе
+	TwoFactorIssuer string
+
+	// TwoFactorRequiredForAdmins Администраторы обязаны использовать двухфакторную аутентификацию
+	TwoFactorRequiredForAdmins bool
+
+	// AccountDeletionGraceDays Сколько дней после запроса на удаление аккаунт можно восстановить, войдя на сайт
+	AccountDeletionGraceDays int
+
+	// OIDCRedirectBaseURL Адрес страницы фронтенда, куда провайдер возвращает пользователя;
+	// к нему добавляется имя провайдера
+	OIDCRedirectBaseURL string
+	OIDCProviders       []OIDCProviderConfig
+
 	SMTPHost     string
 	SMTPPort     string
 	SMTPUsername string
 	SMTPPassword string
 	SMTPFrom     string
+
+	// EmailMaxAttempts После скольких неудачных попыток письмо больше не отправляется
+	EmailMaxAttempts int
+
+	// EmailRetrySeconds Задержка перед второй попыткой отправки письма, перед каждой следующей она удваивается
+	EmailRetrySeconds int
+}
+
+// OIDCProviderConfig Настройки внешнего провайдера входа
+type OIDCProviderConfig struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
 }
 
 func Load() (*Config, error) {
@@ -78,6 +130,12 @@ func Load() (*Config, error) {
 		RedisPassword:  getEnv("REDIS_PASSWORD", ""),
 		RedisSecretKey: getEnv("REDIS_SECRET_KEY", "secret-key-32-bytes-long-12345678"),
 
+		PasswordBreachedListFile: getEnv("PASSWORD_BREACHED_LIST_FILE", ""),
+
+		TwoFactorIssuer: getEnv("TWO_FACTOR_ISSUER", "palomniki.su"),
+
+		OIDCRedirectBaseURL: getEnv("OIDC_REDIRECT_BASE_URL", "http://localhost:3000/auth/oidc"),
+
 		SMTPHost:     getEnv("SMTP_HOST", "localhost"),
 		SMTPPort:     getEnv("SMTP_PORT", "1025"),
 		SMTPUsername: getEnv("SMTP_USERNAME", ""),
@@ -89,14 +147,95 @@ func Load() (*Config, error) {
 		cfg.IsProduction = true
 	}
 
+	if strings.ToLower(strings.TrimSpace(getEnv("TWO_FACTOR_REQUIRED_FOR_ADMINS", "false"))) == "true" {
+		cfg.TwoFactorRequiredForAdmins = true
+	}
+
 	cfg.SessionDays, err = strconv.Atoi(getEnv("SESSION_DAYS", "7"))
 	if err != nil {
 		return nil, err
 	}
 
+	cfg.AccountDeletionGraceDays, err = strconv.Atoi(getEnv("ACCOUNT_DELETION_GRACE_DAYS", "14"))
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.LoginMaxAttempts, err = strconv.Atoi(getEnv("LOGIN_MAX_ATTEMPTS", "10"))
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.LoginLockoutMinutes, err = strconv.Atoi(getEnv("LOGIN_LOCKOUT_MINUTES", "30"))
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.PasswordMinLength, err = strconv.Atoi(getEnv("PASSWORD_MIN_LENGTH", "8"))
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.PasswordMinCharClasses, err = strconv.Atoi(getEnv("PASSWORD_MIN_CHAR_CLASSES", "2"))
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.PhotoMaxSizeMB, err = strconv.Atoi(getEnv("PHOTO_MAX_SIZE_MB", "10"))
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.AvatarMaxSizeMB, err = strconv.Atoi(getEnv("AVATAR_MAX_SIZE_MB", "5"))
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.ImageMaxMegapixels, err = strconv.Atoi(getEnv("IMAGE_MAX_MEGAPIXELS", "50"))
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.EmailMaxAttempts, err = strconv.Atoi(getEnv("EMAIL_MAX_ATTEMPTS", "8"))
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.EmailRetrySeconds, err = strconv.Atoi(getEnv("EMAIL_RETRY_SECONDS", "60"))
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.OIDCProviders = loadOIDCProviders()
+
 	return cfg, nil
 }
 
+// loadOIDCProviders Провайдеры перечисляются в OIDC_PROVIDERS через запятую, настройки каждого
+// задаются переменными OIDC_<ИМЯ>_ISSUER, OIDC_<ИМЯ>_CLIENT_ID, OIDC_<ИМЯ>_CLIENT_SECRET и OIDC_<ИМЯ>_SCOPES
+func loadOIDCProviders() []OIDCProviderConfig {
+	var result []OIDCProviderConfig
+
+	for name := range strings.SplitSeq(getEnv("OIDC_PROVIDERS", ""), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+
+		result = append(result, OIDCProviderConfig{
+			Name:         name,
+			Issuer:       getEnv(prefix+"ISSUER", ""),
+			ClientID:     getEnv(prefix+"CLIENT_ID", ""),
+			ClientSecret: getEnv(prefix+"CLIENT_SECRET", ""),
+			Scopes:       strings.Fields(strings.ReplaceAll(getEnv(prefix+"SCOPES", ""), ",", " ")),
+		})
+	}
+
+	return result
+}
+
 func getEnv(key, fallback string) string {
 	if value, ok := os.LookupEnv(key); ok {
 		return value
@@ -105,5 +244,5 @@ func getEnv(key, fallback string) string {
 }
 
 func GetLang() string {
-	return "ru"
+	return locale.Default
 }
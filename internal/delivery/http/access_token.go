@@ -0,0 +1,117 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"palback/internal/delivery/http/dto"
+	localErrors "palback/internal/pkg/errors"
+	"palback/internal/pkg/helpers"
+	"palback/internal/usecase"
+)
+
+type AccessTokenHandler struct {
+	service usecase.AccessTokenService
+}
+
+func NewAccessTokenHandler(service usecase.AccessTokenService) *AccessTokenHandler {
+	return &AccessTokenHandler{
+		service: service,
+	}
+}
+
+// GetAll Получить персональные токены доступа текущего пользователя
+func (h *AccessTokenHandler) GetAll(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	data, err := h.service.GetByUser(ctx, userID)
+	if err != nil {
+		return accessTokenError(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.CreateAccessTokenResponseList(data))
+}
+
+// Post Выпустить персональный токен доступа для текущего пользователя
+func (h *AccessTokenHandler) Post(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.AccessTokenPostRequest
+	if err = bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	if req.ExpiresInDays < 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, usecase.ErrAccessTokenInvalidExpiry.Error())
+	}
+
+	var expiresAt *time.Time
+	if req.ExpiresInDays > 0 {
+		expiresAt = helpers.ToPtr(time.Now().AddDate(0, 0, req.ExpiresInDays))
+	}
+
+	data, err := h.service.Create(ctx, userID, strings.TrimSpace(req.Name), req.Scopes, expiresAt)
+	if err != nil {
+		return accessTokenError(err)
+	}
+
+	return c.JSON(http.StatusCreated, dto.CreatedAccessTokenResponse{
+		AccessTokenResponse: dto.CreateAccessTokenResponse(data.AccessToken),
+		Token:               data.Token,
+	})
+}
+
+// Delete Отозвать персональный токен доступа текущего пользователя
+func (h *AccessTokenHandler) Delete(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := getPositiveIntParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	err = h.service.Revoke(ctx, userID, id)
+	if err != nil {
+		return accessTokenError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"message": "токен доступа отозван"})
+}
+
+func accessTokenError(err error) error {
+	switch {
+	case errors.Is(err, usecase.ErrAccessTokenNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	case localErrors.IsOneOf(
+		err,
+		usecase.ErrAccessTokenNameRequired,
+		usecase.ErrAccessTokenNameTooLong,
+		usecase.ErrAccessTokenInvalidScope,
+		usecase.ErrAccessTokenInvalidExpiry,
+	):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	case errors.Is(err, usecase.ErrAccessTokenLimitReached):
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	default:
+		return echo.NewHTTPError(http.StatusInternalServerError, "ошибка работы с токенами доступа")
+	}
+}
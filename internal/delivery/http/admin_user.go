@@ -0,0 +1,192 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"palback/internal/delivery/http/dto"
+	"palback/internal/domain/model"
+	localErrors "palback/internal/pkg/errors"
+	"palback/internal/pkg/helpers"
+	"palback/internal/usecase"
+)
+
+// AdminUserHandler Управление пользователями администратором
+type AdminUserHandler struct {
+	service         usecase.UserService
+	deletionService usecase.AccountDeletionService
+}
+
+func NewAdminUserHandler(
+	service usecase.UserService,
+	deletionService usecase.AccountDeletionService,
+) *AdminUserHandler {
+	return &AdminUserHandler{
+		service:         service,
+		deletionService: deletionService,
+	}
+}
+
+// GetList Получить страницу пользователей с поиском по имени пользователя и e-mail
+func (h *AdminUserHandler) GetList(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var (
+		filter model.UserFilter
+		err    error
+	)
+
+	filter.Search = strings.TrimSpace(c.QueryParam("search"))
+
+	if filter.Limit, err = getNonNegativeIntQuery(c, "limit"); err != nil {
+		return err
+	}
+
+	if filter.Offset, err = getNonNegativeIntQuery(c, "offset"); err != nil {
+		return err
+	}
+
+	data, err := h.service.GetList(ctx, filter)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "ошибка получения списка пользователей")
+	}
+
+	return c.JSON(http.StatusOK, dto.CreateAdminUserResponseList(data))
+}
+
+// Get Получить сведения о пользователе
+func (h *AdminUserHandler) Get(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := getPositiveIntParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	data, err := h.service.Get(ctx, id)
+	if err != nil {
+		return adminUserError(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.CreateAdminUserResponse(helpers.FromPtr(data)))
+}
+
+// ChangeRole Изменить роль пользователя
+func (h *AdminUserHandler) ChangeRole(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := getPositiveIntParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.ChangeRoleRequest
+	if err = bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	data, err := h.service.ChangeRole(ctx, id, model.RoleID(req.Role))
+	if err != nil {
+		return adminUserError(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.CreateAdminUserResponse(helpers.FromPtr(data)))
+}
+
+// VerifyEmail Подтвердить e-mail пользователя без письма
+func (h *AdminUserHandler) VerifyEmail(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := getPositiveIntParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	if err = h.service.ForceVerifyEmail(ctx, id); err != nil {
+		return adminUserError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"message": "e-mail пользователя подтвержден"})
+}
+
+// Block Заблокировать пользователя
+func (h *AdminUserHandler) Block(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := getPositiveIntParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	if err = h.service.Block(ctx, id); err != nil {
+		return adminUserError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"message": "пользователь заблокирован"})
+}
+
+// Unblock Снять блокировку пользователя
+func (h *AdminUserHandler) Unblock(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := getPositiveIntParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	if err = h.service.Unblock(ctx, id); err != nil {
+		return adminUserError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"message": "блокировка пользователя снята"})
+}
+
+// Logout Завершить все сессии пользователя
+func (h *AdminUserHandler) Logout(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := getPositiveIntParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	if err = h.service.ForceLogout(ctx, id); err != nil {
+		return adminUserError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"message": "все сессии пользователя завершены"})
+}
+
+// Delete Удалить пользователя без льготного срока
+func (h *AdminUserHandler) Delete(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := getPositiveIntParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	if err = h.deletionService.Delete(ctx, id); err != nil {
+		return adminUserError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"message": "пользователь удален"})
+}
+
+func adminUserError(err error) error {
+	switch {
+	case localErrors.IsOneOf(err, usecase.ErrUserNotFound, localErrors.ErrNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, usecase.ErrUserNotFound.Error())
+	case errors.Is(err, usecase.ErrRoleNotFound):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	case errors.Is(err, usecase.ErrRoleAssignForbidden):
+		return echo.NewHTTPError(http.StatusForbidden, err.Error())
+	case errors.Is(err, usecase.ErrUserSelfAdministration):
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	default:
+		return echo.NewHTTPError(http.StatusInternalServerError, "ошибка управления пользователем")
+	}
+}
@@ -0,0 +1,139 @@
+package http
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"palback/internal/delivery/http/dto"
+	"palback/internal/domain/model"
+	"palback/internal/usecase"
+)
+
+type AuditHandler struct {
+	service usecase.AuditService
+}
+
+func NewAuditHandler(service usecase.AuditService) *AuditHandler {
+	return &AuditHandler{
+		service: service,
+	}
+}
+
+// GetList Получить страницу журнала аудита
+func (h *AuditHandler) GetList(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	filter, err := getAuditFilter(c)
+	if err != nil {
+		return err
+	}
+
+	if filter.Limit, err = getNonNegativeIntQuery(c, "limit"); err != nil {
+		return err
+	}
+
+	if filter.Offset, err = getNonNegativeIntQuery(c, "offset"); err != nil {
+		return err
+	}
+
+	data, err := h.service.GetList(ctx, filter)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "ошибка получения журнала аудита")
+	}
+
+	return c.JSON(http.StatusOK, dto.CreateAuditEntryResponseList(data))
+}
+
+// Export Выгрузить журнал аудита в CSV с теми же фильтрами, что и у списка
+func (h *AuditHandler) Export(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	filter, err := getAuditFilter(c)
+	if err != nil {
+		return err
+	}
+
+	data, err := h.service.Export(ctx, filter)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "ошибка выгрузки журнала аудита")
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+	c.Response().Header().Set(
+		echo.HeaderContentDisposition,
+		fmt.Sprintf(`attachment; filename="audit-%s.csv"`, time.Now().Format("20060102-150405")),
+	)
+	c.Response().WriteHeader(http.StatusOK)
+
+	w := csv.NewWriter(c.Response())
+
+	err = w.Write([]string{
+		"id", "created_at", "action", "actor_id", "ip", "user_agent", "entity_type", "entity_id", "payload",
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range data {
+		var actorID string
+		if entry.ActorID != nil {
+			actorID = strconv.Itoa(*entry.ActorID)
+		}
+
+		err = w.Write([]string{
+			strconv.Itoa(entry.ID),
+			entry.CreatedAt.Format(time.RFC3339),
+			string(entry.Action),
+			actorID,
+			csvSafe(entry.IP),
+			csvSafe(entry.UserAgent),
+			entry.EntityType,
+			csvSafe(entry.EntityID),
+			string(entry.Payload),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+
+	return w.Error()
+}
+
+// csvSafe Экранировать значение, которое табличный редактор принял бы за формулу.
+// IP-адрес и User-Agent приходят от клиента и могут содержать что угодно.
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+
+	return value
+}
+
+// getAuditFilter Получить условия поиска в журнале аудита из строки запроса
+func getAuditFilter(c echo.Context) (filter model.AuditFilter, err error) {
+	filter.Action = model.AuditAction(strings.TrimSpace(c.QueryParam("action")))
+	filter.EntityType = strings.TrimSpace(c.QueryParam("entity_type"))
+	filter.EntityID = strings.TrimSpace(c.QueryParam("entity_id"))
+
+	if filter.ActorID, err = getOptionalPositiveIntQuery(c, "actor_id"); err != nil {
+		return filter, err
+	}
+
+	if filter.From, err = getOptionalTimeQuery(c, "from"); err != nil {
+		return filter, err
+	}
+
+	if filter.To, err = getOptionalTimeQuery(c, "to"); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
@@ -18,4 +18,14 @@ type Authenticator interface {
 
 	// Logout удаляет сессию и cookie.
 	Logout(ctx context.Context, w http.ResponseWriter, r *http.Request) error
+
+	// ListSessions возвращает активные сессии пользователя, отмечая сессию текущего запроса.
+	ListSessions(ctx context.Context, r *http.Request, userID int) ([]model.Session, error)
+
+	// RevokeSession завершает сессию пользователя по её публичному id.
+	RevokeSession(ctx context.Context, userID int, id string) error
+
+	// RevokeOtherSessions завершает все сессии пользователя, кроме сессии текущего запроса.
+	// Cookie текущей сессии может обновиться.
+	RevokeOtherSessions(ctx context.Context, w http.ResponseWriter, r *http.Request, userID int) error
 }
@@ -0,0 +1,210 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"palback/internal/delivery/http/dto"
+	"palback/internal/domain/model"
+	localErrors "palback/internal/pkg/errors"
+	"palback/internal/pkg/helpers"
+	"palback/internal/usecase"
+	ucModel "palback/internal/usecase/model"
+)
+
+type CityHandler struct {
+	service usecase.CityService
+}
+
+func NewCityHandler(service usecase.CityService) *CityHandler {
+	return &CityHandler{
+		service: service,
+	}
+}
+
+func (h *CityHandler) Get(c echo.Context) error {
+	ctx := c.Request().Context()
+	var data *ucModel.CityDetail
+
+	id, err := getPositiveIntParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	data, err = h.service.Get(ctx, id)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrCityNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError).SetInternal(err)
+		}
+	}
+
+	return c.JSON(http.StatusOK, dto.CreateCityResponse(helpers.FromPtr(data)))
+}
+
+func (h *CityHandler) GetByCountry(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	data, err := h.service.GetByCountry(ctx, c.Param("id"))
+
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrCountryNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError).SetInternal(err)
+		}
+	}
+
+	return c.JSON(http.StatusOK, dto.CreateCityResponseList(data))
+}
+
+func (h *CityHandler) Post(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req dto.CityPostRequest
+
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	data, err := h.service.Create(ctx, model.City{
+		CountryID:  req.CountryID,
+		RegionID:   req.RegionID,
+		CityTypeID: req.CityTypeID,
+		Name:       req.Name,
+		Latitude:   req.Latitude,
+		Longitude:  req.Longitude,
+	})
+
+	if err != nil {
+		switch {
+		case isCityValidationError(err):
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError, "невозможно добавить населенный пункт").SetInternal(err)
+		}
+	}
+
+	dataRec := helpers.FromPtr(data)
+
+	c.Response().Header().Set("location", "/cities/"+strconv.Itoa(dataRec.ID))
+
+	return c.JSON(http.StatusCreated, dto.CreateCityResponse(dataRec))
+}
+
+func (h *CityHandler) Put(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := getPositiveIntParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.CityPutRequest
+
+	if err = bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	err = h.service.Update(ctx, id, model.City{
+		CountryID:  req.CountryID,
+		RegionID:   req.RegionID,
+		CityTypeID: req.CityTypeID,
+		Name:       req.Name,
+		Latitude:   req.Latitude,
+		Longitude:  req.Longitude,
+	})
+
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrCityNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		case isCityValidationError(err):
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError, "невозможно изменить населенный пункт").SetInternal(err)
+		}
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"message": "населенный пункт обновлен"})
+}
+
+func (h *CityHandler) Delete(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := getPositiveIntParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	err = h.service.Delete(ctx, id)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrCityNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError, "невозможно удалить населенный пункт").SetInternal(err)
+		}
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"message": "населенный пункт удален"})
+}
+
+func isCityValidationError(err error) bool {
+	return localErrors.IsOneOf(err,
+		usecase.ErrCountryNotFound,
+		usecase.ErrCountryHasNotRegions,
+		usecase.ErrRegionNotFound,
+		usecase.ErrRegionNotInCountry,
+		usecase.ErrCityRegionRequired,
+		usecase.ErrCityTypeNotFound,
+		usecase.ErrInvalidCoordinates,
+	)
+}
+
+// Revisions Получить историю изменений населенного пункта
+func (h *CityHandler) Revisions(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := getPositiveIntParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	data, err := h.service.GetRevisions(ctx, id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError).SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.CreateRevisionResponseList(data))
+}
+
+// Restore Вернуть населенный пункт к состоянию после указанной ревизии
+func (h *CityHandler) Restore(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := getPositiveIntParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	revisionID, err := getPositiveIntParam(c, "rev")
+	if err != nil {
+		return err
+	}
+
+	err = h.service.Restore(ctx, id, revisionID)
+	if err != nil {
+		return restoreError(err, usecase.ErrCityNotFound, isCityValidationError)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"message": "населенный пункт возвращен к выбранной ревизии"})
+}
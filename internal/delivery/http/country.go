@@ -2,7 +2,6 @@ package http
 
 import (
 	"errors"
-	"fmt"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
@@ -61,15 +60,8 @@ func (h *CountryHandler) Post(c echo.Context) error {
 
 	var req dto.CountryPostRequest
 
-	if err := c.Bind(&req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
-	}
-
-	if !validCountryID.MatchString(req.ID) {
-		return echo.NewHTTPError(
-			http.StatusBadRequest,
-			"id страны должен состоять из строчных латинских букв и иметь в длину от 2 до 6 символов включительно",
-		)
+	if err := bindRequest(c, &req); err != nil {
+		return err
 	}
 
 	data, err := h.service.Create(ctx, model.Country{
@@ -84,10 +76,7 @@ func (h *CountryHandler) Post(c echo.Context) error {
 		case localErrors.IsOneOf(err, usecase.ErrCountryAlreadyAdded, usecase.ErrCountryNameNotUnique):
 			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 		default:
-			return echo.NewHTTPError(
-				http.StatusInternalServerError,
-				fmt.Sprintf(err.Error()),
-			)
+			return echo.NewHTTPError(http.StatusInternalServerError, "невозможно добавить страну").SetInternal(err)
 		}
 	}
 
@@ -104,16 +93,13 @@ func (h *CountryHandler) Put(c echo.Context) error {
 
 	var req dto.CountryPutRequest
 
-	if err := c.Bind(&req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	if err := bindRequest(c, &req); err != nil {
+		return err
 	}
 
 	id := c.Param("id")
 	if !validCountryID.MatchString(id) {
-		return echo.NewHTTPError(
-			http.StatusBadRequest,
-			"id страны должен состоять из строчных латинских букв и иметь в длину от 2 до 6 символов включительно",
-		)
+		return echo.NewHTTPError(http.StatusBadRequest, errInvalidCountryID.Error())
 	}
 
 	err := h.service.Update(ctx, id, model.Country{
@@ -129,10 +115,7 @@ func (h *CountryHandler) Put(c echo.Context) error {
 		case errors.Is(err, usecase.ErrCountryNotFound):
 			return echo.NewHTTPError(http.StatusNotFound, err.Error())
 		default:
-			return echo.NewHTTPError(
-				http.StatusInternalServerError,
-				fmt.Sprintf("невозможно изменить страну: %s", err.Error()),
-			)
+			return echo.NewHTTPError(http.StatusInternalServerError, "невозможно изменить страну").SetInternal(err)
 		}
 	}
 
@@ -150,10 +133,7 @@ func (h *CountryHandler) Delete(c echo.Context) error {
 	}
 
 	if err != nil {
-		return echo.NewHTTPError(
-			http.StatusInternalServerError,
-			fmt.Sprintf("невозможно удалить страну: %s", err.Error()),
-		)
+		return echo.NewHTTPError(http.StatusInternalServerError, "невозможно удалить страну").SetInternal(err)
 	}
 
 	return c.JSON(http.StatusOK, map[string]any{"message": "страна удалена"})
@@ -165,17 +145,43 @@ func (h *CountryHandler) Order(c echo.Context) error {
 
 	var req dto.CountryOrderRequest
 
-	if err := c.Bind(&req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	if err := bindRequest(c, &req); err != nil {
+		return err
 	}
 
 	err := h.service.Order(ctx, req.Order)
 	if err != nil {
-		return echo.NewHTTPError(
-			http.StatusInternalServerError,
-			fmt.Sprintf("невозможно упорядочить страны: %s", err.Error()),
-		)
+		return echo.NewHTTPError(http.StatusInternalServerError, "невозможно упорядочить страны").SetInternal(err)
 	}
 
 	return c.JSON(http.StatusOK, map[string]any{"message": "страны упорядочены"})
 }
+
+// Revisions Получить историю изменений страны
+func (h *CountryHandler) Revisions(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	data, err := h.service.GetRevisions(ctx, c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError).SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.CreateRevisionResponseList(data))
+}
+
+// Restore Вернуть страну к состоянию после указанной ревизии
+func (h *CountryHandler) Restore(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	revisionID, err := getPositiveIntParam(c, "rev")
+	if err != nil {
+		return err
+	}
+
+	err = h.service.Restore(ctx, c.Param("id"), revisionID)
+	if err != nil {
+		return restoreError(err, usecase.ErrCountryNotFound, nil)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"message": "страна возвращена к выбранной ревизии"})
+}
@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"time"
+
+	"palback/internal/domain/model"
+)
+
+type AccessTokenPostRequest struct {
+	Name   string                   `json:"name" validate:"required,max=100"`
+	Scopes []model.AccessTokenScope `json:"scopes" validate:"required,min=1,dive,oneof=read write"`
+
+	// ExpiresInDays Срок действия в днях, 0 — бессрочный токен
+	ExpiresInDays int `json:"expires_in_days" validate:"gte=0"`
+}
+
+type AccessTokenResponse struct {
+	ID         int                      `json:"id"`
+	Name       string                   `json:"name"`
+	Prefix     string                   `json:"prefix"`
+	Scopes     []model.AccessTokenScope `json:"scopes"`
+	CreatedAt  time.Time                `json:"created_at"`
+	LastUsedAt *time.Time               `json:"last_used_at"`
+	ExpiresAt  *time.Time               `json:"expires_at"`
+}
+
+func CreateAccessTokenResponse(src model.AccessToken) AccessTokenResponse {
+	return AccessTokenResponse{
+		ID:         src.ID,
+		Name:       src.Name,
+		Prefix:     src.Prefix,
+		Scopes:     src.Scopes,
+		CreatedAt:  src.CreatedAt,
+		LastUsedAt: src.LastUsedAt,
+		ExpiresAt:  src.ExpiresAt,
+	}
+}
+
+func CreateAccessTokenResponseList(src []model.AccessToken) []AccessTokenResponse {
+	result := make([]AccessTokenResponse, 0, len(src))
+	for _, token := range src {
+		result = append(result, CreateAccessTokenResponse(token))
+	}
+
+	return result
+}
+
+// CreatedAccessTokenResponse Ответ на выпуск токена. Значение token больше нигде не показывается.
+type CreatedAccessTokenResponse struct {
+	AccessTokenResponse
+	Token string `json:"token"`
+}
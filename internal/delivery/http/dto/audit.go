@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"palback/internal/domain/model"
+	ucModel "palback/internal/usecase/model"
+)
+
+type AuditEntryResponse struct {
+	ID         int             `json:"id"`
+	Action     string          `json:"action"`
+	ActorID    *int            `json:"actor_id"`
+	IP         string          `json:"ip"`
+	UserAgent  string          `json:"user_agent"`
+	EntityType string          `json:"entity_type"`
+	EntityID   string          `json:"entity_id"`
+	Payload    json.RawMessage `json:"payload"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+func CreateAuditEntryResponse(src model.AuditEntry) AuditEntryResponse {
+	return AuditEntryResponse{
+		ID:         src.ID,
+		Action:     string(src.Action),
+		ActorID:    src.ActorID,
+		IP:         src.IP,
+		UserAgent:  src.UserAgent,
+		EntityType: src.EntityType,
+		EntityID:   src.EntityID,
+		Payload:    src.Payload,
+		CreatedAt:  src.CreatedAt,
+	}
+}
+
+type AuditEntryResponseList struct {
+	Items []AuditEntryResponse `json:"items"`
+	Total int                  `json:"total"`
+}
+
+func CreateAuditEntryResponseList(src ucModel.AuditList) AuditEntryResponseList {
+	result := AuditEntryResponseList{
+		Items: make([]AuditEntryResponse, 0, len(src.Items)),
+		Total: src.Total,
+	}
+
+	for _, item := range src.Items {
+		result.Items = append(result.Items, CreateAuditEntryResponse(item))
+	}
+
+	return result
+}
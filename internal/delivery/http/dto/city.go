@@ -0,0 +1,78 @@
+package dto
+
+import (
+	"palback/internal/domain/model"
+	ucModel "palback/internal/usecase/model"
+)
+
+type CityPostRequest struct {
+	CountryID  string  `json:"country_id" validate:"required,country_id"`
+	RegionID   *int    `json:"region_id" validate:"omitempty,gt=0"`
+	CityTypeID int     `json:"city_type_id" validate:"required,gt=0"`
+	Name       string  `json:"name" validate:"required,max=255"`
+	Latitude   float64 `json:"latitude" validate:"latitude"`
+	Longitude  float64 `json:"longitude" validate:"longitude"`
+}
+
+type CityPutRequest struct {
+	CountryID  string  `json:"country_id" validate:"required,country_id"`
+	RegionID   *int    `json:"region_id" validate:"omitempty,gt=0"`
+	CityTypeID int     `json:"city_type_id" validate:"required,gt=0"`
+	Name       string  `json:"name" validate:"required,max=255"`
+	Latitude   float64 `json:"latitude" validate:"latitude"`
+	Longitude  float64 `json:"longitude" validate:"longitude"`
+}
+
+type CityRegionResponse struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func CreateCityRegionResponse(src *model.Region) *CityRegionResponse {
+	if src == nil {
+		return nil
+	}
+
+	return &CityRegionResponse{
+		ID:   src.ID,
+		Name: src.Name,
+	}
+}
+
+type CityResponse struct {
+	ID        int                 `json:"id"`
+	Name      string              `json:"name"`
+	Country   CountryResponse     `json:"country"`
+	Region    *CityRegionResponse `json:"region"`
+	CityType  CityTypeResponse    `json:"city_type"`
+	Latitude  float64             `json:"latitude"`
+	Longitude float64             `json:"longitude"`
+}
+
+func CreateCityResponse(src ucModel.CityDetail) CityResponse {
+	return CityResponse{
+		ID:        src.ID,
+		Name:      src.Name,
+		Country:   CreateCountryResponse(src.Country),
+		Region:    CreateCityRegionResponse(src.Region),
+		CityType:  CreateCityTypeResponse(src.CityType),
+		Latitude:  src.Latitude,
+		Longitude: src.Longitude,
+	}
+}
+
+type CityResponseList struct {
+	Items []CityResponse `json:"items"`
+}
+
+func CreateCityResponseList(src ucModel.CityList) CityResponseList {
+	result := CityResponseList{
+		Items: make([]CityResponse, 0, len(src.Items)),
+	}
+
+	for _, item := range src.Items {
+		result.Items = append(result.Items, CreateCityResponse(item))
+	}
+
+	return result
+}
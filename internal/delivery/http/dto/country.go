@@ -3,20 +3,20 @@ package dto
 import "palback/internal/domain/model"
 
 type CountryPostRequest struct {
-	ID         string `json:"id"`
-	Name       string `json:"name"`
+	ID         string `json:"id" validate:"required,country_id"`
+	Name       string `json:"name" validate:"required,max=255"`
 	HasRegions bool   `json:"has_regions"`
 	Weight     int    `json:"weight"`
 }
 
 type CountryPutRequest struct {
-	Name       string `json:"name"`
+	Name       string `json:"name" validate:"required,max=255"`
 	HasRegions bool   `json:"has_regions"`
 	Weight     int    `json:"weight"`
 }
 
 type CountryOrderRequest struct {
-	Order []string `json:"order"`
+	Order []string `json:"order" validate:"required,min=1,dive,country_id"`
 }
 
 type CountryResponse struct {
@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"time"
+
+	"palback/internal/domain/model"
+	ucModel "palback/internal/usecase/model"
+)
+
+// EmailMessageResponse Письмо в очереди. Данные шаблона не возвращаются: в них могут быть токены из ссылок.
+type EmailMessageResponse struct {
+	ID            int        `json:"id"`
+	Kind          string     `json:"kind"`
+	ToEmail       string     `json:"to_email"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	LastError     string     `json:"last_error"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	SentAt        *time.Time `json:"sent_at"`
+}
+
+func CreateEmailMessageResponse(src model.EmailMessage) EmailMessageResponse {
+	return EmailMessageResponse{
+		ID:            src.ID,
+		Kind:          string(src.Kind),
+		ToEmail:       src.ToEmail,
+		Status:        string(src.Status),
+		Attempts:      src.Attempts,
+		LastError:     src.LastError,
+		NextAttemptAt: src.NextAttemptAt,
+		CreatedAt:     src.CreatedAt,
+		SentAt:        src.SentAt,
+	}
+}
+
+type EmailMessageResponseList struct {
+	Items []EmailMessageResponse `json:"items"`
+	Total int                    `json:"total"`
+}
+
+func CreateEmailMessageResponseList(src ucModel.EmailMessageList) EmailMessageResponseList {
+	result := EmailMessageResponseList{
+		Items: make([]EmailMessageResponse, 0, len(src.Items)),
+		Total: src.Total,
+	}
+
+	for _, item := range src.Items {
+		result.Items = append(result.Items, CreateEmailMessageResponse(item))
+	}
+
+	return result
+}
@@ -0,0 +1,17 @@
+package dto
+
+// ErrorResponse Ответ с ошибкой. Code не зависит от языка и предназначен для обработки клиентом,
+// Fields содержит ошибки отдельных полей запроса, Detail — подробности вне production.
+type ErrorResponse struct {
+	Code    string               `json:"code"`
+	Message string               `json:"message"`
+	Fields  []FieldErrorResponse `json:"fields,omitempty"`
+	Detail  string               `json:"detail,omitempty"`
+}
+
+// FieldErrorResponse Ошибка поля тела запроса или параметра: Code — нарушенное правило, например required
+type FieldErrorResponse struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"time"
+
+	"palback/internal/domain/model"
+)
+
+type ExternalLoginCallbackRequest struct {
+	State string `json:"state" validate:"required"`
+	Code  string `json:"code" validate:"required"`
+}
+
+type IdentityResponse struct {
+	ID        int       `json:"id"`
+	Provider  string    `json:"provider"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func CreateIdentityResponse(src model.Identity) IdentityResponse {
+	return IdentityResponse{
+		ID:        src.ID,
+		Provider:  src.Provider,
+		Email:     src.Email,
+		CreatedAt: src.CreatedAt,
+	}
+}
+
+func CreateIdentityResponseList(src []model.Identity) []IdentityResponse {
+	result := make([]IdentityResponse, 0, len(src))
+	for _, identity := range src {
+		result = append(result, CreateIdentityResponse(identity))
+	}
+
+	return result
+}
@@ -0,0 +1,95 @@
+package dto
+
+import ucModel "palback/internal/usecase/model"
+
+type PlacePostRequest struct {
+	PlaceTypeID  int     `json:"place_type_id" validate:"required,gt=0"`
+	CityID       int     `json:"city_id" validate:"required,gt=0"`
+	Name         string  `json:"name" validate:"required,max=255"`
+	Address      string  `json:"address" validate:"max=500"`
+	Latitude     float64 `json:"latitude" validate:"latitude"`
+	Longitude    float64 `json:"longitude" validate:"longitude"`
+	Description  string  `json:"description"`
+	FoundingYear *int    `json:"founding_year" validate:"omitempty,gt=0"`
+	Status       string  `json:"status"`
+}
+
+type PlacePutRequest struct {
+	PlaceTypeID  int     `json:"place_type_id" validate:"required,gt=0"`
+	CityID       int     `json:"city_id" validate:"required,gt=0"`
+	Name         string  `json:"name" validate:"required,max=255"`
+	Address      string  `json:"address" validate:"max=500"`
+	Latitude     float64 `json:"latitude" validate:"latitude"`
+	Longitude    float64 `json:"longitude" validate:"longitude"`
+	Description  string  `json:"description"`
+	FoundingYear *int    `json:"founding_year" validate:"omitempty,gt=0"`
+	Status       string  `json:"status"`
+}
+
+type PlaceResponse struct {
+	ID           int               `json:"id"`
+	Name         string            `json:"name"`
+	PlaceType    PlaceTypeResponse `json:"place_type"`
+	City         CityResponse      `json:"city"`
+	Address      string            `json:"address"`
+	Latitude     float64           `json:"latitude"`
+	Longitude    float64           `json:"longitude"`
+	Description  string            `json:"description"`
+	FoundingYear *int              `json:"founding_year"`
+	Status       string            `json:"status"`
+}
+
+func CreatePlaceResponse(src ucModel.PlaceDetail) PlaceResponse {
+	return PlaceResponse{
+		ID:           src.ID,
+		Name:         src.Name,
+		PlaceType:    CreatePlaceTypeResponse(src.PlaceType),
+		City:         CreateCityResponse(src.City),
+		Address:      src.Address,
+		Latitude:     src.Latitude,
+		Longitude:    src.Longitude,
+		Description:  src.Description,
+		FoundingYear: src.FoundingYear,
+		Status:       string(src.Status),
+	}
+}
+
+type PlaceResponseList struct {
+	Items []PlaceResponse `json:"items"`
+}
+
+func CreatePlaceResponseList(src ucModel.PlaceList) PlaceResponseList {
+	result := PlaceResponseList{
+		Items: make([]PlaceResponse, 0, len(src.Items)),
+	}
+
+	for _, item := range src.Items {
+		result.Items = append(result.Items, CreatePlaceResponse(item))
+	}
+
+	return result
+}
+
+type PlaceNearbyResponse struct {
+	PlaceResponse
+	DistanceKm float64 `json:"distance_km"`
+}
+
+type PlaceNearbyResponseList struct {
+	Items []PlaceNearbyResponse `json:"items"`
+}
+
+func CreatePlaceNearbyResponseList(src ucModel.PlaceNearbyList) PlaceNearbyResponseList {
+	result := PlaceNearbyResponseList{
+		Items: make([]PlaceNearbyResponse, 0, len(src.Items)),
+	}
+
+	for _, item := range src.Items {
+		result.Items = append(result.Items, PlaceNearbyResponse{
+			PlaceResponse: CreatePlaceResponse(item.PlaceDetail),
+			DistanceKm:    item.DistanceKm,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,83 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"palback/internal/domain/model"
+)
+
+type PlacePhotoPutRequest struct {
+	Caption  string `json:"caption" validate:"max=1000"`
+	License  string `json:"license" validate:"max=255"`
+	Position int    `json:"position" validate:"gte=0"`
+}
+
+type PlacePhotoResponse struct {
+	ID          int       `json:"id"`
+	PlaceID     int       `json:"place_id"`
+	AuthorID    *int      `json:"author_id"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	Caption     string    `json:"caption"`
+	License     string    `json:"license"`
+	Position    int       `json:"position"`
+	IsCover     bool      `json:"is_cover"`
+	CreatedAt   time.Time `json:"created_at"`
+
+	// Variants Ссылки на варианты фотографии по названию варианта и формату
+	Variants map[model.ImageVariantName]map[model.ImageFormat]string `json:"variants"`
+}
+
+func CreatePlacePhotoResponse(src model.PlacePhoto) PlacePhotoResponse {
+	return PlacePhotoResponse{
+		ID:          src.ID,
+		PlaceID:     src.PlaceID,
+		AuthorID:    src.AuthorID,
+		ContentType: src.ContentType,
+		Size:        src.Size,
+		Caption:     src.Caption,
+		License:     src.License,
+		Position:    src.Position,
+		IsCover:     src.IsCover,
+		CreatedAt:   src.CreatedAt,
+		Variants:    createPhotoVariantURLs(src),
+	}
+}
+
+func createPhotoVariantURLs(src model.PlacePhoto) map[model.ImageVariantName]map[model.ImageFormat]string {
+	result := make(map[model.ImageVariantName]map[model.ImageFormat]string, len(model.PhotoVariantSpecs))
+
+	for _, spec := range model.PhotoVariantSpecs {
+		urls := make(map[model.ImageFormat]string, len(model.ImageFormats))
+
+		for _, format := range model.ImageFormats {
+			urls[format] = fmt.Sprintf(
+				"/places/%d/photos/%d/%s",
+				src.PlaceID,
+				src.ID,
+				model.ImageVariantFile(spec.Name, format),
+			)
+		}
+
+		result[spec.Name] = urls
+	}
+
+	return result
+}
+
+type PlacePhotoResponseList struct {
+	Items []PlacePhotoResponse `json:"items"`
+}
+
+func CreatePlacePhotoResponseList(src []model.PlacePhoto) PlacePhotoResponseList {
+	result := PlacePhotoResponseList{
+		Items: make([]PlacePhotoResponse, 0, len(src)),
+	}
+
+	for _, item := range src {
+		result.Items = append(result.Items, CreatePlacePhotoResponse(item))
+	}
+
+	return result
+}
@@ -0,0 +1,94 @@
+package dto
+
+import (
+	"time"
+
+	"palback/internal/domain/model"
+)
+
+type PlaceSubmissionPostRequest struct {
+	PlaceID *int `json:"place_id" validate:"omitempty,gt=0"`
+	PlaceSubmissionPutRequest
+}
+
+// PlaceSubmissionPutRequest Данные святого места в предложении. Черновик может быть заполнен не полностью,
+// обязательные поля проверяются при отправке на модерацию.
+type PlaceSubmissionPutRequest struct {
+	PlaceTypeID  int     `json:"place_type_id" validate:"omitempty,gt=0"`
+	CityID       int     `json:"city_id" validate:"omitempty,gt=0"`
+	Name         string  `json:"name" validate:"max=255"`
+	Address      string  `json:"address" validate:"max=500"`
+	Latitude     float64 `json:"latitude" validate:"latitude"`
+	Longitude    float64 `json:"longitude" validate:"longitude"`
+	Description  string  `json:"description"`
+	FoundingYear *int    `json:"founding_year" validate:"omitempty,gt=0"`
+	Status       string  `json:"status"`
+}
+
+type PlaceSubmissionRejectRequest struct {
+	Reason string `json:"reason" validate:"required,max=2000"`
+}
+
+type PlaceSubmissionDataResponse struct {
+	PlaceTypeID  int     `json:"place_type_id"`
+	CityID       int     `json:"city_id"`
+	Name         string  `json:"name"`
+	Address      string  `json:"address"`
+	Latitude     float64 `json:"latitude"`
+	Longitude    float64 `json:"longitude"`
+	Description  string  `json:"description"`
+	FoundingYear *int    `json:"founding_year"`
+	Status       string  `json:"status"`
+}
+
+type PlaceSubmissionResponse struct {
+	ID           int                         `json:"id"`
+	PlaceID      *int                        `json:"place_id"`
+	AuthorID     *int                        `json:"author_id"`
+	ModeratorID  *int                        `json:"moderator_id"`
+	State        string                      `json:"state"`
+	RejectReason string                      `json:"reject_reason,omitempty"`
+	Data         PlaceSubmissionDataResponse `json:"data"`
+	CreatedAt    time.Time                   `json:"created_at"`
+	UpdatedAt    time.Time                   `json:"updated_at"`
+}
+
+func CreatePlaceSubmissionResponse(src model.PlaceSubmission) PlaceSubmissionResponse {
+	return PlaceSubmissionResponse{
+		ID:           src.ID,
+		PlaceID:      src.PlaceID,
+		AuthorID:     src.AuthorID,
+		ModeratorID:  src.ModeratorID,
+		State:        string(src.State),
+		RejectReason: src.RejectReason,
+		Data: PlaceSubmissionDataResponse{
+			PlaceTypeID:  src.Place.PlaceTypeID,
+			CityID:       src.Place.CityID,
+			Name:         src.Place.Name,
+			Address:      src.Place.Address,
+			Latitude:     src.Place.Latitude,
+			Longitude:    src.Place.Longitude,
+			Description:  src.Place.Description,
+			FoundingYear: src.Place.FoundingYear,
+			Status:       string(src.Place.Status),
+		},
+		CreatedAt: src.CreatedAt,
+		UpdatedAt: src.UpdatedAt,
+	}
+}
+
+type PlaceSubmissionResponseList struct {
+	Items []PlaceSubmissionResponse `json:"items"`
+}
+
+func CreatePlaceSubmissionResponseList(src []model.PlaceSubmission) PlaceSubmissionResponseList {
+	result := PlaceSubmissionResponseList{
+		Items: make([]PlaceSubmissionResponse, 0, len(src)),
+	}
+
+	for _, item := range src {
+		result.Items = append(result.Items, CreatePlaceSubmissionResponse(item))
+	}
+
+	return result
+}
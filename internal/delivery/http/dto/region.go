@@ -3,13 +3,13 @@ package dto
 import ucModel "palback/internal/usecase/model"
 
 type RegionPostRequest struct {
-	CountryID string `json:"country_id"`
-	Name      string `json:"name"`
+	CountryID string `json:"country_id" validate:"required,country_id"`
+	Name      string `json:"name" validate:"required,max=255"`
 }
 
 type RegionPutRequest struct {
-	CountryID string `json:"country_id"`
-	Name      string `json:"name"`
+	CountryID string `json:"country_id" validate:"required,country_id"`
+	Name      string `json:"name" validate:"required,max=255"`
 }
 
 type RegionResponse struct {
@@ -0,0 +1,59 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	ucModel "palback/internal/usecase/model"
+)
+
+type FieldChangeResponse struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+type RevisionResponse struct {
+	ID        int                            `json:"id"`
+	Action    string                         `json:"action"`
+	AuthorID  *int                           `json:"author_id"`
+	Before    json.RawMessage                `json:"before"`
+	After     json.RawMessage                `json:"after"`
+	Changes   map[string]FieldChangeResponse `json:"changes"`
+	CreatedAt time.Time                      `json:"created_at"`
+}
+
+func CreateRevisionResponse(src ucModel.RevisionDetail) RevisionResponse {
+	changes := make(map[string]FieldChangeResponse, len(src.Changes))
+	for field, change := range src.Changes {
+		changes[field] = FieldChangeResponse{
+			Before: change.Before,
+			After:  change.After,
+		}
+	}
+
+	return RevisionResponse{
+		ID:        src.ID,
+		Action:    string(src.Action),
+		AuthorID:  src.AuthorID,
+		Before:    src.Before,
+		After:     src.After,
+		Changes:   changes,
+		CreatedAt: src.CreatedAt,
+	}
+}
+
+type RevisionResponseList struct {
+	Items []RevisionResponse `json:"items"`
+}
+
+func CreateRevisionResponseList(src ucModel.RevisionList) RevisionResponseList {
+	result := RevisionResponseList{
+		Items: make([]RevisionResponse, 0, len(src.Items)),
+	}
+
+	for _, item := range src.Items {
+		result.Items = append(result.Items, CreateRevisionResponse(item))
+	}
+
+	return result
+}
@@ -13,3 +13,48 @@ func CreateRoleResponse(src model.Role) RoleResponse {
 		Name: src.Name,
 	}
 }
+
+type RolePermissionsPutRequest struct {
+	Permissions []string `json:"permissions"`
+}
+
+// RoleDetailResponse Роль вместе с выданными ей правами
+type RoleDetailResponse struct {
+	RoleResponse
+	Permissions []string `json:"permissions"`
+}
+
+func CreateRoleDetailResponse(src model.Role) RoleDetailResponse {
+	return RoleDetailResponse{
+		RoleResponse: CreateRoleResponse(src),
+		Permissions:  createPermissionList(src.Permissions),
+	}
+}
+
+func CreateRoleDetailResponseList(src []model.Role) []RoleDetailResponse {
+	result := make([]RoleDetailResponse, 0, len(src))
+	for _, role := range src {
+		result = append(result, CreateRoleDetailResponse(role))
+	}
+
+	return result
+}
+
+type PermissionListResponse struct {
+	Permissions []string `json:"permissions"`
+}
+
+func CreatePermissionListResponse(src []model.Permission) PermissionListResponse {
+	return PermissionListResponse{
+		Permissions: createPermissionList(src),
+	}
+}
+
+func createPermissionList(src []model.Permission) []string {
+	result := make([]string, 0, len(src))
+	for _, permission := range src {
+		result = append(result, string(permission))
+	}
+
+	return result
+}
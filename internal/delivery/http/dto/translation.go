@@ -0,0 +1,31 @@
+package dto
+
+import "palback/internal/domain/model"
+
+type TranslationPutRequest struct {
+	Name      string `json:"name" validate:"required,max=255"`
+	ShortName string `json:"short_name" validate:"max=50"`
+}
+
+type TranslationResponse struct {
+	Lang      string `json:"lang"`
+	Name      string `json:"name"`
+	ShortName string `json:"short_name,omitempty"`
+}
+
+func CreateTranslationResponse(src model.Translation) TranslationResponse {
+	return TranslationResponse{
+		Lang:      src.Lang,
+		Name:      src.Name,
+		ShortName: src.ShortName,
+	}
+}
+
+func CreateTranslationResponseList(src []model.Translation) []TranslationResponse {
+	result := make([]TranslationResponse, 0, len(src))
+	for _, translation := range src {
+		result = append(result, CreateTranslationResponse(translation))
+	}
+
+	return result
+}
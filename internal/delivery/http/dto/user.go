@@ -1,15 +1,19 @@
 package dto
 
 import (
+	"fmt"
+	"net/url"
 	"time"
 
+	"palback/internal/domain/model"
+	"palback/internal/pkg/helpers"
 	ucModel "palback/internal/usecase/model"
 )
 
 type RegisterRequest struct {
-	Username string `json:"username" validate:"required"`
-	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required,min=6"`
+	Username string `json:"username" validate:"required,username"`
+	Email    string `json:"email" validate:"required,email,max=255"`
+	Password string `json:"password" validate:"required"`
 }
 
 type LoginRequest struct {
@@ -17,6 +21,20 @@ type LoginRequest struct {
 	Password   string `json:"password" validate:"required"`
 }
 
+type LoginTwoFactorRequest struct {
+	Token string `json:"token" validate:"required"`
+	Code  string `json:"code" validate:"required"` // код из приложения или резервный код
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type DisableTwoFactorRequest struct {
+	Password string `json:"password" validate:"required"`
+	Code     string `json:"code" validate:"required"`
+}
+
 type ResetPasswordRequest struct {
 	Email string `json:"email" validate:"required,email"`
 }
@@ -27,27 +45,246 @@ type VerifyEmailRequest struct {
 
 type ResetPasswordConfirmRequest struct {
 	Token       string `json:"token" validate:"required"`
-	NewPassword string `json:"new_password" validate:"required,min=6"`
+	NewPassword string `json:"new_password" validate:"required"`
+}
+
+type UnlockAccountRequest struct {
+	Token string `json:"token" validate:"required"`
 }
 
 type ResendVerificationRequest struct {
-	Email string `json:"email"`
+	Email string `json:"email" validate:"required,email"`
+}
+
+type UserProfilePutRequest struct {
+	DisplayName string `json:"display_name" validate:"max=100"`
+	About       string `json:"about" validate:"max=2000"`
+	CityID      *int   `json:"city_id" validate:"omitempty,gt=0"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required"`
+}
+
+type ChangeEmailRequest struct {
+	Password string `json:"password" validate:"required"`
+	Email    string `json:"email" validate:"required,email,max=255"`
+}
+
+type ConfirmEmailChangeRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type DeleteAccountRequest struct {
+	Password string `json:"password" validate:"required"`
+}
+
+type DeleteAccountResponse struct {
+	Message  string    `json:"message"`
+	DeleteAt time.Time `json:"delete_at"`
 }
 
 type UserResponse struct {
-	ID        int          `json:"id"`
-	Username  string       `json:"username"`
-	Email     string       `json:"email"`
-	CreatedAt time.Time    `json:"created_at"`
-	Role      RoleResponse `json:"role"`
+	ID          int          `json:"id"`
+	Username    string       `json:"username"`
+	Email       string       `json:"email"`
+	DisplayName string       `json:"display_name"`
+	AvatarURL   *string      `json:"avatar_url"`
+	CreatedAt   time.Time    `json:"created_at"`
+	Role        RoleResponse `json:"role"`
+
+	TwoFactorEnabled bool `json:"two_factor_enabled"`
 }
 
 func CreateUserResponse(src ucModel.UserDetail) UserResponse {
 	return UserResponse{
-		ID:        src.ID,
-		Username:  src.Username,
-		Email:     src.Email,
-		CreatedAt: src.CreatedAt,
-		Role:      CreateRoleResponse(src.Role),
+		ID:          src.ID,
+		Username:    src.Username,
+		Email:       src.Email,
+		DisplayName: src.Profile.DisplayName,
+		AvatarURL:   createAvatarURL(src.User),
+		CreatedAt:   src.CreatedAt,
+		Role:        CreateRoleResponse(src.Role),
+
+		TwoFactorEnabled: src.TwoFactorEnabled(),
+	}
+}
+
+type UserProfileResponse struct {
+	ID          int           `json:"id"`
+	Username    string        `json:"username"`
+	DisplayName string        `json:"display_name"`
+	About       string        `json:"about"`
+	City        *CityResponse `json:"city"`
+	AvatarURL   *string       `json:"avatar_url"`
+}
+
+func CreateUserProfileResponse(src ucModel.UserProfileDetail) UserProfileResponse {
+	result := UserProfileResponse{
+		ID:          src.ID,
+		Username:    src.Username,
+		DisplayName: src.Profile.DisplayName,
+		About:       src.Profile.About,
+		AvatarURL:   createAvatarURL(src.User),
+	}
+
+	if src.City != nil {
+		result.City = helpers.ToPtr(CreateCityResponse(*src.City))
+	}
+
+	return result
+}
+
+// createAvatarURL Ссылка на аватар пользователя, nil если аватар не загружен.
+// Параметр v содержит версию аватара, поэтому ссылка меняется при загрузке нового.
+func createAvatarURL(user model.User) *string {
+	if user.AvatarKey == "" {
+		return nil
+	}
+
+	return helpers.ToPtr(fmt.Sprintf(
+		"/users/%d/avatar/%s?v=%s",
+		user.ID,
+		model.ImageVariantFile(model.ImageVariantAvatar, model.ImageFormatJPEG),
+		url.QueryEscape(user.AvatarVersion()),
+	))
+}
+
+type SessionResponse struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	Current    bool      `json:"current"`
+}
+
+func CreateSessionResponse(src model.Session) SessionResponse {
+	return SessionResponse{
+		ID:         src.ID,
+		UserAgent:  src.UserAgent,
+		IP:         src.IP,
+		CreatedAt:  src.CreatedAt,
+		LastSeenAt: src.LastSeenAt,
+		Current:    src.Current,
+	}
+}
+
+// LoginResponse Ответ на вход. Если требуется второй шаг, user не заполнен, а two_factor_token
+// нужно передать вместе с кодом в /users/login/2fa.
+type LoginResponse struct {
+	User              *UserResponse           `json:"user,omitempty"`
+	TwoFactorRequired bool                    `json:"two_factor_required"`
+	TwoFactorToken    string                  `json:"two_factor_token,omitempty"`
+	TwoFactorSetup    *TwoFactorSetupResponse `json:"two_factor_setup,omitempty"`
+	RecoveryCodes     []string                `json:"recovery_codes,omitempty"`
+}
+
+func CreateLoginResponse(src ucModel.LoginResult) LoginResponse {
+	result := LoginResponse{
+		TwoFactorRequired: src.User == nil,
+		TwoFactorToken:    src.TwoFactorToken,
+		RecoveryCodes:     src.RecoveryCodes,
+	}
+
+	if src.User != nil {
+		result.User = helpers.ToPtr(CreateUserResponse(*src.User))
+	}
+
+	if src.TwoFactorSetup != nil {
+		result.TwoFactorSetup = helpers.ToPtr(CreateTwoFactorSetupResponse(*src.TwoFactorSetup))
+	}
+
+	return result
+}
+
+type TwoFactorSetupResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"` // otpauth:// для QR-кода
+}
+
+func CreateTwoFactorSetupResponse(src ucModel.TwoFactorSetup) TwoFactorSetupResponse {
+	return TwoFactorSetupResponse{
+		Secret: src.Secret,
+		URI:    src.URI,
+	}
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type LoginLockoutResponse struct {
+	UserID       int        `json:"user_id"`
+	Username     string     `json:"username"`
+	Email        string     `json:"email"`
+	FailedCount  int        `json:"failed_count"`
+	LastFailedAt *time.Time `json:"last_failed_at"`
+	LockedUntil  *time.Time `json:"locked_until"`
+	Locked       bool       `json:"locked"`
+}
+
+func CreateLoginLockoutResponse(src model.User, now time.Time) LoginLockoutResponse {
+	return LoginLockoutResponse{
+		UserID:       src.ID,
+		Username:     src.Username,
+		Email:        src.Email,
+		FailedCount:  src.LoginFailures.Count,
+		LastFailedAt: src.LoginFailures.LastFailedAt,
+		LockedUntil:  src.LoginFailures.LockedUntil,
+		Locked:       src.LoginFailures.IsLocked(now),
+	}
+}
+
+func CreateLoginLockoutResponseList(src []model.User, now time.Time) []LoginLockoutResponse {
+	result := make([]LoginLockoutResponse, 0, len(src))
+	for _, user := range src {
+		result = append(result, CreateLoginLockoutResponse(user, now))
 	}
+
+	return result
+}
+
+type ChangeRoleRequest struct {
+	Role string `json:"role" validate:"required"`
+}
+
+// AdminUserResponse Сведения о пользователе для администратора
+type AdminUserResponse struct {
+	UserResponse
+	EmailVerified       bool       `json:"email_verified"`
+	Blocked             bool       `json:"blocked"`
+	BlockedAt           *time.Time `json:"blocked_at"`
+	DeletionRequestedAt *time.Time `json:"deletion_requested_at"`
+	LockedUntil         *time.Time `json:"locked_until"`
+}
+
+func CreateAdminUserResponse(src ucModel.UserDetail) AdminUserResponse {
+	return AdminUserResponse{
+		UserResponse:        CreateUserResponse(src),
+		EmailVerified:       src.EmailVerified,
+		Blocked:             src.IsBlocked(),
+		BlockedAt:           src.BlockedAt,
+		DeletionRequestedAt: src.DeletionRequestedAt,
+		LockedUntil:         src.LoginFailures.LockedUntil,
+	}
+}
+
+type AdminUserResponseList struct {
+	Items []AdminUserResponse `json:"items"`
+	Total int                 `json:"total"`
+}
+
+func CreateAdminUserResponseList(src ucModel.UserList) AdminUserResponseList {
+	result := AdminUserResponseList{
+		Items: make([]AdminUserResponse, 0, len(src.Items)),
+		Total: src.Total,
+	}
+
+	for _, item := range src.Items {
+		result.Items = append(result.Items, CreateAdminUserResponse(item))
+	}
+
+	return result
 }
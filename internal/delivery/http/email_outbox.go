@@ -0,0 +1,99 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"palback/internal/delivery/http/dto"
+	"palback/internal/domain/model"
+	localErrors "palback/internal/pkg/errors"
+	"palback/internal/pkg/helpers"
+	"palback/internal/usecase"
+)
+
+type EmailOutboxHandler struct {
+	service usecase.EmailOutboxService
+}
+
+func NewEmailOutboxHandler(service usecase.EmailOutboxService) *EmailOutboxHandler {
+	return &EmailOutboxHandler{
+		service: service,
+	}
+}
+
+// GetList Получить страницу писем из очереди с фильтром по состоянию, виду и адресу
+func (h *EmailOutboxHandler) GetList(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	filter := model.EmailFilter{
+		Status:  model.EmailStatus(strings.TrimSpace(c.QueryParam("status"))),
+		Kind:    model.EmailKind(strings.TrimSpace(c.QueryParam("kind"))),
+		ToEmail: strings.ToLower(strings.TrimSpace(c.QueryParam("to_email"))),
+	}
+
+	var err error
+
+	if filter.Limit, err = getNonNegativeIntQuery(c, "limit"); err != nil {
+		return err
+	}
+
+	if filter.Offset, err = getNonNegativeIntQuery(c, "offset"); err != nil {
+		return err
+	}
+
+	data, err := h.service.GetList(ctx, filter)
+	if err != nil {
+		return emailError(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.CreateEmailMessageResponseList(data))
+}
+
+func (h *EmailOutboxHandler) Get(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := getPositiveIntParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	data, err := h.service.Get(ctx, id)
+	if err != nil {
+		return emailError(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.CreateEmailMessageResponse(helpers.FromPtr(data)))
+}
+
+// Resend Вернуть неотправленное письмо в очередь
+func (h *EmailOutboxHandler) Resend(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := getPositiveIntParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	err = h.service.Resend(ctx, id)
+	if err != nil {
+		return emailError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"message": "письмо поставлено в очередь на отправку"})
+}
+
+func emailError(err error) error {
+	switch {
+	case errors.Is(err, usecase.ErrEmailNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	case localErrors.IsOneOf(err, usecase.ErrEmailAlreadySent, usecase.ErrEmailTokenExpired):
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	case errors.Is(err, usecase.ErrEmailInvalidStatus):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	default:
+		return echo.NewHTTPError(http.StatusInternalServerError, "ошибка обработки очереди писем").SetInternal(err)
+	}
+}
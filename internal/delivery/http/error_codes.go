@@ -0,0 +1,184 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	mwApp "palback/internal/delivery/http/middleware"
+	localErrors "palback/internal/pkg/errors"
+	"palback/internal/usecase"
+)
+
+// errorCode Стабильный машиночитаемый код ошибки и HTTP-статус, с которым она возвращается,
+// если обработчик вернул ошибку как есть, не выбрав статус сам
+type errorCode struct {
+	err    error
+	status int
+	code   string
+}
+
+const (
+	codeValidationFailed = "validation_failed"
+	codeInvalidParameter = "invalid_parameter"
+	codeInternalError    = "internal_server_error"
+)
+
+var errorCodes = []errorCode{
+	{usecase.ErrCountryNotFound, http.StatusNotFound, "country_not_found"},
+	{usecase.ErrCountryAlreadyAdded, http.StatusConflict, "country_already_added"},
+	{usecase.ErrCountryNameNotUnique, http.StatusConflict, "country_name_not_unique"},
+
+	{usecase.ErrCountryHasNotRegions, http.StatusBadRequest, "country_has_no_regions"},
+	{usecase.ErrRegionNotFound, http.StatusNotFound, "region_not_found"},
+	{usecase.ErrRegionNotUnique, http.StatusConflict, "region_not_unique"},
+
+	{usecase.ErrCityTypeNotFound, http.StatusNotFound, "city_type_not_found"},
+
+	{usecase.ErrCityNotFound, http.StatusNotFound, "city_not_found"},
+	{usecase.ErrCityRegionRequired, http.StatusBadRequest, "city_region_required"},
+	{usecase.ErrRegionNotInCountry, http.StatusBadRequest, "region_not_in_country"},
+	{usecase.ErrInvalidCoordinates, http.StatusBadRequest, "invalid_coordinates"},
+
+	{usecase.ErrPlaceTypeNotFound, http.StatusNotFound, "place_type_not_found"},
+
+	{usecase.ErrPlaceNotFound, http.StatusNotFound, "place_not_found"},
+	{usecase.ErrPlaceInvalidStatus, http.StatusBadRequest, "place_invalid_status"},
+	{usecase.ErrPlaceInvalidFoundingYear, http.StatusBadRequest, "place_invalid_founding_year"},
+	{usecase.ErrPlaceInvalidRadius, http.StatusBadRequest, "place_invalid_radius"},
+	{usecase.ErrInvalidBoundingBox, http.StatusBadRequest, "invalid_bounding_box"},
+
+	{usecase.ErrSubmissionNotFound, http.StatusNotFound, "submission_not_found"},
+	{usecase.ErrSubmissionForbidden, http.StatusForbidden, "submission_forbidden"},
+	{usecase.ErrSubmissionNotEditable, http.StatusConflict, "submission_not_editable"},
+	{usecase.ErrSubmissionInvalidState, http.StatusConflict, "submission_invalid_state"},
+	{usecase.ErrSubmissionReasonRequired, http.StatusBadRequest, "submission_reason_required"},
+
+	{usecase.ErrImageEmpty, http.StatusBadRequest, "image_empty"},
+	{usecase.ErrImageTooLarge, http.StatusRequestEntityTooLarge, "image_too_large"},
+	{usecase.ErrImageTooManyPixels, http.StatusRequestEntityTooLarge, "image_too_many_pixels"},
+	{usecase.ErrImageUnsupportedType, http.StatusUnsupportedMediaType, "image_unsupported_type"},
+	{localErrors.ErrInvalidImage, http.StatusBadRequest, "image_invalid"},
+
+	{usecase.ErrPhotoNotFound, http.StatusNotFound, "photo_not_found"},
+	{usecase.ErrPhotoForbidden, http.StatusForbidden, "photo_forbidden"},
+
+	{usecase.ErrRevisionNotFound, http.StatusNotFound, "revision_not_found"},
+	{usecase.ErrRevisionNotRestorable, http.StatusConflict, "revision_not_restorable"},
+	{usecase.ErrRevisionEntityDeleted, http.StatusConflict, "revision_entity_deleted"},
+
+	{usecase.ErrTranslationNotFound, http.StatusNotFound, "translation_not_found"},
+	{usecase.ErrTranslationInvalidEntity, http.StatusBadRequest, "translation_invalid_entity"},
+	{usecase.ErrTranslationEntityNotFound, http.StatusNotFound, "translation_entity_not_found"},
+	{usecase.ErrTranslationLangNotSupported, http.StatusBadRequest, "translation_lang_not_supported"},
+	{usecase.ErrTranslationDefaultLang, http.StatusBadRequest, "translation_default_lang"},
+	{usecase.ErrTranslationNameRequired, http.StatusBadRequest, "translation_name_required"},
+
+	{usecase.ErrUserNotFound, http.StatusNotFound, "user_not_found"},
+	{usecase.ErrUserBlocked, http.StatusForbidden, "user_blocked"},
+	{usecase.ErrUserSelfAdministration, http.StatusConflict, "user_self_administration"},
+	{usecase.ErrProfileDisplayNameTooLong, http.StatusBadRequest, "profile_display_name_too_long"},
+	{usecase.ErrProfileAboutTooLong, http.StatusBadRequest, "profile_about_too_long"},
+	{usecase.ErrAvatarNotFound, http.StatusNotFound, "avatar_not_found"},
+
+	{usecase.ErrRoleNotFound, http.StatusNotFound, "role_not_found"},
+	{usecase.ErrRoleNotEditable, http.StatusConflict, "role_not_editable"},
+	{usecase.ErrRoleAssignForbidden, http.StatusForbidden, "role_assign_forbidden"},
+	{usecase.ErrRoleInvalidPermission, http.StatusBadRequest, "role_invalid_permission"},
+
+	{usecase.ErrInvalidPassword, http.StatusUnauthorized, "invalid_password"},
+	{usecase.ErrAccountDeletionAlreadyRequested, http.StatusConflict, "account_deletion_already_requested"},
+
+	{usecase.ErrPasswordTooShort, http.StatusBadRequest, "password_too_short"},
+	{usecase.ErrPasswordTooLong, http.StatusBadRequest, "password_too_long"},
+	{usecase.ErrPasswordTooSimple, http.StatusBadRequest, "password_too_simple"},
+	{usecase.ErrPasswordContainsPersonalData, http.StatusBadRequest, "password_contains_personal_data"},
+	{usecase.ErrPasswordBreached, http.StatusBadRequest, "password_breached"},
+
+	{usecase.ErrUserNameNotUnique, http.StatusConflict, "user_name_not_unique"},
+	{usecase.ErrEmailNotChanged, http.StatusBadRequest, "email_not_changed"},
+	{usecase.ErrUserEmailNotUnique, http.StatusConflict, "user_email_not_unique"},
+	{usecase.ErrUserInvalidCredentials, http.StatusUnauthorized, "invalid_credentials"},
+	{usecase.ErrLoginThrottled, http.StatusTooManyRequests, "login_throttled"},
+	{usecase.ErrAccountLocked, http.StatusLocked, "account_locked"},
+	{usecase.ErrUnauthenticated, http.StatusUnauthorized, "unauthenticated"},
+	{usecase.ErrUncheckedEmail, http.StatusForbidden, "email_not_verified"},
+	{usecase.ErrInvalidToken, http.StatusBadRequest, "invalid_token"},
+	{usecase.ErrSessionExpired, http.StatusUnauthorized, "session_expired"},
+	{usecase.ErrSessionNotFound, http.StatusNotFound, "session_not_found"},
+
+	{usecase.ErrAccessTokenNotFound, http.StatusNotFound, "access_token_not_found"},
+	{usecase.ErrAccessTokenNameRequired, http.StatusBadRequest, "access_token_name_required"},
+	{usecase.ErrAccessTokenNameTooLong, http.StatusBadRequest, "access_token_name_too_long"},
+	{usecase.ErrAccessTokenInvalidScope, http.StatusBadRequest, "access_token_invalid_scope"},
+	{usecase.ErrAccessTokenInvalidExpiry, http.StatusBadRequest, "access_token_invalid_expiry"},
+	{usecase.ErrAccessTokenLimitReached, http.StatusConflict, "access_token_limit_reached"},
+	{usecase.ErrAccessTokenScopeDenied, http.StatusForbidden, "access_token_scope_denied"},
+
+	{usecase.ErrIdentityProviderNotFound, http.StatusNotFound, "identity_provider_not_found"},
+	{usecase.ErrIdentityNotFound, http.StatusNotFound, "identity_not_found"},
+	{usecase.ErrIdentityAlreadyLinked, http.StatusConflict, "identity_already_linked"},
+	{usecase.ErrIdentityEmailNotVerified, http.StatusForbidden, "identity_email_not_verified"},
+	{usecase.ErrIdentityEmailConflict, http.StatusConflict, "identity_email_conflict"},
+	{usecase.ErrIdentityLastLoginMethod, http.StatusConflict, "identity_last_login_method"},
+
+	{usecase.ErrTwoFactorInvalidCode, http.StatusUnauthorized, "two_factor_invalid_code"},
+	{usecase.ErrTwoFactorAlreadyEnabled, http.StatusConflict, "two_factor_already_enabled"},
+	{usecase.ErrTwoFactorNotEnabled, http.StatusConflict, "two_factor_not_enabled"},
+	{usecase.ErrTwoFactorSetupNotStarted, http.StatusConflict, "two_factor_setup_not_started"},
+	{usecase.ErrTwoFactorRequired, http.StatusForbidden, "two_factor_required"},
+	{usecase.ErrTwoFactorTooManyAttempts, http.StatusTooManyRequests, "two_factor_too_many_attempts"},
+
+	{usecase.ErrEmailNotFound, http.StatusNotFound, "email_not_found"},
+	{usecase.ErrEmailAlreadySent, http.StatusConflict, "email_already_sent"},
+	{usecase.ErrEmailInvalidStatus, http.StatusBadRequest, "email_invalid_status"},
+	{usecase.ErrEmailTokenExpired, http.StatusConflict, "email_token_expired"},
+
+	{usecase.ErrNoReplyFromKeyValueStorage, http.StatusBadRequest, "invalid_token"},
+	{usecase.ErrKeyNotFound, http.StatusNotFound, "not_found"},
+	{localErrors.ErrNotFound, http.StatusNotFound, "not_found"},
+
+	{mwApp.ErrUnauthorized, http.StatusUnauthorized, "unauthorized"},
+	{mwApp.ErrForbidden, http.StatusForbidden, "forbidden"},
+	{mwApp.ErrSessionRequired, http.StatusForbidden, "session_required"},
+	{mwApp.ErrTooManyRequests, http.StatusTooManyRequests, "too_many_requests"},
+	{mwApp.ErrAuthCheck, http.StatusInternalServerError, codeInternalError},
+	{mwApp.ErrPermissionCheck, http.StatusInternalServerError, codeInternalError},
+	{mwApp.ErrRateLimitCheck, http.StatusInternalServerError, codeInternalError},
+
+	{errInvalidJSON, http.StatusBadRequest, "invalid_json"},
+	{errInvalidCountryID, http.StatusBadRequest, "invalid_country_id"},
+	{errFileMissing, http.StatusBadRequest, "file_missing"},
+	{errFileUnreadable, http.StatusBadRequest, "file_unreadable"},
+
+	{errParamMissing, http.StatusBadRequest, "param_missing"},
+	{errParamNotNumber, http.StatusBadRequest, "param_not_number"},
+	{errParamNotPositive, http.StatusBadRequest, "param_not_positive"},
+	{errParamNegative, http.StatusBadRequest, "param_negative"},
+	{errParamNotTime, http.StatusBadRequest, "param_not_time"},
+	{errParamBoundingBox, http.StatusBadRequest, "param_bounding_box"},
+	{errParamCoordinates, http.StatusBadRequest, "param_coordinates"},
+}
+
+// errorCodesByMessage Коды ошибок по тексту: обработчики часто передают в echo.HTTPError
+// только сообщение ошибки, а не саму ошибку
+var errorCodesByMessage = func() map[string]errorCode {
+	result := make(map[string]errorCode, len(errorCodes))
+	for _, ec := range errorCodes {
+		if _, ok := result[ec.err.Error()]; !ok {
+			result[ec.err.Error()] = ec
+		}
+	}
+
+	return result
+}()
+
+// findErrorCode Найти код для ошибки или ошибки, которую она оборачивает
+func findErrorCode(err error) (errorCode, bool) {
+	for _, ec := range errorCodes {
+		if errors.Is(err, ec.err) {
+			return ec, true
+		}
+	}
+
+	return errorCode{}, false
+}
@@ -0,0 +1,180 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+
+	"palback/internal/delivery/http/dto"
+)
+
+// NewHTTPErrorHandler Единый обработчик ошибок: сопоставляет ошибке стабильный код,
+// возвращает сообщение на языке запроса и ошибки отдельных полей.
+// В production подробности внутренних ошибок клиенту не возвращаются, а только логируются.
+func NewHTTPErrorHandler(isProduction bool) echo.HTTPErrorHandler {
+	return func(err error, c echo.Context) {
+		if c.Response().Committed {
+			return
+		}
+
+		lang := getLang(c)
+		status, resp := resolveError(err, lang)
+
+		if status >= http.StatusInternalServerError {
+			c.Logger().Error(err)
+
+			if isProduction {
+				resp = dto.ErrorResponse{
+					Code:    codeInternalError,
+					Message: localize(lang, codeInternalError, http.StatusText(http.StatusInternalServerError)),
+				}
+			} else {
+				resp.Detail = err.Error()
+			}
+		}
+
+		if c.Request().Method == http.MethodHead {
+			err = c.NoContent(status)
+		} else {
+			err = c.JSON(status, resp)
+		}
+
+		if err != nil {
+			c.Logger().Error(err)
+		}
+	}
+}
+
+// resolveError Определить HTTP-статус и тело ответа для ошибки
+func resolveError(err error, lang string) (int, dto.ErrorResponse) {
+	var (
+		paramErr      *paramError
+		validationErr validator.ValidationErrors
+		httpErr       *echo.HTTPError
+	)
+
+	switch {
+	case errors.As(err, &paramErr):
+		return http.StatusBadRequest, dto.ErrorResponse{
+			Code:    codeInvalidParameter,
+			Message: localize(lang, codeInvalidParameter, ""),
+			Fields:  []dto.FieldErrorResponse{paramFieldError(paramErr, lang)},
+		}
+	case errors.As(err, &validationErr):
+		return http.StatusBadRequest, validationResponse(validationErr, lang)
+	case errors.As(err, &httpErr):
+		if httpErr.Internal != nil && errors.As(httpErr.Internal, &validationErr) {
+			return httpErr.Code, validationResponse(validationErr, lang)
+		}
+
+		return httpErr.Code, httpErrorResponse(httpErr, lang)
+	}
+
+	if ec, ok := findErrorCode(err); ok {
+		return ec.status, dto.ErrorResponse{
+			Code:    ec.code,
+			Message: localize(lang, ec.code, ec.err.Error()),
+		}
+	}
+
+	return http.StatusInternalServerError, dto.ErrorResponse{
+		Code:    codeInternalError,
+		Message: localize(lang, codeInternalError, ""),
+	}
+}
+
+// httpErrorResponse Тело ответа для ошибки, статус которой выбрал обработчик
+func httpErrorResponse(httpErr *echo.HTTPError, lang string) dto.ErrorResponse {
+	var message string
+
+	switch m := httpErr.Message.(type) {
+	case error:
+		if ec, ok := findErrorCode(m); ok {
+			return dto.ErrorResponse{Code: ec.code, Message: localize(lang, ec.code, ec.err.Error())}
+		}
+
+		message = m.Error()
+	case string:
+		if ec, ok := errorCodesByMessage[m]; ok {
+			return dto.ErrorResponse{Code: ec.code, Message: localize(lang, ec.code, m)}
+		}
+
+		message = m
+	default:
+		message = fmt.Sprint(m)
+	}
+
+	code := statusCode(httpErr.Code)
+
+	// Сообщения самого echo, например для несуществующего маршрута, совпадают с названием статуса
+	if message == http.StatusText(httpErr.Code) {
+		message = localize(lang, code, message)
+	}
+
+	return dto.ErrorResponse{Code: code, Message: message}
+}
+
+// validationResponse Тело ответа с ошибками отдельных полей
+func validationResponse(validationErr validator.ValidationErrors, lang string) dto.ErrorResponse {
+	fields := make([]dto.FieldErrorResponse, 0, len(validationErr))
+	for _, fieldErr := range validationErr {
+		fields = append(fields, dto.FieldErrorResponse{
+			Field:   fieldErr.Field(),
+			Code:    fieldErr.Tag(),
+			Message: localizef(lang, validationMessageKey(fieldErr), fieldErr.Param()),
+		})
+	}
+
+	return dto.ErrorResponse{
+		Code:    codeValidationFailed,
+		Message: localize(lang, codeValidationFailed, ""),
+		Fields:  fields,
+	}
+}
+
+// validationMessageKey Ключ сообщения для правила проверки. Для строк и списков ограничения
+// min, max и len относятся к длине, а не к значению.
+func validationMessageKey(fieldErr validator.FieldError) string {
+	key := "validation." + fieldErr.Tag()
+
+	switch fieldErr.Tag() {
+	case "min", "max", "len":
+		switch fieldErr.Kind() {
+		case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+			key += "_length"
+		}
+	}
+
+	return key
+}
+
+// paramFieldError Ошибка параметра пути или строки запроса на языке запроса
+func paramFieldError(paramErr *paramError, lang string) dto.FieldErrorResponse {
+	fieldErr := dto.FieldErrorResponse{
+		Field:   paramErr.name,
+		Code:    codeInvalidParameter,
+		Message: paramErr.reason.Error(),
+	}
+
+	if ec, ok := findErrorCode(paramErr.reason); ok {
+		fieldErr.Code = ec.code
+		fieldErr.Message = localize(lang, ec.code, ec.err.Error())
+	}
+
+	return fieldErr
+}
+
+// statusCode Код ошибки по HTTP-статусу для ошибок без собственного кода, например 404 -> not_found
+func statusCode(status int) string {
+	text := http.StatusText(status)
+	if text == "" || status == http.StatusInternalServerError {
+		return codeInternalError
+	}
+
+	return strings.ReplaceAll(strings.ToLower(text), " ", "_")
+}
@@ -0,0 +1,236 @@
+package http
+
+import (
+	"fmt"
+	"strings"
+
+	"palback/internal/pkg/locale"
+)
+
+// errorMessages Сообщения об ошибках по языкам и кодам ошибок. Для ошибок предметной области
+// русские сообщения берутся из самих ошибок, поэтому здесь есть только общие русские сообщения.
+// Если сообщения на запрошенном языке нет, возвращается сообщение на русском.
+var errorMessages = map[string]map[string]string{
+	locale.Default: {
+		"bad_request":              "неверный запрос",
+		"unauthorized":             "пользователь не авторизован",
+		"forbidden":                "недостаточно прав для выполнения операции",
+		"not_found":                "ресурс не найден",
+		"method_not_allowed":       "метод не поддерживается",
+		"conflict":                 "конфликт с текущим состоянием ресурса",
+		"request_entity_too_large": "слишком большой запрос",
+		"unsupported_media_type":   "неподдерживаемый тип содержимого",
+		"locked":                   "ресурс заблокирован",
+		"too_many_requests":        "слишком много запросов, повторите попытку позже",
+		"service_unavailable":      "сервис временно недоступен",
+		codeInternalError:          "внутренняя ошибка сервера",
+		codeValidationFailed:       "проверьте правильность заполнения полей",
+		codeInvalidParameter:       "неверные параметры запроса",
+
+		"validation.required":   "обязательное поле",
+		"validation.email":      "неверный e-mail",
+		"validation.url":        "неверный адрес",
+		"validation.min":        "значение должно быть не меньше %s",
+		"validation.max":        "значение должно быть не больше %s",
+		"validation.gte":        "значение должно быть не меньше %s",
+		"validation.lte":        "значение должно быть не больше %s",
+		"validation.gt":         "значение должно быть больше %s",
+		"validation.lt":         "значение должно быть меньше %s",
+		"validation.len":        "значение должно быть равно %s",
+		"validation.min_length": "длина должна быть не меньше %s",
+		"validation.max_length": "длина должна быть не больше %s",
+		"validation.len_length": "длина должна быть равна %s",
+		"validation.oneof":      "допустимые значения: %s",
+		"validation.invalid":    "неверное значение",
+		"validation.country_id": "должен состоять из строчных латинских букв и иметь в длину от 2 до 6 символов",
+		"validation.username":   "от 3 до 40 латинских букв, цифр и символов _ . -, начинается с буквы или цифры",
+		"validation.latitude":   "широта должна быть от -90 до 90",
+		"validation.longitude":  "долгота должна быть от -180 до 180",
+	},
+	"en": {
+		"bad_request":              "bad request",
+		"unauthorized":             "user is not authenticated",
+		"forbidden":                "insufficient permissions for this operation",
+		"not_found":                "resource not found",
+		"method_not_allowed":       "method not allowed",
+		"conflict":                 "conflict with the current state of the resource",
+		"request_entity_too_large": "request is too large",
+		"unsupported_media_type":   "unsupported content type",
+		"locked":                   "resource is locked",
+		"too_many_requests":        "too many requests, please try again later",
+		"service_unavailable":      "service is temporarily unavailable",
+		codeInternalError:          "internal server error",
+		codeValidationFailed:       "some fields are invalid",
+		codeInvalidParameter:       "invalid request parameters",
+
+		"validation.required":   "field is required",
+		"validation.email":      "invalid e-mail",
+		"validation.url":        "invalid URL",
+		"validation.min":        "must be at least %s",
+		"validation.max":        "must be at most %s",
+		"validation.gte":        "must be at least %s",
+		"validation.lte":        "must be at most %s",
+		"validation.gt":         "must be greater than %s",
+		"validation.lt":         "must be less than %s",
+		"validation.len":        "must be equal to %s",
+		"validation.min_length": "must be at least %s characters long",
+		"validation.max_length": "must be at most %s characters long",
+		"validation.len_length": "must be exactly %s characters long",
+		"validation.oneof":      "allowed values: %s",
+		"validation.invalid":    "invalid value",
+		"validation.country_id": "must consist of 2 to 6 lowercase latin letters",
+		"validation.username":   "3 to 40 latin letters, digits and _ . -, starting with a letter or digit",
+		"validation.latitude":   "latitude must be between -90 and 90",
+		"validation.longitude":  "longitude must be between -180 and 180",
+
+		"country_not_found":       "country not found",
+		"country_already_added":   "a country with this id has already been added",
+		"country_name_not_unique": "country name must be unique",
+
+		"country_has_no_regions": "the country has no regions",
+		"region_not_found":       "region not found",
+		"region_not_unique":      "region name must be unique within a country",
+
+		"city_type_not_found": "settlement type not found",
+
+		"city_not_found":        "settlement not found",
+		"city_region_required":  "a region is required for a settlement in a country with regions",
+		"region_not_in_country": "the region does not belong to the specified country",
+		"invalid_coordinates":   "invalid coordinates: latitude must be between -90 and 90, longitude between -180 and 180",
+
+		"place_type_not_found": "holy place type not found",
+
+		"place_not_found":             "holy place not found",
+		"place_invalid_status":        "invalid holy place status",
+		"place_invalid_founding_year": "founding year cannot be in the future",
+		"place_invalid_radius":        "search radius must be greater than 0 and at most 500 km",
+		"invalid_bounding_box":        "invalid search area bounds",
+
+		"submission_not_found":       "submission not found",
+		"submission_forbidden":       "no access to the submission",
+		"submission_not_editable":    "the submission cannot be changed in its current state",
+		"submission_invalid_state":   "invalid submission state transition",
+		"submission_reason_required": "a rejection reason is required",
+
+		"image_empty":            "image file is empty",
+		"image_too_large":        "image size exceeds the limit",
+		"image_too_many_pixels":  "image resolution exceeds the limit",
+		"image_unsupported_type": "unsupported image format, JPEG, PNG and WebP are allowed",
+		"image_invalid":          "failed to read the image",
+
+		"photo_not_found": "photo not found",
+		"photo_forbidden": "no access to the photo",
+
+		"revision_not_found":      "revision not found",
+		"revision_not_restorable": "this revision cannot be restored",
+		"revision_entity_deleted": "the record has been deleted and cannot be restored from a revision",
+
+		"translation_not_found":          "translation not found",
+		"translation_invalid_entity":     "unknown catalog, allowed are country, region, city_type, city, place_type and place",
+		"translation_entity_not_found":   "catalog entry not found",
+		"translation_lang_not_supported": "language is not supported",
+		"translation_default_lang":       "the name in the default language is changed in the catalog entry itself",
+		"translation_name_required":      "name is required",
+
+		"user_not_found":                "user not found",
+		"user_blocked":                  "the account is blocked",
+		"user_self_administration":      "you cannot change the role of, block or delete your own account",
+		"profile_display_name_too_long": "display name must be at most 100 characters long",
+		"profile_about_too_long":        "about text must be at most 2000 characters long",
+		"avatar_not_found":              "avatar not found",
+
+		"role_not_found":          "role not found",
+		"role_not_editable":       "administrator role permissions cannot be changed",
+		"role_assign_forbidden":   "only an administrator can grant or revoke the administrator role",
+		"role_invalid_permission": "unknown permission, allowed are catalog.edit, places.moderate and users.manage",
+
+		"invalid_password":                   "invalid password",
+		"account_deletion_already_requested": "account deletion has already been requested",
+
+		"password_too_short":              "the password is too short",
+		"password_too_long":               "the password must be at most 72 bytes long",
+		"password_too_simple":             "the password must contain different kinds of characters: lowercase and uppercase letters, digits, other characters",
+		"password_contains_personal_data": "the password must not contain your username or e-mail",
+		"password_breached":               "this password has appeared in data breaches, choose another one",
+
+		"user_name_not_unique":  "username must be unique",
+		"email_not_changed":     "the new e-mail is the same as the current one",
+		"user_email_not_unique": "e-mail must be unique",
+		"invalid_credentials":   "invalid login or password",
+		"login_throttled":       "too many failed login attempts, please try again later",
+		"account_locked":        "login is temporarily locked, unlock instructions have been sent to your e-mail",
+		"unauthenticated":       "not authenticated",
+		"email_not_verified":    "your e-mail must be verified, check your mailbox and confirm the e-mail",
+		"invalid_token":         "invalid or expired token",
+		"session_expired":       "the session has expired, please log in again",
+		"session_not_found":     "session not found",
+
+		"access_token_not_found":      "access token not found",
+		"access_token_name_required":  "access token name is required",
+		"access_token_name_too_long":  "access token name must be at most 100 characters long",
+		"access_token_invalid_scope":  "invalid access token scopes, allowed are read and write",
+		"access_token_invalid_expiry": "access token expiry must be in the future",
+		"access_token_limit_reached":  "the maximum number of access tokens has been reached",
+		"access_token_scope_denied":   "the access token does not grant this operation",
+		"session_required":            "this action requires signing in with a password, access tokens are not accepted",
+
+		"identity_provider_not_found": "login provider not found",
+		"identity_not_found":          "linked account not found",
+		"identity_already_linked":     "the provider account is already linked",
+		"identity_email_not_verified": "the provider has not verified the e-mail, login is not possible",
+		"identity_email_conflict":     "a user with this e-mail already exists, but their e-mail is not verified",
+		"identity_last_login_method":  "you cannot unlink the only login method, set a password first",
+
+		"two_factor_invalid_code":      "invalid verification code",
+		"two_factor_already_enabled":   "two-factor authentication is already enabled",
+		"two_factor_not_enabled":       "two-factor authentication is not enabled",
+		"two_factor_setup_not_started": "two-factor authentication setup has not been started or has expired",
+		"two_factor_required":          "two-factor authentication is required for administrators",
+		"two_factor_too_many_attempts": "too many invalid verification codes, please try again later",
+
+		"email_not_found":      "e-mail message not found",
+		"email_already_sent":   "the e-mail message has already been sent",
+		"email_invalid_status": "invalid e-mail message status, allowed are pending, sent and dead",
+		"email_token_expired":  "the link in the e-mail message has expired",
+
+		"invalid_json":       "invalid json",
+		"invalid_country_id": "country id must consist of 2 to 6 lowercase latin letters",
+		"file_missing":       "file is missing",
+		"file_unreadable":    "failed to read the file",
+
+		"param_missing":      "parameter is missing",
+		"param_not_number":   "must be a number",
+		"param_not_positive": "must be a positive number",
+		"param_negative":     "must be a non-negative number",
+		"param_not_time":     "must be a time in RFC 3339 format",
+		"param_bounding_box": "expected min_lon,min_lat,max_lon,max_lat",
+		"param_coordinates":  "coordinates must be numbers",
+	},
+}
+
+// localize Получить сообщение по коду на языке запроса, иначе на русском, иначе fallback
+func localize(lang, code, fallback string) string {
+	if message, ok := errorMessages[lang][code]; ok {
+		return message
+	}
+
+	if message, ok := errorMessages[locale.Default][code]; ok {
+		return message
+	}
+
+	return fallback
+}
+
+// localizef То же, что localize, с подстановкой параметра в сообщение
+func localizef(lang, code, param string) string {
+	message := localize(lang, code, "")
+	if message == "" {
+		return localize(lang, "validation.invalid", "")
+	}
+
+	if strings.Contains(message, "%s") {
+		return fmt.Sprintf(message, param)
+	}
+
+	return message
+}
@@ -0,0 +1,10 @@
+package http
+
+import "errors"
+
+var (
+	errInvalidJSON      = errors.New("неверный json")
+	errInvalidCountryID = errors.New("id страны должен состоять из строчных латинских букв и иметь в длину от 2 до 6 символов включительно")
+	errFileMissing      = errors.New("файл не передан")
+	errFileUnreadable   = errors.New("не удалось прочитать файл")
+)
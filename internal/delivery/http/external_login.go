@@ -0,0 +1,170 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"palback/internal/delivery/http/dto"
+	localErrors "palback/internal/pkg/errors"
+	"palback/internal/usecase"
+)
+
+const (
+	// externalLoginCookie Cookie, которым вход через провайдера привязан к начавшему его браузеру
+	externalLoginCookie = "oidc_binding"
+
+	// externalLoginCookieMaxAge Совпадает со временем жизни состояния входа
+	externalLoginCookieMaxAge = 600
+)
+
+type ExternalLoginHandler struct {
+	service      usecase.ExternalLoginService
+	auth         Authenticator
+	secureCookie bool
+}
+
+func NewExternalLoginHandler(
+	service usecase.ExternalLoginService,
+	auth Authenticator,
+	secureCookie bool,
+) *ExternalLoginHandler {
+	return &ExternalLoginHandler{
+		service:      service,
+		auth:         auth,
+		secureCookie: secureCookie,
+	}
+}
+
+// Providers Получить список доступных провайдеров входа
+func (h *ExternalLoginHandler) Providers(c echo.Context) error {
+	return c.JSON(http.StatusOK, map[string]any{"providers": h.service.Providers()})
+}
+
+// Authorize Перенаправить пользователя на страницу входа провайдера
+func (h *ExternalLoginHandler) Authorize(c echo.Context) error {
+	url, binding, err := h.service.Start(c.Request().Context(), c.Param("provider"), nil)
+	if err != nil {
+		return externalLoginError(err)
+	}
+
+	h.setBindingCookie(c, binding, externalLoginCookieMaxAge)
+
+	return c.Redirect(http.StatusFound, url)
+}
+
+// Link Перенаправить вошедшего пользователя к провайдеру для привязки учетной записи
+func (h *ExternalLoginHandler) Link(c echo.Context) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	url, binding, err := h.service.Start(c.Request().Context(), c.Param("provider"), &userID)
+	if err != nil {
+		return externalLoginError(err)
+	}
+
+	h.setBindingCookie(c, binding, externalLoginCookieMaxAge)
+
+	return c.Redirect(http.StatusFound, url)
+}
+
+// Callback Завершить вход по state и коду, с которыми провайдер вернул пользователя на фронтенд.
+// State принимается только от браузера, начавшего вход, то есть вместе с cookie привязки.
+func (h *ExternalLoginHandler) Callback(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req dto.ExternalLoginCallbackRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	var binding string
+	if cookie, err := c.Cookie(externalLoginCookie); err == nil {
+		binding = cookie.Value
+	}
+
+	// State одноразовый, поэтому cookie больше не нужна при любом исходе
+	h.setBindingCookie(c, "", -1)
+
+	data, err := h.service.Callback(ctx, c.Param("provider"), req.State, req.Code, binding)
+	if err != nil {
+		return externalLoginError(err)
+	}
+
+	return loginResponse(c, h.auth, *data)
+}
+
+// Identities Получить учетные записи провайдеров, привязанные к текущему пользователю
+func (h *ExternalLoginHandler) Identities(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	data, err := h.service.GetIdentities(ctx, userID)
+	if err != nil {
+		return externalLoginError(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.CreateIdentityResponseList(data))
+}
+
+// Unlink Отвязать учетную запись провайдера от текущего пользователя
+func (h *ExternalLoginHandler) Unlink(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := getPositiveIntParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	err = h.service.Unlink(ctx, userID, id)
+	if err != nil {
+		return externalLoginError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"message": "учетная запись отвязана"})
+}
+
+// setBindingCookie Выставить cookie привязки входа. Отрицательный maxAge удаляет ее.
+func (h *ExternalLoginHandler) setBindingCookie(c echo.Context, value string, maxAge int) {
+	c.SetCookie(&http.Cookie{
+		Name:     externalLoginCookie,
+		Value:    value,
+		Path:     "/users/oidc",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   h.secureCookie,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func externalLoginError(err error) error {
+	switch {
+	case localErrors.IsOneOf(err, usecase.ErrIdentityProviderNotFound, usecase.ErrIdentityNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	case errors.Is(err, usecase.ErrInvalidToken):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	case localErrors.IsOneOf(
+		err,
+		usecase.ErrIdentityEmailNotVerified,
+		usecase.ErrIdentityEmailConflict,
+		usecase.ErrUserBlocked,
+	):
+		return echo.NewHTTPError(http.StatusForbidden, err.Error())
+	case localErrors.IsOneOf(err, usecase.ErrIdentityAlreadyLinked, usecase.ErrIdentityLastLoginMethod):
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	default:
+		return echo.NewHTTPError(http.StatusInternalServerError, "ошибка входа через внешнего провайдера").SetInternal(err)
+	}
+}
@@ -1,45 +1,181 @@
 package http
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
-	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
+
+	mwApp "palback/internal/delivery/http/middleware"
+	"palback/internal/pkg/geo"
+	"palback/internal/pkg/locale"
+)
+
+var (
+	errParamMissing     = errors.New("параметр отсутствует")
+	errParamNotNumber   = errors.New("должен быть числовым")
+	errParamNotPositive = errors.New("должен быть положительным числом")
+	errParamNegative    = errors.New("должен быть неотрицательным числом")
+	errParamNotTime     = errors.New("должен быть временем в формате RFC 3339")
+	errParamBoundingBox = errors.New("ожидается min_lon,min_lat,max_lon,max_lat")
+	errParamCoordinates = errors.New("координаты должны быть числовыми")
 )
 
-var validCountryID = regexp.MustCompile(`^[a-z]{2,6}$`)
+// paramError Неверный параметр пути или строки запроса; в ответе возвращается как ошибка поля
+type paramError struct {
+	name   string
+	reason error
+}
+
+func newParamError(name string, reason error) *paramError {
+	return &paramError{
+		name:   name,
+		reason: reason,
+	}
+}
+
+func (e *paramError) Error() string {
+	return fmt.Sprintf("неверный параметр %q: %s", e.name, e.reason)
+}
+
+func (e *paramError) Unwrap() error {
+	return e.reason
+}
 
 func getPositiveIntParam(c echo.Context, paramName string) (int, error) {
 	paramStr := c.Param(paramName)
 	if strings.TrimSpace(paramStr) == "" {
-		return 0, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Отсутствует параметр %q", paramName))
+		return 0, newParamError(paramName, errParamMissing)
 	}
 
 	num, err := strconv.Atoi(paramStr)
 	if err != nil {
-		return 0, echo.NewHTTPError(
-			http.StatusBadRequest,
-			fmt.Sprintf("Неверный %q: должен быть числовым", paramName),
-		)
+		return 0, newParamError(paramName, errParamNotNumber)
 	}
 
 	if num <= 0 {
-		return 0, echo.NewHTTPError(
-			http.StatusBadRequest,
-			fmt.Sprintf("Неверныйй %q: должен быть положительным числом", paramName),
-		)
+		return 0, newParamError(paramName, errParamNotPositive)
 	}
 
 	return num, nil
 }
 
+// getOptionalPositiveIntQuery Получить необязательный положительный числовой параметр строки запроса
+func getOptionalPositiveIntQuery(c echo.Context, paramName string) (*int, error) {
+	paramStr := strings.TrimSpace(c.QueryParam(paramName))
+	if paramStr == "" {
+		return nil, nil
+	}
+
+	num, err := strconv.Atoi(paramStr)
+	if err != nil || num <= 0 {
+		return nil, newParamError(paramName, errParamNotPositive)
+	}
+
+	return &num, nil
+}
+
+// getNonNegativeIntQuery Получить неотрицательный числовой параметр строки запроса, 0 если не задан
+func getNonNegativeIntQuery(c echo.Context, paramName string) (int, error) {
+	paramStr := strings.TrimSpace(c.QueryParam(paramName))
+	if paramStr == "" {
+		return 0, nil
+	}
+
+	num, err := strconv.Atoi(paramStr)
+	if err != nil || num < 0 {
+		return 0, newParamError(paramName, errParamNegative)
+	}
+
+	return num, nil
+}
+
+// getOptionalTimeQuery Получить необязательный параметр строки запроса со временем в формате RFC 3339
+func getOptionalTimeQuery(c echo.Context, paramName string) (*time.Time, error) {
+	paramStr := strings.TrimSpace(c.QueryParam(paramName))
+	if paramStr == "" {
+		return nil, nil
+	}
+
+	value, err := time.Parse(time.RFC3339, paramStr)
+	if err != nil {
+		return nil, newParamError(paramName, errParamNotTime)
+	}
+
+	return &value, nil
+}
+
+// getFloatQuery Получить обязательный числовой параметр строки запроса с плавающей точкой
+func getFloatQuery(c echo.Context, paramName string) (float64, error) {
+	paramStr := strings.TrimSpace(c.QueryParam(paramName))
+	if paramStr == "" {
+		return 0, newParamError(paramName, errParamMissing)
+	}
+
+	num, err := strconv.ParseFloat(paramStr, 64)
+	if err != nil {
+		return 0, newParamError(paramName, errParamNotNumber)
+	}
+
+	return num, nil
+}
+
+// getBoundingBoxQuery Получить необязательную область поиска в формате min_lon,min_lat,max_lon,max_lat
+func getBoundingBoxQuery(c echo.Context, paramName string) (*geo.BoundingBox, error) {
+	paramStr := strings.TrimSpace(c.QueryParam(paramName))
+	if paramStr == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(paramStr, ",")
+	if len(parts) != 4 {
+		return nil, newParamError(paramName, errParamBoundingBox)
+	}
+
+	values := make([]float64, 0, len(parts))
+	for _, part := range parts {
+		num, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, newParamError(paramName, errParamCoordinates)
+		}
+		values = append(values, num)
+	}
+
+	return &geo.BoundingBox{
+		MinLongitude: values[0],
+		MinLatitude:  values[1],
+		MaxLongitude: values[2],
+		MaxLatitude:  values[3],
+	}, nil
+}
+
+// bindRequest Прочитать тело запроса и проверить его по правилам из тегов validate
+func bindRequest(c echo.Context, req any) error {
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, errInvalidJSON.Error()).SetInternal(err)
+	}
+
+	return c.Validate(req)
+}
+
+// getUserID Получить id текущего пользователя, установленный AuthMiddleware
+func getUserID(c echo.Context) (int, error) {
+	userID, ok := c.Get("user_id").(int)
+	if !ok || userID <= 0 {
+		return 0, echo.NewHTTPError(http.StatusUnauthorized, mwApp.ErrUnauthorized.Error())
+	}
+
+	return userID, nil
+}
+
 func getLang(c echo.Context) string {
 	lang, ok := c.Get("lang").(string)
 	if !ok {
-		return "ru"
+		return locale.Default
 	}
 
 	return lang
@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"slices"
+
+	"github.com/labstack/echo/v4"
+
+	"palback/internal/domain/model"
+)
+
+type GetterUserRole interface {
+	GetRole(ctx context.Context, userID int) (*model.Role, error)
+}
+
+// RequireAuth Пропустить запрос только для аутентифицированного пользователя
+func RequireAuth() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if _, ok := c.Get("user_id").(int); !ok {
+				return echo.NewHTTPError(http.StatusUnauthorized, ErrUnauthorized.Error())
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// RequireSession Пропустить запрос только для пользователя, вошедшего по паролю. Запросы с токеном доступа
+// отклоняются: токеном нельзя выпустить новый токен или управлять сессиями.
+func RequireSession() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if _, ok := c.Get("user_id").(int); !ok {
+				return echo.NewHTTPError(http.StatusUnauthorized, ErrUnauthorized.Error())
+			}
+
+			if method, _ := c.Get("auth_method").(AuthMethod); method != AuthMethodSession {
+				return echo.NewHTTPError(http.StatusForbidden, ErrSessionRequired.Error())
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// RequireRole Пропустить запрос только для пользователя с одной из указанных ролей
+func RequireRole(roleGetter GetterUserRole, roles ...model.RoleID) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			userID, ok := c.Get("user_id").(int)
+			if !ok {
+				return echo.NewHTTPError(http.StatusUnauthorized, ErrUnauthorized.Error())
+			}
+
+			role, err := roleGetter.GetRole(c.Request().Context(), userID)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, ErrPermissionCheck.Error()).SetInternal(err)
+			}
+
+			if role == nil || !slices.Contains(roles, role.ID) {
+				return echo.NewHTTPError(http.StatusForbidden, ErrForbidden.Error())
+			}
+
+			c.Set("role", role)
+
+			return next(c)
+		}
+	}
+}
+
+type PermissionChecker interface {
+	HasPermission(ctx context.Context, userID int, permission model.Permission) (bool, error)
+}
+
+// RequirePermission Пропустить запрос только для пользователя, роли которого выдано право
+func RequirePermission(checker PermissionChecker, permission model.Permission) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			userID, ok := c.Get("user_id").(int)
+			if !ok {
+				return echo.NewHTTPError(http.StatusUnauthorized, ErrUnauthorized.Error())
+			}
+
+			allowed, err := checker.HasPermission(c.Request().Context(), userID, permission)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, ErrPermissionCheck.Error()).SetInternal(err)
+			}
+
+			if !allowed {
+				return echo.NewHTTPError(http.StatusForbidden, ErrForbidden.Error())
+			}
+
+			return next(c)
+		}
+	}
+}
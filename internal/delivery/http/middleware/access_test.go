@@ -0,0 +1,164 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"palback/internal/domain/model"
+)
+
+type fakeRoleGetter map[int]model.RoleID
+
+func (f fakeRoleGetter) GetRole(_ context.Context, userID int) (*model.Role, error) {
+	roleID, ok := f[userID]
+	if !ok {
+		return nil, errors.New("роль не найдена")
+	}
+
+	return &model.Role{ID: roleID}, nil
+}
+
+type fakePermissionChecker map[int][]model.Permission
+
+func (f fakePermissionChecker) HasPermission(_ context.Context, userID int, permission model.Permission) (bool, error) {
+	permissions, ok := f[userID]
+	if !ok {
+		return false, errors.New("пользователь не найден")
+	}
+
+	for _, p := range permissions {
+		if p == permission {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+const (
+	adminID = 1
+	userID  = 2
+	unknown = 3
+)
+
+// serve Выполнить запрос через middleware; userID 0 означает анонимный запрос
+func serve(mw echo.MiddlewareFunc, userID int) int {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	if userID > 0 {
+		c.Set("user_id", userID)
+	}
+
+	err := mw(func(c echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})(c)
+	if err != nil {
+		e.HTTPErrorHandler(err, c)
+	}
+
+	return rec.Code
+}
+
+func TestRequireAuth(t *testing.T) {
+	tests := []struct {
+		name   string
+		userID int
+		want   int
+	}{
+		{"anonymous", 0, http.StatusUnauthorized},
+		{"authenticated", userID, http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := serve(RequireAuth(), tt.userID); got != tt.want {
+				t.Errorf("status = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequireSession(t *testing.T) {
+	tests := []struct {
+		name   string
+		userID int
+		method AuthMethod
+		want   int
+	}{
+		{"anonymous", 0, "", http.StatusUnauthorized},
+		{"bearer", userID, AuthMethodBearer, http.StatusForbidden},
+		{"session", userID, AuthMethodSession, http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mw := func(next echo.HandlerFunc) echo.HandlerFunc {
+				return func(c echo.Context) error {
+					c.Set("auth_method", tt.method)
+					return RequireSession()(next)(c)
+				}
+			}
+
+			if got := serve(mw, tt.userID); got != tt.want {
+				t.Errorf("status = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequireRole(t *testing.T) {
+	roles := fakeRoleGetter{adminID: model.RoleAdmin, userID: model.RoleUser}
+
+	tests := []struct {
+		name   string
+		userID int
+		want   int
+	}{
+		{"anonymous", 0, http.StatusUnauthorized},
+		{"user", userID, http.StatusForbidden},
+		{"admin", adminID, http.StatusNoContent},
+		{"role lookup error", unknown, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := serve(RequireRole(roles, model.RoleAdmin), tt.userID); got != tt.want {
+				t.Errorf("status = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequirePermission(t *testing.T) {
+	checker := fakePermissionChecker{
+		adminID: {model.PermissionCatalogEdit},
+		userID:  nil,
+	}
+
+	tests := []struct {
+		name   string
+		userID int
+		want   int
+	}{
+		{"anonymous", 0, http.StatusUnauthorized},
+		{"without permission", userID, http.StatusForbidden},
+		{"with permission", adminID, http.StatusNoContent},
+		{"permission check error", unknown, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := serve(RequirePermission(checker, model.PermissionCatalogEdit), tt.userID); got != tt.want {
+				t.Errorf("status = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,23 +2,64 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
+
+	"palback/internal/pkg/actor"
+	localErrors "palback/internal/pkg/errors"
+	"palback/internal/usecase"
 )
 
 type GetterUserID interface {
 	GetUserID(context.Context, *http.Request) (int, error)
 }
 
-func AuthMiddleware(authenticator GetterUserID) echo.MiddlewareFunc {
+// AuthMethod Способ, которым аутентифицирован запрос
+type AuthMethod string
+
+const (
+	AuthMethodBearer  AuthMethod = "bearer"
+	AuthMethodSession AuthMethod = "session"
+)
+
+// AuthMiddleware Определить пользователя запроса: сначала по токену доступа, затем по сессии.
+// Если ни один способ не подошел, запрос выполняется анонимно. Ошибка проверки токена, не связанная
+// с самим токеном, например недоступность базы данных, возвращается как внутренняя ошибка,
+// чтобы запрос не выполнился анонимно.
+func AuthMiddleware(bearer, session GetterUserID) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			userID, _ := authenticator.GetUserID(c.Request().Context(), c.Request())
-			if userID > 0 {
-				c.Set("user_id", userID)
+			ctx := c.Request().Context()
+
+			userID, err := bearer.GetUserID(ctx, c.Request())
+			switch {
+			// Токен действителен, но его права не позволяют выполнить запрос
+			case errors.Is(err, usecase.ErrAccessTokenScopeDenied):
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
+			case err != nil && !localErrors.IsOneOf(err,
+				usecase.ErrUnauthenticated,
+				usecase.ErrSessionExpired,
+				usecase.ErrUserBlocked,
+			):
+				return echo.NewHTTPError(http.StatusInternalServerError, ErrAuthCheck.Error()).SetInternal(err)
+			case userID > 0:
+				authenticate(c, userID, AuthMethodBearer)
+				return next(c)
 			}
+
+			if userID, _ = session.GetUserID(ctx, c.Request()); userID > 0 {
+				authenticate(c, userID, AuthMethodSession)
+			}
+
 			return next(c)
 		}
 	}
 }
+
+func authenticate(c echo.Context, userID int, method AuthMethod) {
+	c.Set("user_id", userID)
+	c.Set("auth_method", method)
+	c.SetRequest(c.Request().WithContext(actor.WithUserID(c.Request().Context(), userID)))
+}
@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"palback/internal/usecase"
+)
+
+// fakeAuthenticator Возвращает заданные id пользователя и ошибку
+type fakeAuthenticator struct {
+	userID int
+	err    error
+}
+
+func (f fakeAuthenticator) GetUserID(context.Context, *http.Request) (int, error) {
+	return f.userID, f.err
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	session := fakeAuthenticator{userID: userID}
+
+	tests := []struct {
+		name       string
+		bearer     fakeAuthenticator
+		session    fakeAuthenticator
+		wantStatus int
+		wantUserID int
+		wantMethod AuthMethod
+	}{
+		{
+			name:       "bearer token",
+			bearer:     fakeAuthenticator{userID: adminID},
+			session:    session,
+			wantStatus: http.StatusNoContent,
+			wantUserID: adminID,
+			wantMethod: AuthMethodBearer,
+		},
+		{
+			name:       "session without token",
+			bearer:     fakeAuthenticator{err: usecase.ErrUnauthenticated},
+			session:    session,
+			wantStatus: http.StatusNoContent,
+			wantUserID: userID,
+			wantMethod: AuthMethodSession,
+		},
+		{
+			name:       "anonymous",
+			bearer:     fakeAuthenticator{err: usecase.ErrUnauthenticated},
+			session:    fakeAuthenticator{err: errors.New("no cookie")},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "token scope denied",
+			bearer:     fakeAuthenticator{err: usecase.ErrAccessTokenScopeDenied},
+			session:    session,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "token lookup error",
+			bearer:     fakeAuthenticator{err: errors.New("connection refused")},
+			session:    session,
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+
+			var (
+				gotUserID int
+				gotMethod AuthMethod
+			)
+
+			err := AuthMiddleware(tt.bearer, tt.session)(func(c echo.Context) error {
+				gotUserID, _ = c.Get("user_id").(int)
+				gotMethod, _ = c.Get("auth_method").(AuthMethod)
+
+				return c.NoContent(http.StatusNoContent)
+			})(c)
+			if err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+
+			if gotUserID != tt.wantUserID || gotMethod != tt.wantMethod {
+				t.Errorf("user_id = %d, auth_method = %q, want %d, %q", gotUserID, gotMethod, tt.wantUserID, tt.wantMethod)
+			}
+		})
+	}
+}
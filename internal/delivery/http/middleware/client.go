@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"palback/internal/pkg/actor"
+)
+
+// ClientMiddleware Сохранить в контексте запроса IP-адрес и User-Agent клиента для журнала аудита
+func ClientMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := actor.WithClient(c.Request().Context(), actor.Client{
+				IP:        c.RealIP(),
+				UserAgent: c.Request().UserAgent(),
+			})
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package middleware
+
+import "errors"
+
+var (
+	ErrUnauthorized    = errors.New("пользователь не авторизован")
+	ErrForbidden       = errors.New("недостаточно прав для выполнения операции")
+	ErrSessionRequired = errors.New("действие доступно только после входа по паролю, токен доступа не подходит")
+	ErrTooManyRequests = errors.New("слишком много запросов с одного IP-адреса")
+	ErrAuthCheck       = errors.New("ошибка проверки токена доступа")
+	ErrPermissionCheck = errors.New("ошибка проверки прав доступа")
+	ErrRateLimitCheck  = errors.New("ошибка проверки частоты запросов")
+)
@@ -2,10 +2,14 @@ package middleware
 
 import (
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/labstack/echo/v4"
 
+	"palback/internal/domain/model"
 	"palback/internal/usecase/port"
 )
 
@@ -20,15 +24,36 @@ func RateLimitByIP(
 			ip := c.RealIP()
 			key := fmt.Sprintf("rl:%s:%s", keyPrefix, ip)
 
-			allowed, err := limiter.Allow(c.Request().Context(), key, limit, windowSeconds)
+			result, err := limiter.Allow(c.Request().Context(), key, limit, windowSeconds)
 			if err != nil {
-				return echo.NewHTTPError(http.StatusInternalServerError, "rate limit check failed")
+				return echo.NewHTTPError(http.StatusInternalServerError, ErrRateLimitCheck.Error()).SetInternal(err)
 			}
-			if !allowed {
-				return echo.NewHTTPError(http.StatusTooManyRequests, "cлишком много запросов с одного IP-адреса")
+
+			SetRateLimitHeaders(c, result)
+
+			if !result.Allowed {
+				return echo.NewHTTPError(http.StatusTooManyRequests, ErrTooManyRequests.Error())
 			}
 
 			return next(c)
 		}
 	}
 }
+
+// SetRateLimitHeaders Сообщить клиенту остаток квоты в заголовках RateLimit-*,
+// а для отклоненного запроса — когда его можно повторить в Retry-After
+func SetRateLimitHeaders(c echo.Context, result model.RateLimitResult) {
+	header := c.Response().Header()
+
+	header.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+	header.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	header.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.ResetAfter)))
+
+	if !result.Allowed {
+		header.Set("Retry-After", strconv.Itoa(max(ceilSeconds(result.RetryAfter), 1)))
+	}
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"palback/internal/infra/rate"
+	"palback/internal/usecase/port"
+)
+
+// fakeClock Часы, которые двигаются только вручную
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+// rateLimitStep Запрос через after после начала и ожидаемые статус и заголовки ответа
+type rateLimitStep struct {
+	after      time.Duration
+	wantStatus int
+	remaining  string
+	reset      string
+	retryAfter string
+}
+
+func TestRateLimitByIP_Headers(t *testing.T) {
+	tests := []struct {
+		name    string
+		limiter func(now func() time.Time) port.RateLimiter
+		steps   []rateLimitStep
+	}{
+		{
+			name: "sliding log",
+			limiter: func(now func() time.Time) port.RateLimiter {
+				return rate.NewMemorySlidingLogRateLimiter(now)
+			},
+			steps: []rateLimitStep{
+				{after: 0, wantStatus: http.StatusNoContent, remaining: "1", reset: "60"},
+				{after: 10 * time.Second, wantStatus: http.StatusNoContent, remaining: "0", reset: "50"},
+				{after: 20 * time.Second, wantStatus: http.StatusTooManyRequests, remaining: "0", reset: "40", retryAfter: "40"},
+				// Первый запрос вышел из окна, в окне остался второй
+				{after: 60 * time.Second, wantStatus: http.StatusNoContent, remaining: "0", reset: "10"},
+			},
+		},
+		{
+			name: "token bucket",
+			limiter: func(now func() time.Time) port.RateLimiter {
+				return rate.NewMemoryTokenBucketRateLimiter(now)
+			},
+			steps: []rateLimitStep{
+				{after: 0, wantStatus: http.StatusNoContent, remaining: "1", reset: "30"},
+				{after: 0, wantStatus: http.StatusNoContent, remaining: "0", reset: "60"},
+				// За 15 секунд наполнилась половина токена
+				{after: 15 * time.Second, wantStatus: http.StatusTooManyRequests, remaining: "0", reset: "45", retryAfter: "15"},
+				{after: 30 * time.Second, wantStatus: http.StatusNoContent, remaining: "0", reset: "60"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+			clock := &fakeClock{now: start}
+			mw := RateLimitByIP(tt.limiter(clock.Now), 2, 60, "test")
+
+			for i, step := range tt.steps {
+				clock.now = start.Add(step.after)
+
+				e := echo.New()
+				rec := httptest.NewRecorder()
+				c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+
+				err := mw(func(c echo.Context) error {
+					return c.NoContent(http.StatusNoContent)
+				})(c)
+				if err != nil {
+					e.HTTPErrorHandler(err, c)
+				}
+
+				header := rec.Header()
+				if rec.Code != step.wantStatus {
+					t.Fatalf("step %d: status = %d, want %d", i, rec.Code, step.wantStatus)
+				}
+
+				if got := header.Get("RateLimit-Limit"); got != "2" {
+					t.Errorf("step %d: RateLimit-Limit = %q, want %q", i, got, "2")
+				}
+
+				if got := header.Get("RateLimit-Remaining"); got != step.remaining {
+					t.Errorf("step %d: RateLimit-Remaining = %q, want %q", i, got, step.remaining)
+				}
+
+				if got := header.Get("RateLimit-Reset"); got != step.reset {
+					t.Errorf("step %d: RateLimit-Reset = %q, want %q", i, got, step.reset)
+				}
+
+				if got := header.Get("Retry-After"); got != step.retryAfter {
+					t.Errorf("step %d: Retry-After = %q, want %q", i, got, step.retryAfter)
+				}
+			}
+		})
+	}
+}
@@ -1,25 +1,47 @@
 package middleware
 
 import (
+	"net/http"
 	"strings"
 
 	"github.com/labstack/echo/v4"
 
 	"palback/internal/config"
+	"palback/internal/pkg/locale"
 )
 
-// SetupLanguage Установить язык для запросов
+// SetupLanguage Установить язык для запросов из параметра lang или заголовка Accept-Language.
+// Переведенные названия возвращаются только при чтении: изменения справочников всегда
+// относятся к основным названиям, и в ревизии не должны попадать переводы.
 func SetupLanguage() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			lang := c.QueryParam("lang")
-			if strings.TrimSpace(lang) == "" {
+			lang := strings.ToLower(strings.TrimSpace(c.QueryParam("lang")))
+			if !locale.IsValid(lang) {
+				lang = preferredLanguage(c.Request().Header.Get("Accept-Language"))
+			}
+
+			if !locale.IsValid(lang) {
 				lang = config.GetLang()
 			}
 
 			c.Set("lang", lang)
 
+			if c.Request().Method == http.MethodGet {
+				ctx := locale.WithLang(c.Request().Context(), lang)
+				c.SetRequest(c.Request().WithContext(ctx))
+			}
+
 			return next(c)
 		}
 	}
 }
+
+// preferredLanguage Первый язык из заголовка Accept-Language, например "en" для "en-US,en;q=0.9"
+func preferredLanguage(header string) string {
+	tag, _, _ := strings.Cut(header, ",")
+	tag, _, _ = strings.Cut(tag, ";")
+	tag, _, _ = strings.Cut(strings.TrimSpace(tag), "-")
+
+	return strings.ToLower(tag)
+}
@@ -0,0 +1,282 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"palback/internal/delivery/http/dto"
+	"palback/internal/domain/model"
+	localErrors "palback/internal/pkg/errors"
+	"palback/internal/pkg/helpers"
+	"palback/internal/usecase"
+	ucModel "palback/internal/usecase/model"
+)
+
+type PlaceHandler struct {
+	service usecase.PlaceService
+}
+
+func NewPlaceHandler(service usecase.PlaceService) *PlaceHandler {
+	return &PlaceHandler{
+		service: service,
+	}
+}
+
+func (h *PlaceHandler) Get(c echo.Context) error {
+	ctx := c.Request().Context()
+	var data *ucModel.PlaceDetail
+
+	id, err := getPositiveIntParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	data, err = h.service.Get(ctx, id)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrPlaceNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError).SetInternal(err)
+		}
+	}
+
+	return c.JSON(http.StatusOK, dto.CreatePlaceResponse(helpers.FromPtr(data)))
+}
+
+func (h *PlaceHandler) GetList(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var (
+		filter model.PlaceFilter
+		err    error
+	)
+
+	if filter.CityID, err = getOptionalPositiveIntQuery(c, "city_id"); err != nil {
+		return err
+	}
+
+	if filter.PlaceTypeID, err = getOptionalPositiveIntQuery(c, "place_type_id"); err != nil {
+		return err
+	}
+
+	if filter.BoundingBox, err = getBoundingBoxQuery(c, "bbox"); err != nil {
+		return err
+	}
+
+	if filter.Limit, err = getNonNegativeIntQuery(c, "limit"); err != nil {
+		return err
+	}
+
+	if filter.Offset, err = getNonNegativeIntQuery(c, "offset"); err != nil {
+		return err
+	}
+
+	data, err := h.service.GetList(ctx, filter)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidBoundingBox):
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError).SetInternal(err)
+		}
+	}
+
+	return c.JSON(http.StatusOK, dto.CreatePlaceResponseList(data))
+}
+
+// GetNearby Найти святые места в заданном радиусе от точки
+func (h *PlaceHandler) GetNearby(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var (
+		filter model.PlaceNearbyFilter
+		err    error
+	)
+
+	if filter.Latitude, err = getFloatQuery(c, "lat"); err != nil {
+		return err
+	}
+
+	if filter.Longitude, err = getFloatQuery(c, "lon"); err != nil {
+		return err
+	}
+
+	if filter.RadiusKm, err = getFloatQuery(c, "radius_km"); err != nil {
+		return err
+	}
+
+	if filter.PlaceTypeID, err = getOptionalPositiveIntQuery(c, "place_type_id"); err != nil {
+		return err
+	}
+
+	if filter.Limit, err = getNonNegativeIntQuery(c, "limit"); err != nil {
+		return err
+	}
+
+	data, err := h.service.GetNearby(ctx, filter)
+
+	if err != nil {
+		switch {
+		case localErrors.IsOneOf(err, usecase.ErrInvalidCoordinates, usecase.ErrPlaceInvalidRadius):
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError).SetInternal(err)
+		}
+	}
+
+	return c.JSON(http.StatusOK, dto.CreatePlaceNearbyResponseList(data))
+}
+
+func (h *PlaceHandler) Post(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req dto.PlacePostRequest
+
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	data, err := h.service.Create(ctx, model.Place{
+		PlaceTypeID:  req.PlaceTypeID,
+		CityID:       req.CityID,
+		Name:         req.Name,
+		Address:      req.Address,
+		Latitude:     req.Latitude,
+		Longitude:    req.Longitude,
+		Description:  req.Description,
+		FoundingYear: req.FoundingYear,
+		Status:       model.PlaceStatus(req.Status),
+	})
+
+	if err != nil {
+		switch {
+		case isPlaceValidationError(err):
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError, "невозможно добавить святое место").SetInternal(err)
+		}
+	}
+
+	dataRec := helpers.FromPtr(data)
+
+	c.Response().Header().Set("location", "/places/"+strconv.Itoa(dataRec.ID))
+
+	return c.JSON(http.StatusCreated, dto.CreatePlaceResponse(dataRec))
+}
+
+func (h *PlaceHandler) Put(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := getPositiveIntParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.PlacePutRequest
+
+	if err = bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	err = h.service.Update(ctx, id, model.Place{
+		PlaceTypeID:  req.PlaceTypeID,
+		CityID:       req.CityID,
+		Name:         req.Name,
+		Address:      req.Address,
+		Latitude:     req.Latitude,
+		Longitude:    req.Longitude,
+		Description:  req.Description,
+		FoundingYear: req.FoundingYear,
+		Status:       model.PlaceStatus(req.Status),
+	})
+
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrPlaceNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		case isPlaceValidationError(err):
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError, "невозможно изменить святое место").SetInternal(err)
+		}
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"message": "святое место обновлено"})
+}
+
+func (h *PlaceHandler) Delete(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := getPositiveIntParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	err = h.service.Delete(ctx, id)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrPlaceNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError, "невозможно удалить святое место").SetInternal(err)
+		}
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"message": "святое место удалено"})
+}
+
+func isPlaceValidationError(err error) bool {
+	return localErrors.IsOneOf(err,
+		usecase.ErrPlaceTypeNotFound,
+		usecase.ErrCityNotFound,
+		usecase.ErrPlaceInvalidStatus,
+		usecase.ErrPlaceInvalidFoundingYear,
+		usecase.ErrInvalidCoordinates,
+	)
+}
+
+// Revisions Получить историю изменений святого места
+func (h *PlaceHandler) Revisions(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := getPositiveIntParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	data, err := h.service.GetRevisions(ctx, id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError).SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.CreateRevisionResponseList(data))
+}
+
+// Restore Вернуть святое место к состоянию после указанной ревизии
+func (h *PlaceHandler) Restore(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := getPositiveIntParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	revisionID, err := getPositiveIntParam(c, "rev")
+	if err != nil {
+		return err
+	}
+
+	err = h.service.Restore(ctx, id, revisionID)
+	if err != nil {
+		return restoreError(err, usecase.ErrPlaceNotFound, isPlaceValidationError)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"message": "святое место возвращено к выбранной ревизии"})
+}
@@ -0,0 +1,218 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"palback/internal/delivery/http/dto"
+	"palback/internal/domain/model"
+	localErrors "palback/internal/pkg/errors"
+	"palback/internal/pkg/helpers"
+	"palback/internal/usecase"
+)
+
+type PlacePhotoHandler struct {
+	service usecase.PlacePhotoService
+}
+
+func NewPlacePhotoHandler(service usecase.PlacePhotoService) *PlacePhotoHandler {
+	return &PlacePhotoHandler{
+		service: service,
+	}
+}
+
+// GetByPlace Получить галерею святого места
+func (h *PlacePhotoHandler) GetByPlace(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	placeID, err := getPositiveIntParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	data, err := h.service.GetByPlace(ctx, placeID)
+	if err != nil {
+		return photoError(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.CreatePlacePhotoResponseList(data))
+}
+
+// Post Загрузить фотографию святого места. Файл передается в поле file формы multipart/form-data.
+func (h *PlacePhotoHandler) Post(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	placeID, err := getPositiveIntParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, errFileMissing.Error()).SetInternal(err)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, errFileUnreadable.Error()).SetInternal(err)
+	}
+	defer file.Close()
+
+	data, err := h.service.Upload(ctx, model.PlacePhoto{
+		PlaceID:  placeID,
+		AuthorID: &userID,
+		Size:     fileHeader.Size,
+		Caption:  c.FormValue("caption"),
+		License:  c.FormValue("license"),
+	}, file)
+	if err != nil {
+		return photoError(err)
+	}
+
+	dataRec := helpers.FromPtr(data)
+
+	c.Response().Header().Set(
+		"location",
+		"/places/"+strconv.Itoa(placeID)+"/photos/"+strconv.Itoa(dataRec.ID),
+	)
+
+	return c.JSON(http.StatusCreated, dto.CreatePlacePhotoResponse(dataRec))
+}
+
+// Download Отдать вариант фотографии из хранилища. Имя файла задает вариант и формат, например card.webp.
+func (h *PlacePhotoHandler) Download(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	placeID, id, err := getPhotoParams(c)
+	if err != nil {
+		return err
+	}
+
+	variant, format, ok := model.ParseImageVariantFile(c.Param("file"))
+	if !ok {
+		return echo.NewHTTPError(http.StatusNotFound, usecase.ErrPhotoNotFound.Error())
+	}
+
+	data, err := h.service.Download(ctx, placeID, id, variant, format)
+	if err != nil {
+		return photoError(err)
+	}
+	defer data.Close()
+
+	c.Response().Header().Set("Cache-Control", "public, max-age=86400")
+
+	return c.Stream(http.StatusOK, format.ContentType(), data)
+}
+
+func (h *PlacePhotoHandler) Put(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	placeID, id, err := getPhotoParams(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.PlacePhotoPutRequest
+
+	if err = bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	err = h.service.Update(ctx, placeID, id, userID, model.PlacePhoto{
+		Caption:  req.Caption,
+		License:  req.License,
+		Position: req.Position,
+	})
+	if err != nil {
+		return photoError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"message": "фотография обновлена"})
+}
+
+// SetCover Сделать фотографию обложкой святого места
+func (h *PlacePhotoHandler) SetCover(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	placeID, id, err := getPhotoParams(c)
+	if err != nil {
+		return err
+	}
+
+	err = h.service.SetCover(ctx, placeID, id, userID)
+	if err != nil {
+		return photoError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"message": "обложка святого места изменена"})
+}
+
+func (h *PlacePhotoHandler) Delete(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	placeID, id, err := getPhotoParams(c)
+	if err != nil {
+		return err
+	}
+
+	err = h.service.Delete(ctx, placeID, id, userID)
+	if err != nil {
+		return photoError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"message": "фотография удалена"})
+}
+
+func getPhotoParams(c echo.Context) (placeID, id int, err error) {
+	placeID, err = getPositiveIntParam(c, "id")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	id, err = getPositiveIntParam(c, "photo")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return placeID, id, nil
+}
+
+func photoError(err error) error {
+	switch {
+	case localErrors.IsOneOf(err, usecase.ErrPhotoNotFound, usecase.ErrPlaceNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	case errors.Is(err, usecase.ErrPhotoForbidden):
+		return echo.NewHTTPError(http.StatusForbidden, err.Error())
+	case localErrors.IsOneOf(err, usecase.ErrImageTooLarge, usecase.ErrImageTooManyPixels):
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, err.Error())
+	case errors.Is(err, usecase.ErrImageUnsupportedType):
+		return echo.NewHTTPError(http.StatusUnsupportedMediaType, err.Error())
+	case errors.Is(err, usecase.ErrImageEmpty):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	default:
+		return echo.NewHTTPError(http.StatusInternalServerError, "ошибка обработки фотографии").SetInternal(err)
+	}
+}
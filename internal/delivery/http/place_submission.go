@@ -0,0 +1,238 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"palback/internal/delivery/http/dto"
+	"palback/internal/domain/model"
+	localErrors "palback/internal/pkg/errors"
+	"palback/internal/pkg/helpers"
+	"palback/internal/usecase"
+)
+
+type PlaceSubmissionHandler struct {
+	service usecase.PlaceSubmissionService
+}
+
+func NewPlaceSubmissionHandler(service usecase.PlaceSubmissionService) *PlaceSubmissionHandler {
+	return &PlaceSubmissionHandler{
+		service: service,
+	}
+}
+
+func (h *PlaceSubmissionHandler) Get(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := getPositiveIntParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	data, err := h.service.Get(ctx, id, userID)
+	if err != nil {
+		return submissionError(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.CreatePlaceSubmissionResponse(helpers.FromPtr(data)))
+}
+
+// GetMy Получить предложения текущего пользователя
+func (h *PlaceSubmissionHandler) GetMy(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	data, err := h.service.GetByAuthor(ctx, userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError).SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.CreatePlaceSubmissionResponseList(data))
+}
+
+// GetQueue Получить очередь модерации
+func (h *PlaceSubmissionHandler) GetQueue(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	data, err := h.service.GetQueue(ctx)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError).SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.CreatePlaceSubmissionResponseList(data))
+}
+
+func (h *PlaceSubmissionHandler) Post(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.PlaceSubmissionPostRequest
+
+	if err = bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	data, err := h.service.Create(ctx, model.PlaceSubmission{
+		PlaceID:  req.PlaceID,
+		AuthorID: &userID,
+		Place:    placeFromRequest(req.PlaceSubmissionPutRequest),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrPlaceNotFound):
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError, "невозможно добавить предложение").SetInternal(err)
+		}
+	}
+
+	dataRec := helpers.FromPtr(data)
+
+	c.Response().Header().Set("location", "/place-submissions/"+strconv.Itoa(dataRec.ID))
+
+	return c.JSON(http.StatusCreated, dto.CreatePlaceSubmissionResponse(dataRec))
+}
+
+func (h *PlaceSubmissionHandler) Put(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := getPositiveIntParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.PlaceSubmissionPutRequest
+
+	if err = bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	err = h.service.Update(ctx, id, userID, placeFromRequest(req))
+	if err != nil {
+		return submissionError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"message": "предложение обновлено"})
+}
+
+// Submit Отправить предложение на модерацию
+func (h *PlaceSubmissionHandler) Submit(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := getPositiveIntParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	err = h.service.Submit(ctx, id, userID)
+	if err != nil {
+		return submissionError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"message": "предложение отправлено на модерацию"})
+}
+
+// Approve Одобрить предложение
+func (h *PlaceSubmissionHandler) Approve(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	moderatorID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := getPositiveIntParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	data, err := h.service.Approve(ctx, id, moderatorID)
+	if err != nil {
+		return submissionError(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.CreatePlaceResponse(helpers.FromPtr(data)))
+}
+
+// Reject Отклонить предложение
+func (h *PlaceSubmissionHandler) Reject(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	moderatorID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := getPositiveIntParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req dto.PlaceSubmissionRejectRequest
+
+	if err = bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	err = h.service.Reject(ctx, id, moderatorID, req.Reason)
+	if err != nil {
+		return submissionError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"message": "предложение отклонено"})
+}
+
+func placeFromRequest(req dto.PlaceSubmissionPutRequest) model.Place {
+	return model.Place{
+		PlaceTypeID:  req.PlaceTypeID,
+		CityID:       req.CityID,
+		Name:         req.Name,
+		Address:      req.Address,
+		Latitude:     req.Latitude,
+		Longitude:    req.Longitude,
+		Description:  req.Description,
+		FoundingYear: req.FoundingYear,
+		Status:       model.PlaceStatus(req.Status),
+	}
+}
+
+func submissionError(err error) error {
+	switch {
+	case errors.Is(err, usecase.ErrSubmissionNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	case errors.Is(err, usecase.ErrSubmissionForbidden):
+		return echo.NewHTTPError(http.StatusForbidden, err.Error())
+	case localErrors.IsOneOf(err, usecase.ErrSubmissionNotEditable, usecase.ErrSubmissionInvalidState):
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	case errors.Is(err, usecase.ErrSubmissionReasonRequired), isPlaceValidationError(err):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	default:
+		return echo.NewHTTPError(http.StatusInternalServerError, "ошибка обработки предложения").SetInternal(err)
+	}
+}
@@ -29,7 +29,7 @@ func (h *PlaceTypeHandler) Get(c echo.Context) error {
 
 	id, err := getPositiveIntParam(c, "id")
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "ошибка получения типа святого места по id: "+err.Error())
+		return err
 	}
 
 	data, err = h.service.Get(ctx, id)
@@ -39,7 +39,7 @@ func (h *PlaceTypeHandler) Get(c echo.Context) error {
 		case errors.Is(err, localErrors.ErrNotFound):
 			return echo.NewHTTPError(http.StatusNotFound, err.Error())
 		default:
-			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+			return echo.NewHTTPError(http.StatusInternalServerError).SetInternal(err)
 		}
 	}
 
@@ -54,7 +54,7 @@ func (h *PlaceTypeHandler) GetAll(c echo.Context) error {
 	if err != nil {
 		switch {
 		default:
-			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+			return echo.NewHTTPError(http.StatusInternalServerError).SetInternal(err)
 		}
 	}
 
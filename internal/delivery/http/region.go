@@ -2,7 +2,6 @@ package http
 
 import (
 	"errors"
-	"fmt"
 	"net/http"
 	"strconv"
 
@@ -32,7 +31,7 @@ func (h *RegionHandler) Get(c echo.Context) error {
 
 	id, err := getPositiveIntParam(c, "id")
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "ошибка получения региона по id: "+err.Error())
+		return err
 	}
 
 	data, err = h.service.Get(ctx, id)
@@ -42,7 +41,7 @@ func (h *RegionHandler) Get(c echo.Context) error {
 	}
 
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError).SetInternal(err)
 	}
 
 	return c.JSON(http.StatusOK, dto.CreateRegionResponse(helpers.FromPtr(data)))
@@ -58,7 +57,7 @@ func (h *RegionHandler) GetByCountry(c echo.Context) error {
 		case errors.Is(err, usecase.ErrCountryNotFound):
 			return echo.NewHTTPError(http.StatusNotFound, err.Error())
 		default:
-			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+			return echo.NewHTTPError(http.StatusInternalServerError).SetInternal(err)
 		}
 	}
 
@@ -70,8 +69,8 @@ func (h *RegionHandler) Post(c echo.Context) error {
 
 	var req dto.RegionPostRequest
 
-	if err := c.Bind(&req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	if err := bindRequest(c, &req); err != nil {
+		return err
 	}
 
 	data, err := h.service.Create(ctx, model.Region{
@@ -84,10 +83,7 @@ func (h *RegionHandler) Post(c echo.Context) error {
 		case localErrors.IsOneOf(err, usecase.ErrCountryHasNotRegions, usecase.ErrRegionNotUnique):
 			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 		default:
-			return echo.NewHTTPError(
-				http.StatusInternalServerError,
-				fmt.Sprintf("невозможно добавить регион: %s", err.Error()),
-			)
+			return echo.NewHTTPError(http.StatusInternalServerError, "невозможно добавить регион").SetInternal(err)
 		}
 	}
 
@@ -103,13 +99,13 @@ func (h *RegionHandler) Put(c echo.Context) error {
 
 	id, err := getPositiveIntParam(c, "id")
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "ошибка получения региона по id: "+err.Error())
+		return err
 	}
 
 	var req dto.RegionPutRequest
 
-	if err = c.Bind(&req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	if err = bindRequest(c, &req); err != nil {
+		return err
 	}
 
 	err = h.service.Update(ctx, id, model.Region{
@@ -124,10 +120,7 @@ func (h *RegionHandler) Put(c echo.Context) error {
 		case localErrors.IsOneOf(err, usecase.ErrCountryHasNotRegions, usecase.ErrRegionNotUnique):
 			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 		default:
-			return echo.NewHTTPError(
-				http.StatusInternalServerError,
-				fmt.Sprintf("невозможно изменить регион: %s", err.Error()),
-			)
+			return echo.NewHTTPError(http.StatusInternalServerError, "невозможно изменить регион").SetInternal(err)
 		}
 	}
 
@@ -139,7 +132,7 @@ func (h *RegionHandler) Delete(c echo.Context) error {
 
 	id, err := getPositiveIntParam(c, "id")
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "ошибка получения региона по id: "+err.Error())
+		return err
 	}
 
 	err = h.service.Delete(ctx, id)
@@ -149,12 +142,56 @@ func (h *RegionHandler) Delete(c echo.Context) error {
 		case localErrors.IsOneOf(err, usecase.ErrRegionNotFound):
 			return echo.NewHTTPError(http.StatusNotFound, err.Error())
 		default:
-			return echo.NewHTTPError(
-				http.StatusInternalServerError,
-				fmt.Sprintf("невозможно удалить регион: %s", err.Error()),
-			)
+			return echo.NewHTTPError(http.StatusInternalServerError, "невозможно удалить регион").SetInternal(err)
 		}
 	}
 
 	return c.JSON(http.StatusOK, map[string]any{"message": "регион удален"})
 }
+
+// Revisions Получить историю изменений региона
+func (h *RegionHandler) Revisions(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := getPositiveIntParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	data, err := h.service.GetRevisions(ctx, id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError).SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.CreateRevisionResponseList(data))
+}
+
+// Restore Вернуть регион к состоянию после указанной ревизии
+func (h *RegionHandler) Restore(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := getPositiveIntParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	revisionID, err := getPositiveIntParam(c, "rev")
+	if err != nil {
+		return err
+	}
+
+	err = h.service.Restore(ctx, id, revisionID)
+	if err != nil {
+		return restoreError(err, usecase.ErrRegionNotFound, isRegionValidationError)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"message": "регион возвращен к выбранной ревизии"})
+}
+
+func isRegionValidationError(err error) bool {
+	return localErrors.IsOneOf(err,
+		usecase.ErrCountryNotFound,
+		usecase.ErrCountryHasNotRegions,
+		usecase.ErrRegionNotUnique,
+	)
+}
@@ -0,0 +1,24 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"palback/internal/usecase"
+)
+
+// restoreError Преобразовать ошибку возврата записи к ревизии в ответ
+func restoreError(err error, notFound error, isValidationError func(error) bool) error {
+	switch {
+	case errors.Is(err, usecase.ErrRevisionNotFound), errors.Is(err, notFound):
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	case errors.Is(err, usecase.ErrRevisionNotRestorable), errors.Is(err, usecase.ErrRevisionEntityDeleted):
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	case isValidationError != nil && isValidationError(err):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	default:
+		return echo.NewHTTPError(http.StatusInternalServerError, "невозможно вернуться к ревизии").SetInternal(err)
+	}
+}
@@ -0,0 +1,73 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"palback/internal/delivery/http/dto"
+	"palback/internal/domain/model"
+	"palback/internal/pkg/helpers"
+	"palback/internal/usecase"
+)
+
+type RoleHandler struct {
+	service usecase.RoleService
+}
+
+func NewRoleHandler(service usecase.RoleService) *RoleHandler {
+	return &RoleHandler{
+		service: service,
+	}
+}
+
+// GetAll Получить роли вместе с их правами
+func (h *RoleHandler) GetAll(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	data, err := h.service.GetAll(ctx)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "ошибка получения ролей")
+	}
+
+	return c.JSON(http.StatusOK, dto.CreateRoleDetailResponseList(data))
+}
+
+// Permissions Получить все права, которые можно выдать ролям
+func (h *RoleHandler) Permissions(c echo.Context) error {
+	return c.JSON(http.StatusOK, dto.CreatePermissionListResponse(model.Permissions))
+}
+
+// PutPermissions Заменить права роли
+func (h *RoleHandler) PutPermissions(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id := model.RoleID(c.Param("id"))
+
+	var req dto.RolePermissionsPutRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	permissions := make([]model.Permission, 0, len(req.Permissions))
+	for _, permission := range req.Permissions {
+		permissions = append(permissions, model.Permission(permission))
+	}
+
+	data, err := h.service.UpdatePermissions(ctx, id, permissions)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrRoleNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		case errors.Is(err, usecase.ErrRoleInvalidPermission):
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		case errors.Is(err, usecase.ErrRoleNotEditable):
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError, "ошибка изменения прав роли")
+		}
+	}
+
+	return c.JSON(http.StatusOK, dto.CreateRoleDetailResponse(helpers.FromPtr(data)))
+}
@@ -1,88 +1,228 @@
 package http
 
 import (
-	"net/http"
-	"palback/internal/usecase/port"
+	"fmt"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 
 	"palback/internal/config"
 	mwApp "palback/internal/delivery/http/middleware"
+	"palback/internal/domain/model"
+	"palback/internal/usecase/port"
 )
 
-type CustomValidator struct {
-	validator *validator.Validate
-}
+// RateLimiters Алгоритмы ограничения частоты запросов, из которых для каждого маршрута выбирается подходящий
+type RateLimiters struct {
+	// SlidingLog Точный подсчет запросов в скользящем окне, для редких операций с жесткой квотой
+	SlidingLog port.RateLimiter
 
-// Validate реализует интерфейс echo.Validator
-func (cv *CustomValidator) Validate(i any) error {
-	if err := cv.validator.Struct(i); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
-	}
-	return nil
+	// TokenBucket Допускает короткие всплески, для частых операций вроде входа
+	TokenBucket port.RateLimiter
 }
 
 func NewRouter(
 	cfg *config.Config,
 	authenticator Authenticator,
-	rateLimiter port.RateLimiter,
+	bearerAuthenticator mwApp.GetterUserID,
+	roleGetter mwApp.GetterUserRole,
+	permissionChecker mwApp.PermissionChecker,
+	rateLimiters RateLimiters,
 	countryHandler *CountryHandler,
 	regionHandler *RegionHandler,
 	cityTypeHandler *CityTypeHandler,
+	cityHandler *CityHandler,
 	placeTypeHandler *PlaceTypeHandler,
+	placeHandler *PlaceHandler,
+	placeSubmissionHandler *PlaceSubmissionHandler,
+	placePhotoHandler *PlacePhotoHandler,
 	userHandler *UserHandler,
+	accessTokenHandler *AccessTokenHandler,
+	externalLoginHandler *ExternalLoginHandler,
+	adminUserHandler *AdminUserHandler,
+	roleHandler *RoleHandler,
+	auditHandler *AuditHandler,
+	translationHandler *TranslationHandler,
+	emailOutboxHandler *EmailOutboxHandler,
 ) *echo.Echo {
 	e := echo.New()
-	e.Validator = &CustomValidator{validator: validator.New()}
+	e.Validator = NewCustomValidator()
+	// X-Forwarded-For учитывается только от прокси из внутренних сетей, иначе клиент может подставить любой адрес
+	e.IPExtractor = echo.ExtractIPFromXFFHeader()
+	e.HTTPErrorHandler = NewHTTPErrorHandler(cfg.IsProduction)
 
 	e.Use(middleware.Logger())
-	e.Use(mwApp.AuthMiddleware(authenticator))
+	e.Use(mwApp.ClientMiddleware())
+	e.Use(mwApp.SetupLanguage())
+	e.Use(mwApp.AuthMiddleware(bearerAuthenticator, authenticator))
 	e.Use(middleware.Recover())
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
 		AllowOrigins:     []string{cfg.FrontendOrigin},
 		AllowCredentials: true,
+		ExposeHeaders:    []string{"RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "Retry-After"},
 	}))
 
+	// Изменять права ролей может только администратор
+	requireAdmin := []echo.MiddlewareFunc{
+		mwApp.RequireAuth(),
+		mwApp.RequireRole(roleGetter, model.RoleAdmin),
+	}
+
+	// Остальные административные операции доступны ролям с соответствующим правом
+	requirePermission := func(permission model.Permission) []echo.MiddlewareFunc {
+		return []echo.MiddlewareFunc{
+			mwApp.RequireAuth(),
+			mwApp.RequirePermission(permissionChecker, permission),
+		}
+	}
+	requireCatalogEdit := requirePermission(model.PermissionCatalogEdit)
+	requirePlacesModerate := requirePermission(model.PermissionPlacesModerate)
+	requireUsersManage := requirePermission(model.PermissionUsersManage)
+
 	// Работа со странами
 	e.GET("/countries/:id", countryHandler.Get)
 	e.GET("/countries", countryHandler.GetAll)
-	e.POST("/countries", countryHandler.Post)
-	e.PUT("/countries/:id", countryHandler.Put)
-	e.DELETE("/countries/:id", countryHandler.Delete)
-	e.POST("/countries/order", countryHandler.Order)
+	e.POST("/countries", countryHandler.Post, requireCatalogEdit...)
+	e.PUT("/countries/:id", countryHandler.Put, requireCatalogEdit...)
+	e.DELETE("/countries/:id", countryHandler.Delete, requireCatalogEdit...)
+	e.POST("/countries/order", countryHandler.Order, requireCatalogEdit...)
+	e.GET("/countries/:id/revisions", countryHandler.Revisions, requireCatalogEdit...)
+	e.POST("/countries/:id/revisions/:rev/restore", countryHandler.Restore, requireCatalogEdit...)
 
 	// Работа с регионами
 	e.GET("/regions/:id", regionHandler.Get)
 	e.GET("/countries/:id/regions", regionHandler.GetByCountry)
-	e.POST("/regions", regionHandler.Post)
-	e.PUT("/regions/:id", regionHandler.Put)
-	e.DELETE("/regions/:id", regionHandler.Delete)
+	e.POST("/regions", regionHandler.Post, requireCatalogEdit...)
+	e.PUT("/regions/:id", regionHandler.Put, requireCatalogEdit...)
+	e.DELETE("/regions/:id", regionHandler.Delete, requireCatalogEdit...)
+	e.GET("/regions/:id/revisions", regionHandler.Revisions, requireCatalogEdit...)
+	e.POST("/regions/:id/revisions/:rev/restore", regionHandler.Restore, requireCatalogEdit...)
 
 	// Работа с типами населенных пунктов
 	e.GET("/city-types/:id", cityTypeHandler.Get)
 	e.GET("/city-types", cityTypeHandler.GetAll)
 
+	// Работа с населенными пунктами
+	e.GET("/cities/:id", cityHandler.Get)
+	e.GET("/countries/:id/cities", cityHandler.GetByCountry)
+	e.POST("/cities", cityHandler.Post, requireCatalogEdit...)
+	e.PUT("/cities/:id", cityHandler.Put, requireCatalogEdit...)
+	e.DELETE("/cities/:id", cityHandler.Delete, requireCatalogEdit...)
+	e.GET("/cities/:id/revisions", cityHandler.Revisions, requireCatalogEdit...)
+	e.POST("/cities/:id/revisions/:rev/restore", cityHandler.Restore, requireCatalogEdit...)
+
 	// Работа с типами святых мест
 	e.GET("/place-types/:id", placeTypeHandler.Get)
 	e.GET("/place-types", placeTypeHandler.GetAll)
 
+	// Работа со святыми местами
+	e.GET("/places/nearby", placeHandler.GetNearby)
+	e.GET("/places/:id", placeHandler.Get)
+	e.GET("/places", placeHandler.GetList)
+	e.POST("/places", placeHandler.Post, requireCatalogEdit...)
+	e.PUT("/places/:id", placeHandler.Put, requireCatalogEdit...)
+	e.DELETE("/places/:id", placeHandler.Delete, requireCatalogEdit...)
+	e.GET("/places/:id/revisions", placeHandler.Revisions, requireCatalogEdit...)
+	e.POST("/places/:id/revisions/:rev/restore", placeHandler.Restore, requireCatalogEdit...)
+
+	// Галерея фотографий святых мест
+	e.GET("/places/:id/photos", placePhotoHandler.GetByPlace)
+	e.GET("/places/:id/photos/:photo/:file", placePhotoHandler.Download)
+	e.POST("/places/:id/photos", placePhotoHandler.Post, mwApp.RequireAuth(),
+		middleware.BodyLimit(fmt.Sprintf("%dM", cfg.PhotoMaxSizeMB+1)))
+	e.PUT("/places/:id/photos/:photo", placePhotoHandler.Put, mwApp.RequireAuth())
+	e.POST("/places/:id/photos/:photo/cover", placePhotoHandler.SetCover, mwApp.RequireAuth())
+	e.DELETE("/places/:id/photos/:photo", placePhotoHandler.Delete, mwApp.RequireAuth())
+
+	// Переводы названий записей справочников
+	e.GET("/admin/translations/:entity/:id", translationHandler.GetByEntity, requireCatalogEdit...)
+	e.PUT("/admin/translations/:entity/:id/:lang", translationHandler.Put, requireCatalogEdit...)
+	e.DELETE("/admin/translations/:entity/:id/:lang", translationHandler.Delete, requireCatalogEdit...)
+
+	// Предложения святых мест от пользователей и их модерация
+	e.GET("/place-submissions/my", placeSubmissionHandler.GetMy, mwApp.RequireAuth())
+	e.GET("/place-submissions/queue", placeSubmissionHandler.GetQueue, requirePlacesModerate...)
+	e.GET("/place-submissions/:id", placeSubmissionHandler.Get, mwApp.RequireAuth())
+	e.POST("/place-submissions", placeSubmissionHandler.Post, mwApp.RequireAuth())
+	e.PUT("/place-submissions/:id", placeSubmissionHandler.Put, mwApp.RequireAuth())
+	e.POST("/place-submissions/:id/submit", placeSubmissionHandler.Submit, mwApp.RequireAuth())
+	e.POST("/place-submissions/:id/approve", placeSubmissionHandler.Approve, requirePlacesModerate...)
+	e.POST("/place-submissions/:id/reject", placeSubmissionHandler.Reject, requirePlacesModerate...)
+
+	// Управление пользователями
+	e.GET("/admin/users", adminUserHandler.GetList, requireUsersManage...)
+	e.GET("/admin/users/:id", adminUserHandler.Get, requireUsersManage...)
+	e.PUT("/admin/users/:id/role", adminUserHandler.ChangeRole, requireUsersManage...)
+	e.POST("/admin/users/:id/verify-email", adminUserHandler.VerifyEmail, requireUsersManage...)
+	e.POST("/admin/users/:id/block", adminUserHandler.Block, requireUsersManage...)
+	e.DELETE("/admin/users/:id/block", adminUserHandler.Unblock, requireUsersManage...)
+	e.POST("/admin/users/:id/logout", adminUserHandler.Logout, requireUsersManage...)
+	e.DELETE("/admin/users/:id", adminUserHandler.Delete, requireUsersManage...)
+
+	// Роли и их права
+	e.GET("/admin/roles", roleHandler.GetAll, requireAdmin...)
+	e.GET("/admin/permissions", roleHandler.Permissions, requireAdmin...)
+	e.PUT("/admin/roles/:id/permissions", roleHandler.PutPermissions, requireAdmin...)
+
+	// Журнал аудита
+	e.GET("/admin/audit", auditHandler.GetList, requireAdmin...)
+	e.GET("/admin/audit/export", auditHandler.Export, requireAdmin...)
+
+	// Очередь писем
+	e.GET("/admin/emails", emailOutboxHandler.GetList, requireAdmin...)
+	e.GET("/admin/emails/:id", emailOutboxHandler.Get, requireAdmin...)
+	e.POST("/admin/emails/:id/resend", emailOutboxHandler.Resend, requireAdmin...)
+
+	// Блокировки входа после неудачных попыток
+	e.GET("/admin/lockouts", userHandler.LoginLockouts, requireUsersManage...)
+	e.DELETE("/admin/lockouts/:id", userHandler.ClearLoginLockout, requireUsersManage...)
+
 	// Работа с пользователями
 	e.POST("/users/register", userHandler.Register,
-		mwApp.RateLimitByIP(rateLimiter, 5*100, 600, "register"))
+		mwApp.RateLimitByIP(rateLimiters.SlidingLog, 5*100, 600, "register"))
 	e.POST("/users/verify-email", userHandler.VerifyEmail)
 	e.POST("/users/resend-verification", userHandler.ResendVerification,
-		mwApp.RateLimitByIP(rateLimiter, 5*100, 60, "resend-verification"))
+		mwApp.RateLimitByIP(rateLimiters.SlidingLog, 5*100, 60, "resend-verification"))
 	e.POST("/users/login", userHandler.Login,
-		mwApp.RateLimitByIP(rateLimiter, 5*100, 60, "login"))
+		mwApp.RateLimitByIP(rateLimiters.TokenBucket, 5*100, 60, "login"))
+	e.POST("/users/login/2fa", userHandler.LoginTwoFactor,
+		mwApp.RateLimitByIP(rateLimiters.SlidingLog, 10, 60, "login-2fa"))
 	e.POST("/users/logout", userHandler.Logout)
+	e.POST("/users/unlock", userHandler.UnlockAccount,
+		mwApp.RateLimitByIP(rateLimiters.SlidingLog, 10, 60, "unlock"))
 	e.POST("/users/reset-password", userHandler.ResetPassword,
-		mwApp.RateLimitByIP(rateLimiter, 6*100, 3600, "reset"))
+		mwApp.RateLimitByIP(rateLimiters.SlidingLog, 6*100, 3600, "reset"))
 	e.POST("/users/reset-password/confirm", userHandler.ResetPasswordConfirm)
 	e.GET("/users/me", userHandler.Me)
-	e.GET("/users/profile", userHandler.ResetPassword)
-	e.DELETE("users/delete", userHandler.Delete)
+	e.POST("/users/me/password", userHandler.ChangePassword, mwApp.RequireSession())
+	e.POST("/users/me/email", userHandler.ChangeEmail, mwApp.RequireSession(),
+		mwApp.RateLimitByIP(rateLimiters.SlidingLog, 5, 3600, "change-email"))
+	e.POST("/users/me/email/confirm", userHandler.ConfirmEmailChange)
+	e.POST("/users/me/2fa/setup", userHandler.SetupTwoFactor, mwApp.RequireSession())
+	e.POST("/users/me/2fa/enable", userHandler.EnableTwoFactor, mwApp.RequireSession())
+	e.DELETE("/users/me/2fa", userHandler.DisableTwoFactor, mwApp.RequireSession())
+	e.POST("/users/me/2fa/recovery-codes", userHandler.RegenerateRecoveryCodes, mwApp.RequireSession())
+	e.GET("/users/oidc/providers", externalLoginHandler.Providers)
+	e.GET("/users/oidc/:provider/authorize", externalLoginHandler.Authorize,
+		mwApp.RateLimitByIP(rateLimiters.TokenBucket, 30, 60, "oidc-authorize"))
+	e.GET("/users/oidc/:provider/link", externalLoginHandler.Link, mwApp.RequireSession())
+	e.POST("/users/oidc/:provider/callback", externalLoginHandler.Callback,
+		mwApp.RateLimitByIP(rateLimiters.TokenBucket, 30, 60, "oidc-callback"))
+	e.GET("/users/me/identities", externalLoginHandler.Identities, mwApp.RequireAuth())
+	e.DELETE("/users/me/identities/:id", externalLoginHandler.Unlink, mwApp.RequireSession())
+	e.GET("/users/me/tokens", accessTokenHandler.GetAll, mwApp.RequireSession())
+	e.POST("/users/me/tokens", accessTokenHandler.Post, mwApp.RequireSession())
+	e.DELETE("/users/me/tokens/:id", accessTokenHandler.Delete, mwApp.RequireSession())
+	e.GET("/users/me/sessions", userHandler.Sessions, mwApp.RequireSession())
+	e.DELETE("/users/me/sessions", userHandler.RevokeOtherSessions, mwApp.RequireSession())
+	e.DELETE("/users/me/sessions/:id", userHandler.RevokeSession, mwApp.RequireSession())
+	e.GET("/users/profile", userHandler.Profile, mwApp.RequireAuth())
+	e.PUT("/users/profile", userHandler.UpdateProfile, mwApp.RequireAuth())
+	e.PUT("/users/profile/avatar", userHandler.UploadAvatar, mwApp.RequireAuth(),
+		middleware.BodyLimit(fmt.Sprintf("%dM", cfg.AvatarMaxSizeMB+1)))
+	e.DELETE("/users/profile/avatar", userHandler.DeleteAvatar, mwApp.RequireAuth())
+	e.GET("/users/:id/avatar/:file", userHandler.Avatar)
+	e.DELETE("/users/delete", userHandler.Delete, mwApp.RequireSession())
 
 	return e
 }
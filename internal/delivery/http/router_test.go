@@ -0,0 +1,175 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"palback/internal/config"
+	"palback/internal/domain/model"
+)
+
+const (
+	testAdminID = 1
+	testUserID  = 2
+)
+
+// fakeSessions Сессия определяется по cookie session с id пользователя
+type fakeSessions struct {
+	Authenticator
+}
+
+func (fakeSessions) GetUserID(_ context.Context, r *http.Request) (int, error) {
+	cookie, err := r.Cookie("session")
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(cookie.Value)
+}
+
+// fakeBearer Токен доступа — id пользователя в заголовке Authorization
+type fakeBearer struct{}
+
+func (fakeBearer) GetUserID(_ context.Context, r *http.Request) (int, error) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return 0, nil
+	}
+
+	return strconv.Atoi(token)
+}
+
+type fakeRoles struct{}
+
+func (fakeRoles) GetRole(_ context.Context, userID int) (*model.Role, error) {
+	if userID == testAdminID {
+		return &model.Role{ID: model.RoleAdmin}, nil
+	}
+
+	return &model.Role{ID: model.RoleUser}, nil
+}
+
+func (fakeRoles) HasPermission(_ context.Context, userID int, _ model.Permission) (bool, error) {
+	return userID == testAdminID, nil
+}
+
+func newTestRouter() http.Handler {
+	var roles fakeRoles
+
+	return NewRouter(
+		&config.Config{PhotoMaxSizeMB: 1, AvatarMaxSizeMB: 1},
+		fakeSessions{},
+		fakeBearer{},
+		roles,
+		roles,
+		RateLimiters{},
+		&CountryHandler{},
+		&RegionHandler{},
+		&CityTypeHandler{},
+		&CityHandler{},
+		&PlaceTypeHandler{},
+		&PlaceHandler{},
+		&PlaceSubmissionHandler{},
+		&PlacePhotoHandler{},
+		&UserHandler{},
+		&AccessTokenHandler{},
+		&ExternalLoginHandler{},
+		&AdminUserHandler{},
+		&RoleHandler{},
+		&AuditHandler{},
+		&TranslationHandler{},
+		&EmailOutboxHandler{},
+	)
+}
+
+// TestCatalogWriteRoutesAccess Изменять справочники может только роль с правом catalog.edit:
+// анонимный запрос получает 401, а обычный пользователь — 403
+func TestCatalogWriteRoutesAccess(t *testing.T) {
+	router := newTestRouter()
+
+	routes := []struct {
+		method string
+		path   string
+	}{
+		{http.MethodPost, "/countries"},
+		{http.MethodPut, "/countries/ru"},
+		{http.MethodDelete, "/countries/ru"},
+		{http.MethodPost, "/countries/order"},
+		{http.MethodPost, "/regions"},
+		{http.MethodPut, "/regions/1"},
+		{http.MethodDelete, "/regions/1"},
+		{http.MethodPost, "/cities"},
+		{http.MethodPut, "/cities/1"},
+		{http.MethodDelete, "/cities/1"},
+		{http.MethodPost, "/places"},
+		{http.MethodPut, "/places/1"},
+		{http.MethodDelete, "/places/1"},
+		{http.MethodPost, "/places/1/revisions/1/restore"},
+	}
+
+	users := []struct {
+		name   string
+		userID int
+		want   int
+	}{
+		{"anonymous", 0, http.StatusUnauthorized},
+		{"user", testUserID, http.StatusForbidden},
+	}
+
+	for _, route := range routes {
+		for _, user := range users {
+			t.Run(user.name+" "+route.method+" "+route.path, func(t *testing.T) {
+				req := httptest.NewRequest(route.method, route.path, nil)
+				if user.userID > 0 {
+					req.AddCookie(&http.Cookie{Name: "session", Value: strconv.Itoa(user.userID)})
+				}
+
+				rec := httptest.NewRecorder()
+				router.ServeHTTP(rec, req)
+
+				if rec.Code != user.want {
+					t.Errorf("status = %d, want %d", rec.Code, user.want)
+				}
+			})
+		}
+	}
+}
+
+// TestAccountRoutesRequireSession Управлять учетной записью можно только из сессии:
+// запрос с токеном доступа получает 403
+func TestAccountRoutesRequireSession(t *testing.T) {
+	router := newTestRouter()
+
+	routes := []struct {
+		method string
+		path   string
+	}{
+		{http.MethodPost, "/users/me/password"},
+		{http.MethodPost, "/users/me/email"},
+		{http.MethodPost, "/users/me/2fa/setup"},
+		{http.MethodPost, "/users/me/2fa/enable"},
+		{http.MethodDelete, "/users/me/2fa"},
+		{http.MethodPost, "/users/me/2fa/recovery-codes"},
+		{http.MethodGet, "/users/oidc/google/link"},
+		{http.MethodDelete, "/users/me/identities/1"},
+		{http.MethodDelete, "/users/delete"},
+	}
+
+	for _, route := range routes {
+		t.Run(route.method+" "+route.path, func(t *testing.T) {
+			req := httptest.NewRequest(route.method, route.path, nil)
+			req.Header.Set("Authorization", "Bearer "+strconv.Itoa(testUserID))
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusForbidden {
+				t.Errorf("status = %d, want %d", rec.Code, http.StatusForbidden)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"palback/internal/delivery/http/dto"
+	"palback/internal/domain/model"
+	"palback/internal/pkg/helpers"
+	"palback/internal/usecase"
+)
+
+// TranslationHandler Управление переводами названий записей справочников
+type TranslationHandler struct {
+	service usecase.TranslationService
+}
+
+func NewTranslationHandler(service usecase.TranslationService) *TranslationHandler {
+	return &TranslationHandler{
+		service: service,
+	}
+}
+
+// GetByEntity Получить переводы записи справочника на все языки
+func (h *TranslationHandler) GetByEntity(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	data, err := h.service.GetByEntity(ctx, model.TranslationEntity(c.Param("entity")), c.Param("id"))
+	if err != nil {
+		return translationError(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.CreateTranslationResponseList(data))
+}
+
+// Put Добавить или заменить перевод записи справочника на указанный язык
+func (h *TranslationHandler) Put(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req dto.TranslationPutRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	data, err := h.service.Save(ctx, model.Translation{
+		Entity:    model.TranslationEntity(c.Param("entity")),
+		EntityID:  c.Param("id"),
+		Lang:      c.Param("lang"),
+		Name:      req.Name,
+		ShortName: req.ShortName,
+	})
+	if err != nil {
+		return translationError(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.CreateTranslationResponse(helpers.FromPtr(data)))
+}
+
+// Delete Удалить перевод записи справочника на указанный язык
+func (h *TranslationHandler) Delete(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	err := h.service.Delete(ctx, model.TranslationEntity(c.Param("entity")), c.Param("id"), c.Param("lang"))
+	if err != nil {
+		return translationError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"message": "перевод удален"})
+}
+
+func translationError(err error) error {
+	switch {
+	case errors.Is(err, usecase.ErrTranslationNotFound),
+		errors.Is(err, usecase.ErrTranslationEntityNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	case errors.Is(err, usecase.ErrTranslationInvalidEntity),
+		errors.Is(err, usecase.ErrTranslationLangNotSupported),
+		errors.Is(err, usecase.ErrTranslationDefaultLang),
+		errors.Is(err, usecase.ErrTranslationNameRequired):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	default:
+		return echo.NewHTTPError(http.StatusInternalServerError, "ошибка изменения перевода")
+	}
+}
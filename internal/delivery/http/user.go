@@ -2,32 +2,45 @@ package http
 
 import (
 	"errors"
-	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/gommon/log"
 
 	"palback/internal/delivery/http/dto"
+	mwApp "palback/internal/delivery/http/middleware"
 	"palback/internal/domain/model"
+	localErrors "palback/internal/pkg/errors"
 	"palback/internal/pkg/helpers"
 	"palback/internal/usecase"
+	ucModel "palback/internal/usecase/model"
 	"palback/internal/usecase/port"
 )
 
 type UserHandler struct {
-	service     usecase.UserService
-	auth        Authenticator
-	rateLimiter port.RateLimiter
+	service         usecase.UserService
+	profileService  usecase.UserProfileService
+	deletionService usecase.AccountDeletionService
+	auth            Authenticator
+	rateLimiter     port.RateLimiter
 }
 
-func NewUserHandler(service usecase.UserService, auth Authenticator, rateLimiter port.RateLimiter) *UserHandler {
+func NewUserHandler(
+	service usecase.UserService,
+	profileService usecase.UserProfileService,
+	deletionService usecase.AccountDeletionService,
+	auth Authenticator,
+	rateLimiter port.RateLimiter,
+) *UserHandler {
 	return &UserHandler{
-		service:     service,
-		auth:        auth,
-		rateLimiter: rateLimiter,
+		service:         service,
+		profileService:  profileService,
+		deletionService: deletionService,
+		auth:            auth,
+		rateLimiter:     rateLimiter,
 	}
 }
 
@@ -36,8 +49,8 @@ func (h *UserHandler) Register(c echo.Context) error {
 
 	var req dto.RegisterRequest
 
-	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, err.Error())
+	if err := bindRequest(c, &req); err != nil {
+		return err
 	}
 
 	email := strings.ToLower(strings.TrimSpace(req.Email))
@@ -45,11 +58,12 @@ func (h *UserHandler) Register(c echo.Context) error {
 	data, err := h.service.Register(ctx, req.Username, email, req.Password)
 	if err != nil {
 		switch {
+		case localErrors.IsOneOf(err, usecase.ErrUserNameNotUnique, usecase.ErrUserEmailNotUnique):
+			return echo.NewHTTPError(http.StatusConflict, err)
+		case isPasswordPolicyError(err):
+			return echo.NewHTTPError(http.StatusBadRequest, err)
 		default:
-			return echo.NewHTTPError(
-				http.StatusInternalServerError,
-				fmt.Sprintf("ошибка регистрации пользователя: %s", err.Error()),
-			)
+			return echo.NewHTTPError(http.StatusInternalServerError, "ошибка регистрации пользователя").SetInternal(err)
 		}
 	}
 
@@ -64,12 +78,8 @@ func (h *UserHandler) VerifyEmail(c echo.Context) error {
 	ctx := c.Request().Context()
 
 	var req dto.VerifyEmailRequest
-	if err := c.Bind(&req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "неверный json")
-	}
-
-	if req.Token == "" {
-		return c.JSON(http.StatusBadRequest, "отсутсвует токен")
+	if err := bindRequest(c, &req); err != nil {
+		return err
 	}
 
 	err := h.service.VerifyEmail(ctx, req.Token)
@@ -78,10 +88,7 @@ func (h *UserHandler) VerifyEmail(c echo.Context) error {
 		case errors.Is(err, usecase.ErrNoReplyFromKeyValueStorage):
 			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 		default:
-			return echo.NewHTTPError(
-				http.StatusInternalServerError,
-				fmt.Errorf("ошибка при проверке e-mail: %w", err),
-			)
+			return echo.NewHTTPError(http.StatusInternalServerError, "ошибка при проверке e-mail").SetInternal(err)
 		}
 	}
 
@@ -92,22 +99,20 @@ func (h *UserHandler) ResendVerification(c echo.Context) error {
 	ctx := c.Request().Context()
 
 	var req dto.ResendVerificationRequest
-	if err := c.Bind(&req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "неверный json")
+	if err := bindRequest(c, &req); err != nil {
+		return err
 	}
 
 	email := strings.ToLower(strings.TrimSpace(req.Email))
 
-	if email == "" {
-		return echo.NewHTTPError(http.StatusBadRequest, "e-mail не задан")
-	}
-
-	allowed, err := h.rateLimiter.Allow(c.Request().Context(), "rl:resend-verification:"+email, 5, 3600)
+	limit, err := h.rateLimiter.Allow(c.Request().Context(), "rl:resend-verification:"+email, 5, 3600)
 	if err != nil {
 		log.Warn("rate limit check failed", "email", email, "error", err)
+	} else {
+		mwApp.SetRateLimitHeaders(c, limit)
 	}
 
-	if !allowed {
+	if !limit.Allowed {
 		return echo.NewHTTPError(
 			http.StatusTooManyRequests,
 			"слишком много запросов на изменение пароля от данного e-mail, подождите некоторое время",
@@ -116,7 +121,7 @@ func (h *UserHandler) ResendVerification(c echo.Context) error {
 
 	err = h.service.ResendVerificationEmail(ctx, email)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "внутренная ошибка сервиса")
+		return echo.NewHTTPError(http.StatusInternalServerError, "внутренная ошибка сервиса").SetInternal(err)
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{
@@ -128,40 +133,64 @@ func (h *UserHandler) Login(c echo.Context) error {
 	ctx := c.Request().Context()
 
 	var req dto.LoginRequest
-	if err := c.Bind(&req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "неверный json")
-	}
-
-	if req.Identifier == "" || req.Password == "" {
-		return echo.NewHTTPError(http.StatusBadRequest, "логин или пароль не заданы")
+	if err := bindRequest(c, &req); err != nil {
+		return err
 	}
 
 	data, err := h.service.Login(ctx, req.Identifier, req.Password)
 	if err != nil || data == nil {
 		switch {
-		case errors.Is(err, usecase.ErrUncheckedEmail):
+		case localErrors.IsOneOf(err, usecase.ErrUncheckedEmail, usecase.ErrUserBlocked):
 			return echo.NewHTTPError(http.StatusForbidden, err)
 		case errors.Is(err, usecase.ErrUserInvalidCredentials):
 			return echo.NewHTTPError(http.StatusUnauthorized, err)
+		case errors.Is(err, usecase.ErrLoginThrottled):
+			return echo.NewHTTPError(http.StatusTooManyRequests, err)
+		case errors.Is(err, usecase.ErrAccountLocked):
+			return echo.NewHTTPError(http.StatusLocked, err)
 		default:
-			return echo.NewHTTPError(
-				http.StatusInternalServerError,
-				fmt.Sprintf("невозможно войти в систему: %s", err),
-			)
+			return echo.NewHTTPError(http.StatusInternalServerError, "невозможно войти в систему").SetInternal(err)
 		}
 	}
 
-	err = h.auth.Login(ctx, c.Response(), c.Request(), &model.User{
-		ID:             data.ID,
-		SessionVersion: data.SessionVersion,
-	})
+	return loginResponse(c, h.auth, *data)
+}
+
+// LoginTwoFactor Второй шаг входа с кодом двухфакторной аутентификации
+func (h *UserHandler) LoginTwoFactor(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req dto.LoginTwoFactorRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	data, err := h.service.LoginTwoFactor(ctx, req.Token, req.Code)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "ошибка сессии")
+		switch {
+		case localErrors.IsOneOf(err, usecase.ErrInvalidToken, usecase.ErrTwoFactorInvalidCode):
+			return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+		default:
+			return twoFactorError(err)
+		}
 	}
 
-	dataRec := helpers.FromPtr(data)
+	return loginResponse(c, h.auth, *data)
+}
+
+// loginResponse Создать сессию, если вход завершен, и вернуть результат входа
+func loginResponse(c echo.Context, auth Authenticator, data ucModel.LoginResult) error {
+	if data.User != nil {
+		err := auth.Login(c.Request().Context(), c.Response(), c.Request(), &model.User{
+			ID:             data.User.ID,
+			SessionVersion: data.User.SessionVersion,
+		})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "ошибка сессии")
+		}
+	}
 
-	return c.JSON(http.StatusOK, dto.CreateUserResponse(dataRec))
+	return c.JSON(http.StatusOK, dto.CreateLoginResponse(data))
 }
 
 func (h *UserHandler) Logout(c echo.Context) error {
@@ -182,23 +211,21 @@ func (h *UserHandler) ResetPassword(c echo.Context) error {
 
 	var req dto.ResetPasswordRequest
 
-	if err := c.Bind(&req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "неверный json")
+	if err := bindRequest(c, &req); err != nil {
+		return err
 	}
 
 	email := strings.ToLower(strings.TrimSpace(req.Email))
 
-	if email == "" {
-		return echo.NewHTTPError(http.StatusBadRequest, "e-mail не задан")
-	}
-
 	// Rate limit по email
-	allowed, err := h.rateLimiter.Allow(c.Request().Context(), "rl:reset:"+email, 5, 3600)
+	limit, err := h.rateLimiter.Allow(c.Request().Context(), "rl:reset:"+email, 5, 3600)
 	if err != nil {
 		log.Warn("rate limit check failed", "email", email, "error", err)
+	} else {
+		mwApp.SetRateLimitHeaders(c, limit)
 	}
 
-	if !allowed {
+	if !limit.Allowed {
 		return echo.NewHTTPError(
 			http.StatusTooManyRequests,
 			"слишком много запросов на изменение пароля от данного e-mail, подождите некоторое время",
@@ -207,7 +234,7 @@ func (h *UserHandler) ResetPassword(c echo.Context) error {
 
 	err = h.service.RequestPasswordReset(ctx, email)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "ошибка выполнения запроса")
+		return echo.NewHTTPError(http.StatusInternalServerError, "ошибка выполнения запроса").SetInternal(err)
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{
@@ -220,12 +247,8 @@ func (h *UserHandler) ResetPasswordConfirm(c echo.Context) error {
 
 	var req dto.ResetPasswordConfirmRequest
 
-	if err := c.Bind(&req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "неверный json")
-	}
-
-	if req.Token == "" || len(req.NewPassword) < 6 {
-		return echo.NewHTTPError(http.StatusBadRequest, "требуется токен и пароль длиной не менее 6 символов")
+	if err := bindRequest(c, &req); err != nil {
+		return err
 	}
 
 	err := h.service.ConfirmPasswordReset(ctx, req.Token, req.NewPassword)
@@ -233,8 +256,10 @@ func (h *UserHandler) ResetPasswordConfirm(c echo.Context) error {
 		switch {
 		case errors.Is(err, usecase.ErrInvalidToken):
 			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		case isPasswordPolicyError(err):
+			return echo.NewHTTPError(http.StatusBadRequest, err)
 		default:
-			return echo.NewHTTPError(http.StatusInternalServerError, "не удалось обновить пароль")
+			return echo.NewHTTPError(http.StatusInternalServerError, "не удалось обновить пароль").SetInternal(err)
 		}
 	}
 
@@ -252,7 +277,7 @@ func (h *UserHandler) Me(c echo.Context) error {
 	}
 
 	if userId <= 0 {
-		return echo.NewHTTPError(http.StatusUnauthorized, "пользователь не авторизован")
+		return echo.NewHTTPError(http.StatusUnauthorized, mwApp.ErrUnauthorized.Error())
 	}
 
 	user, err := h.service.Get(ctx, userId)
@@ -263,10 +288,527 @@ func (h *UserHandler) Me(c echo.Context) error {
 	return c.JSON(http.StatusOK, dto.CreateUserResponse(helpers.FromPtr(user)))
 }
 
+// ChangePassword Сменить пароль текущего пользователя. Остальные сессии пользователя завершаются.
+func (h *UserHandler) ChangePassword(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.ChangePasswordRequest
+
+	if err = bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	data, err := h.service.ChangePassword(ctx, userID, req.CurrentPassword, req.NewPassword)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidPassword):
+			return echo.NewHTTPError(http.StatusForbidden, err.Error())
+		case isPasswordPolicyError(err):
+			return echo.NewHTTPError(http.StatusBadRequest, err)
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError, "не удалось обновить пароль").SetInternal(err)
+		}
+	}
+
+	// Текущая сессия устарела вместе с остальными, поэтому создается заново
+	err = h.auth.Login(ctx, c.Response(), c.Request(), &model.User{
+		ID:             data.ID,
+		SessionVersion: data.SessionVersion,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "ошибка сессии")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "пароль был успешно обновлён",
+	})
+}
+
+// ChangeEmail Запросить смену e-mail текущего пользователя
+func (h *UserHandler) ChangeEmail(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.ChangeEmailRequest
+
+	if err = bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+
+	err = h.service.RequestEmailChange(ctx, userID, req.Password, email)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidPassword):
+			return echo.NewHTTPError(http.StatusForbidden, err.Error())
+		case localErrors.IsOneOf(err, usecase.ErrEmailNotChanged, usecase.ErrUserEmailNotUnique):
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError, "невозможно сменить e-mail").SetInternal(err)
+		}
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "письмо для подтверждения отправлено на новый e-mail",
+	})
+}
+
+// ConfirmEmailChange Подтвердить смену e-mail по токену из письма
+func (h *UserHandler) ConfirmEmailChange(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req dto.ConfirmEmailChangeRequest
+
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	err := h.service.ConfirmEmailChange(ctx, req.Token)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidToken):
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		case errors.Is(err, usecase.ErrUserEmailNotUnique):
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError, "невозможно сменить e-mail").SetInternal(err)
+		}
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "e-mail успешно изменен",
+	})
+}
+
+// Sessions Получить список активных сессий текущего пользователя
+func (h *UserHandler) Sessions(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	sessions, err := h.auth.ListSessions(ctx, c.Request(), userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "ошибка получения списка сессий")
+	}
+
+	result := make([]dto.SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		result = append(result, dto.CreateSessionResponse(session))
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// RevokeSession Завершить одну из сессий текущего пользователя
+func (h *UserHandler) RevokeSession(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	err = h.auth.RevokeSession(ctx, userID, c.Param("id"))
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrSessionNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError, "ошибка завершения сессии")
+		}
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"message": "сессия завершена"})
+}
+
+// RevokeOtherSessions Завершить все сессии текущего пользователя, кроме текущей
+func (h *UserHandler) RevokeOtherSessions(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	err = h.auth.RevokeOtherSessions(ctx, c.Response(), c.Request(), userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "ошибка завершения сессий").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"message": "все остальные сессии завершены"})
+}
+
+// UnlockAccount Снять блокировку входа по токену из письма
+func (h *UserHandler) UnlockAccount(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req dto.UnlockAccountRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	err := h.service.UnlockAccount(ctx, req.Token)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidToken):
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		case errors.Is(err, usecase.ErrUserNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError, "ошибка снятия блокировки входа")
+		}
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"message": "блокировка входа снята"})
+}
+
+// LoginLockouts Получить пользователей с неудачными попытками входа
+func (h *UserHandler) LoginLockouts(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	data, err := h.service.GetLoginLockouts(ctx)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "ошибка получения блокировок входа")
+	}
+
+	return c.JSON(http.StatusOK, dto.CreateLoginLockoutResponseList(data, time.Now()))
+}
+
+// ClearLoginLockout Снять блокировку входа пользователя
+func (h *UserHandler) ClearLoginLockout(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getPositiveIntParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	err = h.service.ClearLoginLockout(ctx, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrUserNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError, "ошибка снятия блокировки входа")
+		}
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"message": "блокировка входа снята"})
+}
+
+// SetupTwoFactor Начать настройку двухфакторной аутентификации: получить секрет и ссылку для QR-кода
+func (h *UserHandler) SetupTwoFactor(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	data, err := h.service.SetupTwoFactor(ctx, userID)
+	if err != nil {
+		return twoFactorError(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.CreateTwoFactorSetupResponse(helpers.FromPtr(data)))
+}
+
+// EnableTwoFactor Подтвердить настройку двухфакторной аутентификации кодом из приложения
+func (h *UserHandler) EnableTwoFactor(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.TwoFactorCodeRequest
+	if err = bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	recoveryCodes, err := h.service.EnableTwoFactor(ctx, userID, req.Code)
+	if err != nil {
+		return twoFactorError(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.RecoveryCodesResponse{RecoveryCodes: recoveryCodes})
+}
+
+// DisableTwoFactor Выключить двухфакторную аутентификацию
+func (h *UserHandler) DisableTwoFactor(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.DisableTwoFactorRequest
+	if err = bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	err = h.service.DisableTwoFactor(ctx, userID, req.Password, req.Code)
+	if err != nil {
+		return twoFactorError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"message": "двухфакторная аутентификация выключена"})
+}
+
+// RegenerateRecoveryCodes Получить новые резервные коды взамен прежних
+func (h *UserHandler) RegenerateRecoveryCodes(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.TwoFactorCodeRequest
+	if err = bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	recoveryCodes, err := h.service.RegenerateRecoveryCodes(ctx, userID, req.Code)
+	if err != nil {
+		return twoFactorError(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.RecoveryCodesResponse{RecoveryCodes: recoveryCodes})
+}
+
+// Profile Получить профиль текущего пользователя
 func (h *UserHandler) Profile(c echo.Context) error {
-	return nil
+	ctx := c.Request().Context()
+
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	data, err := h.profileService.Get(ctx, userID)
+	if err != nil {
+		return profileError(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.CreateUserProfileResponse(helpers.FromPtr(data)))
+}
+
+// UpdateProfile Изменить профиль текущего пользователя
+func (h *UserHandler) UpdateProfile(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.UserProfilePutRequest
+
+	if err = bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	err = h.profileService.Update(ctx, userID, model.UserProfile{
+		DisplayName: strings.TrimSpace(req.DisplayName),
+		About:       strings.TrimSpace(req.About),
+		CityID:      req.CityID,
+	})
+	if err != nil {
+		return profileError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"message": "профиль обновлен"})
+}
+
+// UploadAvatar Загрузить аватар текущего пользователя. Файл передается в поле file формы multipart/form-data.
+func (h *UserHandler) UploadAvatar(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, errFileMissing.Error()).SetInternal(err)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, errFileUnreadable.Error()).SetInternal(err)
+	}
+	defer file.Close()
+
+	err = h.profileService.UploadAvatar(ctx, userID, fileHeader.Size, file)
+	if err != nil {
+		return profileError(err)
+	}
+
+	data, err := h.profileService.Get(ctx, userID)
+	if err != nil {
+		return profileError(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.CreateUserProfileResponse(helpers.FromPtr(data)))
 }
 
+// DeleteAvatar Удалить аватар текущего пользователя
+func (h *UserHandler) DeleteAvatar(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	err = h.profileService.DeleteAvatar(ctx, userID)
+	if err != nil {
+		return profileError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"message": "аватар удален"})
+}
+
+// Avatar Отдать аватар пользователя. Имя файла задает формат: avatar.jpg или avatar.webp,
+// параметр v — версию аватара из ссылки в профиле.
+func (h *UserHandler) Avatar(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getPositiveIntParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	variant, format, ok := model.ParseImageVariantFile(c.Param("file"))
+	if !ok || variant != model.ImageVariantAvatar {
+		return echo.NewHTTPError(http.StatusNotFound, usecase.ErrAvatarNotFound.Error())
+	}
+
+	data, version, err := h.profileService.DownloadAvatar(ctx, userID, format)
+	if err != nil {
+		return profileError(err)
+	}
+	defer data.Close()
+
+	// Ссылка с текущей версией аватара не меняет содержимое и кэшируется надолго.
+	// Ссылка без версии или со старой версией отдает текущий аватар, поэтому кэшируется ненадолго.
+	if c.QueryParam("v") == version {
+		c.Response().Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	} else {
+		c.Response().Header().Set("Cache-Control", "public, max-age=300")
+	}
+
+	return c.Stream(http.StatusOK, format.ContentType(), data)
+}
+
+// Delete Запросить удаление аккаунта текущего пользователя. Требуется повторный ввод пароля.
 func (h *UserHandler) Delete(c echo.Context) error {
-	return nil
+	ctx := c.Request().Context()
+
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.DeleteAccountRequest
+
+	if err = bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	deleteAt, err := h.deletionService.RequestDeletion(ctx, userID, req.Password)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidPassword):
+			return echo.NewHTTPError(http.StatusForbidden, err.Error())
+		case errors.Is(err, usecase.ErrAccountDeletionAlreadyRequested):
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError, "невозможно удалить аккаунт").SetInternal(err)
+		}
+	}
+
+	err = h.auth.Logout(ctx, c.Response(), c.Request())
+	if err != nil {
+		log.Error("logout failed", err)
+	}
+
+	return c.JSON(http.StatusAccepted, dto.DeleteAccountResponse{
+		Message:  "аккаунт будет удален по истечении льготного срока, чтобы отменить удаление, войдите на сайт",
+		DeleteAt: deleteAt,
+	})
+}
+
+func twoFactorError(err error) error {
+	switch {
+	case localErrors.IsOneOf(
+		err,
+		usecase.ErrTwoFactorInvalidCode,
+		usecase.ErrInvalidPassword,
+		usecase.ErrTwoFactorRequired,
+		usecase.ErrUserBlocked,
+	):
+		return echo.NewHTTPError(http.StatusForbidden, err.Error())
+	case localErrors.IsOneOf(
+		err,
+		usecase.ErrTwoFactorAlreadyEnabled,
+		usecase.ErrTwoFactorNotEnabled,
+		usecase.ErrTwoFactorSetupNotStarted,
+	):
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	case errors.Is(err, usecase.ErrTwoFactorTooManyAttempts):
+		return echo.NewHTTPError(http.StatusTooManyRequests, err.Error())
+	case errors.Is(err, usecase.ErrUserNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	default:
+		return echo.NewHTTPError(http.StatusInternalServerError, "ошибка двухфакторной аутентификации").SetInternal(err)
+	}
+}
+
+func profileError(err error) error {
+	switch {
+	case localErrors.IsOneOf(err, usecase.ErrUserNotFound, usecase.ErrAvatarNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	case localErrors.IsOneOf(err,
+		usecase.ErrProfileDisplayNameTooLong,
+		usecase.ErrProfileAboutTooLong,
+		usecase.ErrCityNotFound,
+		usecase.ErrImageEmpty,
+	):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	case localErrors.IsOneOf(err, usecase.ErrImageTooLarge, usecase.ErrImageTooManyPixels):
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, err.Error())
+	case errors.Is(err, usecase.ErrImageUnsupportedType):
+		return echo.NewHTTPError(http.StatusUnsupportedMediaType, err.Error())
+	default:
+		return echo.NewHTTPError(http.StatusInternalServerError, "ошибка обработки профиля").SetInternal(err)
+	}
+}
+
+// isPasswordPolicyError Новый пароль не соответствует требованиям
+func isPasswordPolicyError(err error) bool {
+	return localErrors.IsOneOf(err,
+		usecase.ErrPasswordTooShort,
+		usecase.ErrPasswordTooLong,
+		usecase.ErrPasswordTooSimple,
+		usecase.ErrPasswordContainsPersonalData,
+		usecase.ErrPasswordBreached,
+	)
 }
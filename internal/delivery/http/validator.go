@@ -0,0 +1,59 @@
+package http
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var (
+	validCountryID = regexp.MustCompile(`^[a-z]{2,6}$`)
+	validUsername  = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]{2,39}$`)
+)
+
+type CustomValidator struct {
+	validator *validator.Validate
+}
+
+func NewCustomValidator() *CustomValidator {
+	v := validator.New()
+
+	// В ошибках полей используются имена из json, как их видит клиент
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+
+		return name
+	})
+
+	// Ошибки регистрации возможны только при неверном имени правила, то есть при запуске
+	for tag, fn := range map[string]validator.Func{
+		"country_id": validateCountryID,
+		"username":   validateUsername,
+	} {
+		if err := v.RegisterValidation(tag, fn); err != nil {
+			panic(err)
+		}
+	}
+
+	return &CustomValidator{validator: v}
+}
+
+// Validate реализует интерфейс echo.Validator. Ошибки проверки полей обрабатывает NewHTTPErrorHandler.
+func (cv *CustomValidator) Validate(i any) error {
+	return cv.validator.Struct(i)
+}
+
+// validateCountryID id страны: от 2 до 6 строчных латинских букв
+func validateCountryID(fl validator.FieldLevel) bool {
+	return validCountryID.MatchString(fl.Field().String())
+}
+
+// validateUsername Имя пользователя: от 3 до 40 латинских букв, цифр и символов _ . -, начинается с буквы или цифры
+func validateUsername(fl validator.FieldLevel) bool {
+	return validUsername.MatchString(fl.Field().String())
+}
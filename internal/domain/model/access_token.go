@@ -0,0 +1,51 @@
+package model
+
+import (
+	"net/http"
+	"slices"
+	"time"
+)
+
+type AccessTokenScope string
+
+const (
+	// AccessTokenScopeRead Только чтение: запросы GET, HEAD и OPTIONS
+	AccessTokenScopeRead AccessTokenScope = "read"
+
+	// AccessTokenScopeWrite Изменение данных: все остальные запросы
+	AccessTokenScopeWrite AccessTokenScope = "write"
+)
+
+var AccessTokenScopes = []AccessTokenScope{AccessTokenScopeRead, AccessTokenScopeWrite}
+
+func (s AccessTokenScope) IsValid() bool {
+	return slices.Contains(AccessTokenScopes, s)
+}
+
+// AccessToken Персональный токен доступа к API. Сам токен показывается только при создании,
+// хранится его хэш и начало для узнавания в списке.
+type AccessToken struct {
+	ID         int
+	UserID     int
+	Name       string
+	Prefix     string
+	TokenHash  string
+	Scopes     []AccessTokenScope
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+	ExpiresAt  *time.Time
+}
+
+func (t AccessToken) IsExpired(now time.Time) bool {
+	return t.ExpiresAt != nil && !now.Before(*t.ExpiresAt)
+}
+
+// Allows Разрешает ли токен запрос указанным методом
+func (t AccessToken) Allows(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return slices.Contains(t.Scopes, AccessTokenScopeRead) || slices.Contains(t.Scopes, AccessTokenScopeWrite)
+	default:
+		return slices.Contains(t.Scopes, AccessTokenScopeWrite)
+	}
+}
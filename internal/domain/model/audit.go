@@ -0,0 +1,65 @@
+package model
+
+import "time"
+
+type AuditAction string
+
+const (
+	AuditActionLoginSuccess   AuditAction = "login.success"
+	AuditActionLoginFailure   AuditAction = "login.failure"
+	AuditActionPasswordReset  AuditAction = "password.reset"
+	AuditActionPasswordChange AuditAction = "password.change"
+
+	AuditActionUserRoleChange AuditAction = "user.role_change"
+	AuditActionUserBlock      AuditAction = "user.block"
+	AuditActionUserUnblock    AuditAction = "user.unblock"
+	AuditActionUserLogout     AuditAction = "user.logout"
+	AuditActionUserDelete     AuditAction = "user.delete"
+
+	// AuditActionAccountDeletionRequest Пользователь запросил удаление собственного аккаунта
+	AuditActionAccountDeletionRequest AuditAction = "account.deletion_request"
+
+	AuditActionRolePermissionsChange AuditAction = "role.permissions_change"
+
+	AuditActionCatalogCreate  AuditAction = "catalog.create"
+	AuditActionCatalogUpdate  AuditAction = "catalog.update"
+	AuditActionCatalogDelete  AuditAction = "catalog.delete"
+	AuditActionCatalogRestore AuditAction = "catalog.restore"
+
+	AuditActionTranslationSave   AuditAction = "translation.save"
+	AuditActionTranslationDelete AuditAction = "translation.delete"
+
+	// AuditActionEmailResend Администратор вернул неотправленное письмо в очередь
+	AuditActionEmailResend AuditAction = "email.resend"
+)
+
+const (
+	AuditEntityUser  = "user"
+	AuditEntityRole  = "role"
+	AuditEntityEmail = "email"
+)
+
+// AuditEntry Запись журнала аудита. Payload содержит подробности действия в JSON.
+type AuditEntry struct {
+	ID         int
+	Action     AuditAction
+	ActorID    *int
+	IP         string
+	UserAgent  string
+	EntityType string
+	EntityID   string
+	Payload    []byte
+	CreatedAt  time.Time
+}
+
+// AuditFilter Условия поиска в журнале аудита, пустые поля не учитываются
+type AuditFilter struct {
+	Action     AuditAction
+	ActorID    *int
+	EntityType string
+	EntityID   string
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Offset     int
+}
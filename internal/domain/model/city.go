@@ -0,0 +1,11 @@
+package model
+
+type City struct {
+	ID         int
+	CountryID  string
+	RegionID   *int
+	CityTypeID int
+	Name       string
+	Latitude   float64
+	Longitude  float64
+}
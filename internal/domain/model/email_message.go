@@ -0,0 +1,72 @@
+package model
+
+import (
+	"slices"
+	"time"
+)
+
+// EmailKind Вид письма, по нему выбирается шаблон
+type EmailKind string
+
+const (
+	EmailKindVerification    EmailKind = "verification"
+	EmailKindPasswordReset   EmailKind = "password_reset"
+	EmailKindEmailChange     EmailKind = "email_change"
+	EmailKindSubmissionState EmailKind = "submission_state"
+	EmailKindAccountDeletion EmailKind = "account_deletion"
+	EmailKindAccountLocked   EmailKind = "account_locked"
+)
+
+// EmailStatus Состояние письма в очереди на отправку
+type EmailStatus string
+
+const (
+	// EmailStatusPending Письмо ждет отправки, в том числе повторной после ошибки
+	EmailStatusPending EmailStatus = "pending"
+
+	// EmailStatusSent Письмо отправлено
+	EmailStatusSent EmailStatus = "sent"
+
+	// EmailStatusDead Все попытки отправки исчерпаны, письмо можно отправить заново вручную
+	EmailStatusDead EmailStatus = "dead"
+)
+
+var EmailStatuses = []EmailStatus{EmailStatusPending, EmailStatusSent, EmailStatusDead}
+
+func (s EmailStatus) IsValid() bool {
+	return slices.Contains(EmailStatuses, s)
+}
+
+// EmailPayload Данные для шаблона письма. Заполняются только поля, которые нужны письму этого вида.
+type EmailPayload struct {
+	Token     string
+	PlaceName string
+	State     SubmissionState
+	Reason    string
+
+	// Time Дата удаления аккаунта или окончания блокировки входа
+	Time *time.Time
+}
+
+// EmailMessage Письмо в очереди на отправку
+type EmailMessage struct {
+	ID            int
+	Kind          EmailKind
+	ToEmail       string
+	Payload       EmailPayload
+	Status        EmailStatus
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+	SentAt        *time.Time
+}
+
+// EmailFilter Условия поиска писем в очереди, пустые поля не учитываются
+type EmailFilter struct {
+	Status  EmailStatus
+	Kind    EmailKind
+	ToEmail string
+	Limit   int
+	Offset  int
+}
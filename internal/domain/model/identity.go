@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// Identity Учетная запись внешнего провайдера, привязанная к пользователю
+type Identity struct {
+	ID        int
+	UserID    int
+	Provider  string
+	Subject   string
+	Email     string
+	CreatedAt time.Time
+}
+
+// ExternalIdentity Данные пользователя, подтвержденные внешним провайдером при входе
+type ExternalIdentity struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
@@ -0,0 +1,66 @@
+package model
+
+import "strings"
+
+type ImageFormat string
+
+const (
+	ImageFormatJPEG ImageFormat = "jpeg"
+	ImageFormatWebP ImageFormat = "webp"
+)
+
+// ImageFormats Форматы, в которых сохраняются варианты изображений
+var ImageFormats = []ImageFormat{ImageFormatJPEG, ImageFormatWebP}
+
+func (f ImageFormat) ContentType() string {
+	return "image/" + string(f)
+}
+
+func (f ImageFormat) Extension() string {
+	switch f {
+	case ImageFormatJPEG:
+		return ".jpg"
+	default:
+		return "." + string(f)
+	}
+}
+
+type ImageVariantName string
+
+const (
+	ImageVariantThumbnail ImageVariantName = "thumbnail"
+	ImageVariantCard      ImageVariantName = "card"
+	ImageVariantFull      ImageVariantName = "full"
+	ImageVariantAvatar    ImageVariantName = "avatar"
+)
+
+// ImageVariantSpec Параметры варианта изображения: наибольшая сторона в пикселях и необходимость обрезки до квадрата.
+// Изображения меньше MaxSide не увеличиваются.
+type ImageVariantSpec struct {
+	Name    ImageVariantName
+	MaxSide int
+	Square  bool
+}
+
+// ImageVariant Готовый вариант изображения в одном из форматов. Метаданные исходного файла (EXIF) не сохраняются.
+type ImageVariant struct {
+	Name   ImageVariantName
+	Format ImageFormat
+	Data   []byte
+}
+
+// ImageVariantFile Имя файла варианта изображения, например thumbnail.webp
+func ImageVariantFile(name ImageVariantName, format ImageFormat) string {
+	return string(name) + format.Extension()
+}
+
+// ParseImageVariantFile Разобрать имя файла варианта изображения
+func ParseImageVariantFile(file string) (ImageVariantName, ImageFormat, bool) {
+	for _, format := range ImageFormats {
+		if name, ok := strings.CutSuffix(file, format.Extension()); ok && name != "" {
+			return ImageVariantName(name), format, true
+		}
+	}
+
+	return "", "", false
+}
@@ -0,0 +1,54 @@
+package model
+
+import "palback/internal/pkg/geo"
+
+type PlaceStatus string
+
+const (
+	PlaceStatusActive    PlaceStatus = "active"
+	PlaceStatusDestroyed PlaceStatus = "destroyed"
+	PlaceStatusRestored  PlaceStatus = "restored"
+)
+
+func (s PlaceStatus) IsValid() bool {
+	switch s {
+	case PlaceStatusActive, PlaceStatusDestroyed, PlaceStatusRestored:
+		return true
+	default:
+		return false
+	}
+}
+
+type Place struct {
+	ID           int
+	PlaceTypeID  int
+	CityID       int
+	Name         string
+	Address      string
+	Latitude     float64
+	Longitude    float64
+	Description  string
+	FoundingYear *int
+	Status       PlaceStatus
+}
+
+type PlaceFilter struct {
+	CityID      *int
+	PlaceTypeID *int
+	BoundingBox *geo.BoundingBox
+	Limit       int
+	Offset      int
+}
+
+type PlaceNearbyFilter struct {
+	Latitude    float64
+	Longitude   float64
+	RadiusKm    float64
+	PlaceTypeID *int
+	Limit       int
+}
+
+type PlaceWithDistance struct {
+	Place
+	DistanceKm float64
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"path"
+	"strings"
+	"time"
+)
+
+// PlacePhoto Фотография святого места. Сам файл хранится в файловом хранилище по ключу ObjectKey.
+type PlacePhoto struct {
+	ID          int
+	PlaceID     int
+	AuthorID    *int
+	ObjectKey   string
+	ContentType string
+	Size        int64
+	Caption     string
+	License     string
+	Position    int
+	IsCover     bool
+	CreatedAt   time.Time
+}
+
+// PhotoVariantSpecs Варианты, в которых показываются фотографии святых мест
+var PhotoVariantSpecs = []ImageVariantSpec{
+	{Name: ImageVariantThumbnail, MaxSide: 320},
+	{Name: ImageVariantCard, MaxSide: 800},
+	{Name: ImageVariantFull, MaxSide: 1920},
+}
+
+// VariantKey Ключ варианта фотографии в хранилище. Варианты лежат рядом с исходным файлом в каталоге с его именем.
+func (p PlacePhoto) VariantKey(name ImageVariantName, format ImageFormat) string {
+	base := strings.TrimSuffix(p.ObjectKey, path.Ext(p.ObjectKey))
+
+	return base + "/" + ImageVariantFile(name, format)
+}
+
+// IsPhotoVariant Проверить, что фотографии показываются в указанном варианте
+func IsPhotoVariant(name ImageVariantName) bool {
+	for _, spec := range PhotoVariantSpecs {
+		if spec.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"slices"
+	"time"
+)
+
+type SubmissionState string
+
+const (
+	SubmissionStateDraft     SubmissionState = "draft"
+	SubmissionStatePending   SubmissionState = "pending"
+	SubmissionStatePublished SubmissionState = "published"
+	SubmissionStateRejected  SubmissionState = "rejected"
+)
+
+// submissionTransitions Допустимые переходы между состояниями предложения
+var submissionTransitions = map[SubmissionState][]SubmissionState{
+	SubmissionStateDraft:    {SubmissionStatePending},
+	SubmissionStatePending:  {SubmissionStatePublished, SubmissionStateRejected},
+	SubmissionStateRejected: {SubmissionStateDraft},
+}
+
+func (s SubmissionState) CanTransitionTo(next SubmissionState) bool {
+	return slices.Contains(submissionTransitions[s], next)
+}
+
+// IsEditable Предложение может редактировать автор
+func (s SubmissionState) IsEditable() bool {
+	return s == SubmissionStateDraft || s == SubmissionStateRejected
+}
+
+// PlaceSubmission Предложенное пользователем новое святое место или правка существующего.
+// Если PlaceID не задан, после одобрения будет создано новое место.
+// AuthorID не задан, если автор удалил свою учетную запись.
+type PlaceSubmission struct {
+	ID           int
+	PlaceID      *int
+	AuthorID     *int
+	ModeratorID  *int
+	Place        Place
+	State        SubmissionState
+	RejectReason string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// RateLimitResult Результат проверки ограничения частоты запросов
+type RateLimitResult struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+
+	// ResetAfter Через сколько квота восстановится полностью
+	ResetAfter time.Duration
+
+	// RetryAfter Через сколько можно повторить отклоненный запрос, 0 если запрос разрешен
+	RetryAfter time.Duration
+}
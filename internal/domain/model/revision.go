@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+type RevisionEntity string
+
+const (
+	RevisionEntityCountry RevisionEntity = "country"
+	RevisionEntityRegion  RevisionEntity = "region"
+	RevisionEntityCity    RevisionEntity = "city"
+	RevisionEntityPlace   RevisionEntity = "place"
+)
+
+type RevisionAction string
+
+const (
+	RevisionActionCreate  RevisionAction = "create"
+	RevisionActionUpdate  RevisionAction = "update"
+	RevisionActionDelete  RevisionAction = "delete"
+	RevisionActionRestore RevisionAction = "restore"
+)
+
+// Revision Изменение записи справочника. Before и After содержат полное состояние записи в JSON
+// до и после изменения; при добавлении пуст Before, при удалении — After. Хранятся именно снимки,
+// а не разница, чтобы к любой ревизии можно было вернуться; список измененных полей вычисляется
+// из снимков при чтении.
+type Revision struct {
+	ID        int
+	Entity    RevisionEntity
+	EntityID  string
+	AuthorID  *int
+	Action    RevisionAction
+	Before    []byte
+	After     []byte
+	CreatedAt time.Time
+}
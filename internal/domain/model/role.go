@@ -1,17 +1,45 @@
 package model
 
+import "slices"
+
 type RoleID string
 
 const (
-	RoleAdmin RoleID = "admin"
-	RoleUser  RoleID = "user"
+	RoleAdmin     RoleID = "admin"
+	RoleModerator RoleID = "moderator"
+	RoleUser      RoleID = "user"
+)
+
+// Permission Право на группу операций, которое выдается ролям
+type Permission string
+
+const (
+	// PermissionCatalogEdit Изменение справочников: страны, регионы, населенные пункты и святые места
+	PermissionCatalogEdit Permission = "catalog.edit"
+
+	// PermissionPlacesModerate Модерация предложений святых мест и фотографий пользователей
+	PermissionPlacesModerate Permission = "places.moderate"
+
+	// PermissionUsersManage Управление пользователями и их блокировками
+	PermissionUsersManage Permission = "users.manage"
 )
 
+var Permissions = []Permission{PermissionCatalogEdit, PermissionPlacesModerate, PermissionUsersManage}
+
+func (p Permission) IsValid() bool {
+	return slices.Contains(Permissions, p)
+}
+
 type Role struct {
-	ID   RoleID
-	Name string
+	ID          RoleID
+	Name        string
+	Permissions []Permission
 }
 
 func (r *Role) IsAdmin() bool {
 	return r.ID == RoleAdmin
 }
+
+func (r *Role) HasPermission(permission Permission) bool {
+	return slices.Contains(r.Permissions, permission)
+}
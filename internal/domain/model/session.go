@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// Session Активная сессия пользователя на одном из устройств
+type Session struct {
+	ID         string
+	UserAgent  string
+	IP         string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	Current    bool
+}
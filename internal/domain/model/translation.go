@@ -0,0 +1,43 @@
+package model
+
+import "slices"
+
+type TranslationEntity string
+
+const (
+	TranslationEntityCountry   TranslationEntity = "country"
+	TranslationEntityRegion    TranslationEntity = "region"
+	TranslationEntityCityType  TranslationEntity = "city_type"
+	TranslationEntityCity      TranslationEntity = "city"
+	TranslationEntityPlaceType TranslationEntity = "place_type"
+	TranslationEntityPlace     TranslationEntity = "place"
+)
+
+// TranslationEntities Записи справочников, названия которых можно перевести
+var TranslationEntities = []TranslationEntity{
+	TranslationEntityCountry,
+	TranslationEntityRegion,
+	TranslationEntityCityType,
+	TranslationEntityCity,
+	TranslationEntityPlaceType,
+	TranslationEntityPlace,
+}
+
+func (e TranslationEntity) IsValid() bool {
+	return slices.Contains(TranslationEntities, e)
+}
+
+// HasShortName Есть ли у записи справочника сокращенное название
+func (e TranslationEntity) HasShortName() bool {
+	return e == TranslationEntityCityType
+}
+
+// Translation Название записи справочника на языке, отличном от языка по умолчанию.
+// ShortName заполняется только для типов населенных пунктов.
+type Translation struct {
+	Entity    TranslationEntity
+	EntityID  string
+	Lang      string
+	Name      string
+	ShortName string
+}
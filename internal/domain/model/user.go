@@ -1,6 +1,9 @@
 package model
 
-import "time"
+import (
+	"path"
+	"time"
+)
 
 type User struct {
 	ID             int
@@ -11,4 +14,68 @@ type User struct {
 	EmailVerified  bool
 	SessionVersion int64
 	CreatedAt      time.Time
+	Profile        UserProfile
+	AvatarKey      string
+
+	// DeletionRequestedAt Время запроса на удаление аккаунта, nil если удаление не запрошено
+	DeletionRequestedAt *time.Time
+
+	// TOTPSecret Секрет для одноразовых кодов, пустая строка если двухфакторная аутентификация не включена
+	TOTPSecret string
+
+	LoginFailures LoginFailures
+
+	// BlockedAt Время блокировки учетной записи администратором, nil если пользователь не заблокирован
+	BlockedAt *time.Time
+}
+
+// UserFilter Условия поиска пользователей в административном списке
+type UserFilter struct {
+	// Search Часть имени пользователя или e-mail, без учета регистра
+	Search string
+	Limit  int
+	Offset int
+}
+
+// LoginFailures Неудачные попытки входа подряд с момента последнего успешного входа
+type LoginFailures struct {
+	Count        int
+	LastFailedAt *time.Time
+
+	// LockedUntil До какого времени вход заблокирован, nil если блокировки не было
+	LockedUntil *time.Time
+}
+
+func (f LoginFailures) IsLocked(now time.Time) bool {
+	return f.LockedUntil != nil && now.Before(*f.LockedUntil)
+}
+
+func (u User) IsBlocked() bool {
+	return u.BlockedAt != nil
+}
+
+func (u User) TwoFactorEnabled() bool {
+	return u.TOTPSecret != ""
+}
+
+// UserProfile Публичные данные профиля, которые пользователь заполняет сам
+type UserProfile struct {
+	DisplayName string
+	About       string
+	CityID      *int
+}
+
+// AvatarVariantSpecs Аватар хранится квадратным в одном размере
+var AvatarVariantSpecs = []ImageVariantSpec{
+	{Name: ImageVariantAvatar, MaxSide: 256, Square: true},
+}
+
+// AvatarFileKey Ключ файла аватара в хранилище
+func (u User) AvatarFileKey(format ImageFormat) string {
+	return u.AvatarKey + "/" + ImageVariantFile(ImageVariantAvatar, format)
+}
+
+// AvatarVersion Версия аватара — последняя часть его ключа, которая меняется при каждой загрузке
+func (u User) AvatarVersion() string {
+	return path.Base(u.AvatarKey)
 }
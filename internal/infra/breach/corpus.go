@@ -0,0 +1,90 @@
+package breach
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	_ "embed"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+// prefixLength Длина префикса SHA-1, по которому хэши делятся на диапазоны, как в Pwned Passwords
+const prefixLength = 5
+
+//go:embed breached_passwords.txt
+var defaultCorpus []byte
+
+// Corpus Список SHA-1 хэшей паролей из утечек данных. Хэши хранятся по диапазонам префиксов:
+// по префиксу выбирается диапазон, а в нем ищется окончание хэша, так же как при запросе к Pwned Passwords.
+type Corpus struct {
+	ranges map[string][]string
+}
+
+// Load Загрузить список из файла. Если путь не указан, используется встроенный список распространенных паролей.
+func Load(path string) (*Corpus, error) {
+	if path == "" {
+		return parse(bytes.NewReader(defaultCorpus))
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка открытия списка утекших паролей: %w", err)
+	}
+	defer file.Close()
+
+	return parse(file)
+}
+
+// Contains Проверить, есть ли пароль в списке
+func (c *Corpus) Contains(password string) bool {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	_, found := slices.BinarySearch(c.ranges[hash[:prefixLength]], hash[prefixLength:])
+
+	return found
+}
+
+// parse Прочитать список: по одному хэшу в строке, после хэша через двоеточие может быть указано,
+// сколько раз пароль встречался в утечках. Пустые строки и строки с # пропускаются.
+func parse(r io.Reader) (*Corpus, error) {
+	corpus := &Corpus{ranges: make(map[string][]string)}
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		hash, _, _ := strings.Cut(text, ":")
+		hash = strings.ToUpper(hash)
+
+		if len(hash) != sha1.Size*2 {
+			return nil, fmt.Errorf("неверный хэш в строке %d списка утекших паролей", line)
+		}
+
+		if _, err := hex.DecodeString(hash); err != nil {
+			return nil, fmt.Errorf("неверный хэш в строке %d списка утекших паролей", line)
+		}
+
+		prefix := hash[:prefixLength]
+		corpus.ranges[prefix] = append(corpus.ranges[prefix], hash[prefixLength:])
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка чтения списка утекших паролей: %w", err)
+	}
+
+	for prefix := range corpus.ranges {
+		slices.Sort(corpus.ranges[prefix])
+		corpus.ranges[prefix] = slices.Compact(corpus.ranges[prefix])
+	}
+
+	return corpus, nil
+}
@@ -5,8 +5,10 @@ import (
 	"html/template"
 	"net/smtp"
 	"strings"
+	"time"
 
 	"palback/internal/config"
+	"palback/internal/domain/model"
 )
 
 type SMTPSender struct {
@@ -38,7 +40,7 @@ func (s *SMTPSender) SendVerificationEmail(toEmail, token string) error {
 	</html>
     `
 
-	return s.sendMail(verifyLink, tmpl, "Подтверждение регистрации", toEmail)
+	return s.sendMail(struct{ Link string }{Link: verifyLink}, tmpl, "Подтверждение регистрации", toEmail)
 }
 
 func (s *SMTPSender) SendPasswordResetEmail(toEmail, token string) error {
@@ -61,13 +63,151 @@ func (s *SMTPSender) SendPasswordResetEmail(toEmail, token string) error {
 	</html>
     `
 
-	return s.sendMail(resetLink, tmpl, "Сброс пароля", toEmail)
+	return s.sendMail(struct{ Link string }{Link: resetLink}, tmpl, "Сброс пароля", toEmail)
 }
 
-func (s *SMTPSender) sendMail(link, tmpl, subject, toEmail string) error {
+func (s *SMTPSender) SendEmailChangeEmail(toEmail, token string) error {
+	confirmLink := fmt.Sprintf("%s/user/confirm-email?token=%s", s.config.FrontendOrigin, token)
+
+	// HTML-шаблон
+	tmpl := `
+	<!DOCTYPE html>
+	<html>
+	<head><meta charset="utf-8"></head>
+	<body>
+		<p>Здравствуйте!</p>
+		<p>Вы указали этот адрес как новый e-mail своего аккаунта. Если это были не вы — просто проигнорируйте это письмо.</p>
+		<p>Чтобы подтвердить смену e-mail, перейдите по ссылке:</p>
+		<p><a href="{{.Link}}" style="display:inline-block;padding:10px 20px;background:#007bff;color:#fff;text-decoration:none;border-radius:4px;">Подтвердить e-mail</a></p>
+		<p>Ссылка действительна в течение 1 часа.</p>
+		<hr>
+		<p>С уважением,<br>Администрация проекта palomniki.su</p>
+	</body>
+	</html>
+    `
+
+	return s.sendMail(struct{ Link string }{Link: confirmLink}, tmpl, "Смена e-mail", toEmail)
+}
+
+func (s *SMTPSender) SendSubmissionStateEmail(
+	toEmail, placeName string,
+	state model.SubmissionState,
+	reason string,
+) error {
+	var (
+		subject string
+		message string
+	)
+
+	switch state {
+	case model.SubmissionStatePending:
+		subject = "Предложение отправлено на модерацию"
+		message = "ваше предложение отправлено на модерацию. Мы сообщим вам о результатах проверки."
+	case model.SubmissionStatePublished:
+		subject = "Предложение опубликовано"
+		message = "ваше предложение одобрено модератором и опубликовано на сайте. Спасибо за помощь проекту!"
+	case model.SubmissionStateRejected:
+		subject = "Предложение отклонено"
+		message = "ваше предложение отклонено модератором."
+	default:
+		return nil
+	}
+
+	// HTML-шаблон
+	tmpl := `
+	<!DOCTYPE html>
+	<html>
+	<head><meta charset="utf-8"></head>
+	<body>
+		<p>Здравствуйте!</p>
+		<p>Святое место «{{.PlaceName}}»: {{.Message}}</p>
+		{{if .Reason}}<p>Причина: {{.Reason}}</p>{{end}}
+		<p><a href="{{.Link}}" style="display:inline-block;padding:10px 20px;background:#007bff;color:#fff;text-decoration:none;border-radius:4px;">Мои предложения</a></p>
+		<hr>
+		<p>С уважением,<br>Администрация проекта palomniki.su</p>
+	</body>
+	</html>
+    `
+
+	data := struct {
+		Link      string
+		PlaceName string
+		Message   string
+		Reason    string
+	}{
+		Link:      fmt.Sprintf("%s/user/submissions", s.config.FrontendOrigin),
+		PlaceName: placeName,
+		Message:   message,
+		Reason:    reason,
+	}
+
+	return s.sendMail(data, tmpl, subject, toEmail)
+}
+
+func (s *SMTPSender) SendAccountDeletionEmail(toEmail string, deleteAt time.Time) error {
+	// HTML-шаблон
+	tmpl := `
+	<!DOCTYPE html>
+	<html>
+	<head><meta charset="utf-8"></head>
+	<body>
+		<p>Здравствуйте!</p>
+		<p>Вы запросили удаление своего аккаунта. Аккаунт и аватар будут удалены {{.DeleteAt}},
+		а добавленные вами фотографии останутся на сайте без указания автора.</p>
+		<p>Если вы передумали или это были не вы, просто войдите на сайт до этой даты — удаление будет отменено.</p>
+		<p><a href="{{.Link}}" style="display:inline-block;padding:10px 20px;background:#007bff;color:#fff;text-decoration:none;border-radius:4px;">Войти на сайт</a></p>
+		<hr>
+		<p>С уважением,<br>Администрация проекта palomniki.su</p>
+	</body>
+	</html>
+    `
+
+	data := struct {
+		Link     string
+		DeleteAt string
+	}{
+		Link:     fmt.Sprintf("%s/user/login", s.config.FrontendOrigin),
+		DeleteAt: deleteAt.Format("02.01.2006"),
+	}
+
+	return s.sendMail(data, tmpl, "Удаление аккаунта", toEmail)
+}
+
+func (s *SMTPSender) SendAccountLockedEmail(toEmail, token string, lockedUntil time.Time) error {
+	unlockLink := fmt.Sprintf("%s/user/unlock?token=%s", s.config.FrontendOrigin, token)
+
+	// HTML-шаблон
+	tmpl := `
+	<!DOCTYPE html>
+	<html>
+	<head><meta charset="utf-8"></head>
+	<body>
+		<p>Здравствуйте!</p>
+		<p>Из-за множества неудачных попыток входа вход в ваш аккаунт заблокирован до {{.LockedUntil}}.</p>
+		<p>Если это были вы, снимите блокировку по ссылке ниже. Если нет — рекомендуем сменить пароль.</p>
+		<p><a href="{{.Link}}" style="display:inline-block;padding:10px 20px;background:#007bff;color:#fff;text-decoration:none;border-radius:4px;">Снять блокировку</a></p>
+		<p>Ссылка действительна 1 час.</p>
+		<hr>
+		<p>С уважением,<br>Администрация проекта palomniki.su</p>
+	</body>
+	</html>
+    `
+
+	data := struct {
+		Link        string
+		LockedUntil string
+	}{
+		Link:        unlockLink,
+		LockedUntil: lockedUntil.Format("02.01.2006 15:04"),
+	}
+
+	return s.sendMail(data, tmpl, "Вход в аккаунт заблокирован", toEmail)
+}
+
+func (s *SMTPSender) sendMail(data any, tmpl, subject, toEmail string) error {
 	t := template.Must(template.New("email").Parse(tmpl))
 	var body strings.Builder
-	if err := t.Execute(&body, struct{ Link string }{Link: link}); err != nil {
+	if err := t.Execute(&body, data); err != nil {
 		return err
 	}
 
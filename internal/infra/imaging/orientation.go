@@ -0,0 +1,139 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	stdDraw "image/draw"
+)
+
+const (
+	exifOrientationTag = 0x0112
+	jpegMarkerSOS      = 0xDA
+	jpegMarkerAPP1     = 0xE1
+)
+
+// jpegOrientation Прочитать ориентацию снимка из EXIF. Для прочих форматов и при ошибках возвращается 1 (без поворота).
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return 1
+		}
+
+		marker := data[pos+1]
+		length := int(binary.BigEndian.Uint16(data[pos+2:]))
+
+		if marker == jpegMarkerSOS || length < 2 || pos+2+length > len(data) {
+			return 1
+		}
+
+		segment := data[pos+4 : pos+2+length]
+		if marker == jpegMarkerAPP1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return tiffOrientation(segment[6:])
+		}
+
+		pos += 2 + length
+	}
+
+	return 1
+}
+
+// tiffOrientation Найти тег ориентации в первом каталоге TIFF-заголовка EXIF
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	offset := int(order.Uint32(tiff[4:]))
+	if offset+2 > len(tiff) {
+		return 1
+	}
+
+	count := int(order.Uint16(tiff[offset:]))
+	for i := range count {
+		entry := offset + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+
+		if order.Uint16(tiff[entry:]) == exifOrientationTag {
+			value := int(order.Uint16(tiff[entry+8:]))
+			if value >= 1 && value <= 8 {
+				return value
+			}
+
+			return 1
+		}
+	}
+
+	return 1
+}
+
+// applyOrientation Повернуть и отразить изображение согласно EXIF, так как после удаления метаданных ориентация теряется
+func applyOrientation(src image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return src
+	}
+
+	// Пиксели переставляются в буфере RGBA: для него у draw.Draw есть быстрые преобразования
+	// из форматов, которые дают декодеры JPEG, PNG и WebP
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	rgba := image.NewRGBA(image.Rect(0, 0, width, height))
+	stdDraw.Draw(rgba, rgba.Bounds(), src, bounds.Min, stdDraw.Src)
+
+	// Ориентации 5-8 меняют ширину и высоту местами
+	dstWidth, dstHeight := width, height
+	if orientation >= 5 {
+		dstWidth, dstHeight = height, width
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+
+	for y := range height {
+		row := rgba.Pix[y*rgba.Stride : y*rgba.Stride+width*4]
+
+		for x := range width {
+			var dx, dy int
+
+			switch orientation {
+			case 2:
+				dx, dy = width-1-x, y
+			case 3:
+				dx, dy = width-1-x, height-1-y
+			case 4:
+				dx, dy = x, height-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = height-1-y, x
+			case 7:
+				dx, dy = height-1-y, width-1-x
+			case 8:
+				dx, dy = y, width-1-x
+			}
+
+			offset := dy*dst.Stride + dx*4
+			copy(dst.Pix[offset:offset+4], row[x*4:x*4+4])
+		}
+	}
+
+	return dst
+}
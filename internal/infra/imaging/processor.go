@@ -0,0 +1,132 @@
+package imaging
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	stdDraw "image/draw"
+	"image/jpeg"
+	_ "image/png"
+
+	"github.com/HugoSmits86/nativewebp"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+
+	"palback/internal/domain/model"
+	localErrors "palback/internal/pkg/errors"
+)
+
+const jpegQuality = 85
+
+// Processor Построение вариантов изображений на чистом Go.
+// Изображение декодируется и кодируется заново, поэтому метаданные исходного файла (EXIF, GPS) отбрасываются.
+type Processor struct {
+	// maxPixels Наибольшее число пикселей в изображении. Небольшой файл может описывать огромное
+	// изображение, поэтому размеры проверяются по заголовку до декодирования.
+	maxPixels int64
+}
+
+func NewProcessor(maxPixels int64) *Processor {
+	return &Processor{
+		maxPixels: maxPixels,
+	}
+}
+
+// Variants Построить варианты изображения по описаниям. Каждый вариант сохраняется в JPEG и WebP.
+func (p *Processor) Variants(data []byte, specs []model.ImageVariantSpec) ([]model.ImageVariant, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", localErrors.ErrInvalidImage, err)
+	}
+
+	if config.Width <= 0 || config.Height <= 0 {
+		return nil, localErrors.ErrInvalidImage
+	}
+
+	if pixels := int64(config.Width) * int64(config.Height); pixels > p.maxPixels {
+		return nil, fmt.Errorf("%w: %dx%d", localErrors.ErrImageTooManyPixels, config.Width, config.Height)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", localErrors.ErrInvalidImage, err)
+	}
+
+	src = applyOrientation(src, jpegOrientation(data))
+
+	result := make([]model.ImageVariant, 0, len(specs)*len(model.ImageFormats))
+
+	for _, spec := range specs {
+		img := resize(src, spec)
+
+		for _, format := range model.ImageFormats {
+			encoded, err := encode(img, format)
+			if err != nil {
+				return nil, fmt.Errorf("ошибка кодирования варианта %s в %s: %w", spec.Name, format, err)
+			}
+
+			result = append(result, model.ImageVariant{
+				Name:   spec.Name,
+				Format: format,
+				Data:   encoded,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// resize Уменьшить изображение так, чтобы большая сторона не превышала spec.MaxSide.
+// Для квадратного варианта сначала вырезается центральный квадрат.
+func resize(src image.Image, spec model.ImageVariantSpec) *image.NRGBA {
+	bounds := src.Bounds()
+
+	if spec.Square {
+		side := min(bounds.Dx(), bounds.Dy())
+		x0 := bounds.Min.X + (bounds.Dx()-side)/2
+		y0 := bounds.Min.Y + (bounds.Dy()-side)/2
+		bounds = image.Rect(x0, y0, x0+side, y0+side)
+	}
+
+	width, height := bounds.Dx(), bounds.Dy()
+
+	if longest := max(width, height); spec.MaxSide > 0 && longest > spec.MaxSide {
+		width = max(1, width*spec.MaxSide/longest)
+		height = max(1, height*spec.MaxSide/longest)
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Src, nil)
+
+	return dst
+}
+
+func encode(img *image.NRGBA, format model.ImageFormat) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+
+	switch format {
+	case model.ImageFormatJPEG:
+		err = jpeg.Encode(&buf, flatten(img), &jpeg.Options{Quality: jpegQuality})
+	case model.ImageFormatWebP:
+		err = nativewebp.Encode(&buf, img, nil)
+	default:
+		err = fmt.Errorf("неизвестный формат %q", format)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// flatten Наложить изображение на белый фон, так как JPEG не поддерживает прозрачность
+func flatten(img *image.NRGBA) *image.RGBA {
+	dst := image.NewRGBA(img.Bounds())
+	stdDraw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, stdDraw.Src)
+	stdDraw.Draw(dst, dst.Bounds(), img, img.Bounds().Min, stdDraw.Over)
+
+	return dst
+}
@@ -0,0 +1,94 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"palback/internal/domain/model"
+	localErrors "palback/internal/pkg/errors"
+)
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("ошибка кодирования PNG: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestProcessor_VariantsPixelLimit(t *testing.T) {
+	data := encodePNG(t, image.NewGray(image.Rect(0, 0, 100, 50)))
+	specs := []model.ImageVariantSpec{{Name: "thumb", MaxSide: 20}}
+
+	_, err := NewProcessor(100*50-1).Variants(data, specs)
+	if !errors.Is(err, localErrors.ErrImageTooManyPixels) {
+		t.Fatalf("ожидалась ErrImageTooManyPixels, получено %v", err)
+	}
+
+	variants, err := NewProcessor(100*50).Variants(data, specs)
+	if err != nil {
+		t.Fatalf("Variants: %v", err)
+	}
+
+	if len(variants) != len(model.ImageFormats) {
+		t.Fatalf("получено %d вариантов, ожидалось %d", len(variants), len(model.ImageFormats))
+	}
+}
+
+func TestProcessor_VariantsRejectsLargeHeaderBeforeDecoding(t *testing.T) {
+	// Заголовок PNG объявляет изображение 100000x100000, данных пикселей в файле нет
+	data := encodePNG(t, image.NewGray(image.Rect(0, 0, 1, 1)))
+	copy(data[16:24], []byte{0, 1, 0x86, 0xA0, 0, 1, 0x86, 0xA0})
+	binary.BigEndian.PutUint32(data[29:33], crc32.ChecksumIEEE(data[12:29]))
+
+	_, err := NewProcessor(50_000_000).Variants(data, nil)
+	if !errors.Is(err, localErrors.ErrImageTooManyPixels) {
+		t.Fatalf("ожидалась ErrImageTooManyPixels, получено %v", err)
+	}
+}
+
+func TestApplyOrientation(t *testing.T) {
+	// Изображение 2x1: красный пиксель слева, синий справа
+	red := color.RGBA{R: 255, A: 255}
+	blue := color.RGBA{B: 255, A: 255}
+
+	src := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	src.Set(0, 0, red)
+	src.Set(1, 0, blue)
+
+	tests := []struct {
+		orientation int
+		want        [][]color.RGBA
+	}{
+		{orientation: 1, want: [][]color.RGBA{{red, blue}}},
+		{orientation: 2, want: [][]color.RGBA{{blue, red}}},
+		{orientation: 3, want: [][]color.RGBA{{blue, red}}},
+		{orientation: 6, want: [][]color.RGBA{{red}, {blue}}},
+		{orientation: 8, want: [][]color.RGBA{{blue}, {red}}},
+	}
+
+	for _, tt := range tests {
+		got := applyOrientation(src, tt.orientation)
+
+		if got.Bounds().Dx() != len(tt.want[0]) || got.Bounds().Dy() != len(tt.want) {
+			t.Fatalf("ориентация %d: размер %v", tt.orientation, got.Bounds())
+		}
+
+		for y, row := range tt.want {
+			for x, want := range row {
+				if c := color.RGBAModel.Convert(got.At(x, y)); c != want {
+					t.Errorf("ориентация %d: пиксель (%d,%d) = %v, ожидался %v", tt.orientation, x, y, c, want)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+
+	"palback/internal/domain/model"
+)
+
+// Config Настройки провайдера OpenID Connect
+type Config struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+// Provider Провайдер OpenID Connect. Адреса провайдера берутся из документа
+// /.well-known/openid-configuration издателя, поэтому для проверки достаточно локального mock-провайдера.
+type Provider struct {
+	name     string
+	oauth    oauth2.Config
+	provider *gooidc.Provider
+	verifier *gooidc.IDTokenVerifier
+}
+
+func NewProvider(ctx context.Context, cfg Config) (*Provider, error) {
+	provider, err := gooidc.NewProvider(ctx, cfg.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения настроек провайдера %s: %w", cfg.Name, err)
+	}
+
+	scopes := cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"email", "profile"}
+	}
+
+	return &Provider{
+		name: cfg.Name,
+		oauth: oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Endpoint:     provider.Endpoint(),
+			Scopes:       append([]string{gooidc.ScopeOpenID}, scopes...),
+		},
+		provider: provider,
+		verifier: provider.Verifier(&gooidc.Config{ClientID: cfg.ClientID}),
+	}, nil
+}
+
+func (p *Provider) Name() string {
+	return p.name
+}
+
+func (p *Provider) AuthCodeURL(state, nonce, codeVerifier string) string {
+	return p.oauth.AuthCodeURL(state, gooidc.Nonce(nonce), oauth2.S256ChallengeOption(codeVerifier))
+}
+
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*model.ExternalIdentity, error) {
+	token, err := p.oauth.Exchange(ctx, code, oauth2.VerifierOption(codeVerifier))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка обмена кода авторизации: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, errors.New("провайдер не вернул id_token")
+	}
+
+	idToken, err := p.verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка проверки id_token: %w", err)
+	}
+
+	if idToken.Nonce != nonce {
+		return nil, errors.New("nonce в id_token не совпадает с отправленным")
+	}
+
+	var claims identityClaims
+	if err = idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("ошибка чтения id_token: %w", err)
+	}
+
+	// Часть провайдеров не кладет e-mail в id_token, тогда он запрашивается отдельно
+	if claims.Email == "" {
+		userInfo, err := p.provider.UserInfo(ctx, oauth2.StaticTokenSource(token))
+		if err != nil {
+			return nil, fmt.Errorf("ошибка получения данных пользователя: %w", err)
+		}
+
+		if err = userInfo.Claims(&claims); err != nil {
+			return nil, fmt.Errorf("ошибка чтения данных пользователя: %w", err)
+		}
+	}
+
+	return &model.ExternalIdentity{
+		Provider:      p.name,
+		Subject:       idToken.Subject,
+		Email:         claims.Email,
+		EmailVerified: bool(claims.EmailVerified),
+		Name:          claims.Name,
+	}, nil
+}
+
+type identityClaims struct {
+	Email         string   `json:"email"`
+	EmailVerified flexBool `json:"email_verified"`
+	Name          string   `json:"name"`
+}
+
+// flexBool Логическое значение, которое некоторые провайдеры передают строкой "true"
+type flexBool bool
+
+func (b *flexBool) UnmarshalJSON(data []byte) error {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	switch v := value.(type) {
+	case bool:
+		*b = flexBool(v)
+	case string:
+		parsed, _ := strconv.ParseBool(v)
+		*b = flexBool(parsed)
+	}
+
+	return nil
+}
@@ -0,0 +1,340 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testClientID    = "palback"
+	testRedirectURL = "http://localhost/oidc/callback"
+	testKeyID       = "test"
+)
+
+// mockProvider Локальный провайдер OpenID Connect: документ настроек, ключи, авторизация, выдача токенов
+// и данные пользователя. Код авторизации выдается сразу, без страницы входа.
+type mockProvider struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	// claims Данные пользователя, которые попадут в id_token
+	claims map[string]any
+
+	// userInfo Данные, которые вернет userinfo_endpoint
+	userInfo map[string]any
+
+	// nonce Если задан, подменяет nonce из запроса авторизации
+	nonce string
+
+	// signingKey Если задан, id_token подписывается им, а не опубликованным ключом
+	signingKey *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]mockGrant
+}
+
+type mockGrant struct {
+	nonce         string
+	codeChallenge string
+}
+
+func newMockProvider(t *testing.T) *mockProvider {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	m := &mockProvider{
+		key: key,
+		claims: map[string]any{
+			"sub":            "user-1",
+			"email":          "Pilgrim@example.com",
+			"email_verified": true,
+			"name":           "Pilgrim",
+		},
+		codes: make(map[string]mockGrant),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", m.discovery)
+	mux.HandleFunc("/jwks", m.jwks)
+	mux.HandleFunc("/authorize", m.authorize)
+	mux.HandleFunc("/token", m.token)
+	mux.HandleFunc("/userinfo", m.userinfo)
+
+	m.server = httptest.NewServer(mux)
+	t.Cleanup(m.server.Close)
+
+	return m
+}
+
+func (m *mockProvider) discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                m.server.URL,
+		"authorization_endpoint":                m.server.URL + "/authorize",
+		"token_endpoint":                        m.server.URL + "/token",
+		"jwks_uri":                              m.server.URL + "/jwks",
+		"userinfo_endpoint":                     m.server.URL + "/userinfo",
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+	})
+}
+
+func (m *mockProvider) jwks(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": testKeyID,
+			"alg": "RS256",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(m.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(m.key.E)).Bytes()),
+		}},
+	})
+}
+
+// authorize Сразу вернуть пользователя на redirect_uri с кодом авторизации
+func (m *mockProvider) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	if query.Get("client_id") != testClientID || query.Get("code_challenge_method") != "S256" {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+
+	code := rand.Text()
+
+	m.mu.Lock()
+	m.codes[code] = mockGrant{nonce: query.Get("nonce"), codeChallenge: query.Get("code_challenge")}
+	m.mu.Unlock()
+
+	redirect, _ := url.Parse(query.Get("redirect_uri"))
+	redirect.RawQuery = url.Values{"code": {code}, "state": {query.Get("state")}}.Encode()
+
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+func (m *mockProvider) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	m.mu.Lock()
+	grant, ok := m.codes[r.PostForm.Get("code")]
+	delete(m.codes, r.PostForm.Get("code"))
+	m.mu.Unlock()
+
+	challenge := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !ok || base64.RawURLEncoding.EncodeToString(challenge[:]) != grant.codeChallenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	nonce := grant.nonce
+	if m.nonce != "" {
+		nonce = m.nonce
+	}
+
+	claims := map[string]any{
+		"iss":   m.server.URL,
+		"aud":   testClientID,
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"nonce": nonce,
+	}
+	for name, value := range m.claims {
+		claims[name] = value
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": "access-token",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     m.sign(claims),
+	})
+}
+
+func (m *mockProvider) userinfo(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer access-token" || m.userInfo == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, m.userInfo)
+}
+
+// sign Подписать id_token ключом провайдера по RS256
+func (m *mockProvider) sign(claims map[string]any) string {
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": testKeyID, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+
+	input := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(input))
+
+	key := m.key
+	if m.signingKey != nil {
+		key = m.signingKey
+	}
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		panic(err)
+	}
+
+	return input + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// login Пройти авторизацию у провайдера по адресу из AuthCodeURL и вернуть код и state из redirect_uri
+func (m *mockProvider) login(t *testing.T, authURL string) (code, state string) {
+	t.Helper()
+
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	resp, err := client.Get(authURL)
+	if err != nil {
+		t.Fatalf("authorize: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("authorize status = %d, want %d", resp.StatusCode, http.StatusFound)
+	}
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatalf("Location: %v", err)
+	}
+
+	return location.Query().Get("code"), location.Query().Get("state")
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func newTestProvider(t *testing.T, mock *mockProvider) *Provider {
+	t.Helper()
+
+	provider, err := NewProvider(context.Background(), Config{
+		Name:         "mock",
+		Issuer:       mock.server.URL,
+		ClientID:     testClientID,
+		ClientSecret: "secret",
+		RedirectURL:  testRedirectURL,
+	})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	return provider
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	mock := newMockProvider(t)
+	provider := newTestProvider(t, mock)
+
+	code, state := mock.login(t, provider.AuthCodeURL("state-1", "nonce-1", "verifier-verifier-verifier-verifier-123"))
+	if state != "state-1" {
+		t.Fatalf("state = %q, want %q", state, "state-1")
+	}
+
+	identity, err := provider.Exchange(context.Background(), code, "verifier-verifier-verifier-verifier-123", "nonce-1")
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+
+	if identity.Provider != "mock" || identity.Subject != "user-1" {
+		t.Errorf("identity = %s/%s, want mock/user-1", identity.Provider, identity.Subject)
+	}
+
+	if identity.Email != "Pilgrim@example.com" || !identity.EmailVerified || identity.Name != "Pilgrim" {
+		t.Errorf("identity = %+v, want verified Pilgrim@example.com", identity)
+	}
+}
+
+func TestProvider_ExchangeRejectsWrongVerifier(t *testing.T) {
+	mock := newMockProvider(t)
+	provider := newTestProvider(t, mock)
+
+	code, _ := mock.login(t, provider.AuthCodeURL("state-1", "nonce-1", "verifier-verifier-verifier-verifier-123"))
+
+	_, err := provider.Exchange(context.Background(), code, "verifier-verifier-verifier-verifier-456", "nonce-1")
+	if err == nil {
+		t.Fatal("Exchange с чужим code_verifier прошел")
+	}
+}
+
+func TestProvider_ExchangeRejectsWrongNonce(t *testing.T) {
+	mock := newMockProvider(t)
+	mock.nonce = "nonce-2"
+	provider := newTestProvider(t, mock)
+
+	code, _ := mock.login(t, provider.AuthCodeURL("state-1", "nonce-1", "verifier-verifier-verifier-verifier-123"))
+
+	_, err := provider.Exchange(context.Background(), code, "verifier-verifier-verifier-verifier-123", "nonce-1")
+	if err == nil {
+		t.Fatal("Exchange с чужим nonce в id_token прошел")
+	}
+}
+
+func TestProvider_ExchangeRejectsForeignSignature(t *testing.T) {
+	mock := newMockProvider(t)
+	provider := newTestProvider(t, mock)
+
+	foreign, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	mock.signingKey = foreign
+
+	code, _ := mock.login(t, provider.AuthCodeURL("state-1", "nonce-1", "verifier-verifier-verifier-verifier-123"))
+
+	_, err = provider.Exchange(context.Background(), code, "verifier-verifier-verifier-verifier-123", "nonce-1")
+	if err == nil {
+		t.Fatal("Exchange с id_token, подписанным чужим ключом, прошел")
+	}
+}
+
+func TestProvider_ExchangeEmailFromUserInfo(t *testing.T) {
+	mock := newMockProvider(t)
+	mock.claims = map[string]any{"sub": "user-1"}
+	mock.userInfo = map[string]any{
+		"sub":            "user-1",
+		"email":          "pilgrim@example.com",
+		"email_verified": "true",
+		"name":           "Pilgrim",
+	}
+	provider := newTestProvider(t, mock)
+
+	code, _ := mock.login(t, provider.AuthCodeURL("state-1", "nonce-1", "verifier-verifier-verifier-verifier-123"))
+
+	identity, err := provider.Exchange(context.Background(), code, "verifier-verifier-verifier-verifier-123", "nonce-1")
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+
+	if identity.Email != "pilgrim@example.com" || !identity.EmailVerified {
+		t.Errorf("identity = %+v, want verified pilgrim@example.com", identity)
+	}
+}
@@ -0,0 +1,140 @@
+package rate
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"palback/internal/domain/model"
+)
+
+// MemorySlidingLogRateLimiter Ограничение в скользящем окне в памяти процесса, повторяющее
+// SlidingLogRateLimiter. Предназначено для тестов: состояние не разделяется между экземплярами сервера.
+type MemorySlidingLogRateLimiter struct {
+	mu   sync.Mutex
+	now  func() time.Time
+	logs map[string][]time.Time
+}
+
+// NewMemorySlidingLogRateLimiter Часы now подменяются в тестах, чтобы не ждать окончания окна
+func NewMemorySlidingLogRateLimiter(now func() time.Time) *MemorySlidingLogRateLimiter {
+	return &MemorySlidingLogRateLimiter{
+		now:  now,
+		logs: make(map[string][]time.Time),
+	}
+}
+
+func (r *MemorySlidingLogRateLimiter) Allow(
+	_ context.Context,
+	key string,
+	limit int,
+	windowSeconds int,
+) (model.RateLimitResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	window := time.Duration(windowSeconds) * time.Second
+
+	// Убрать запросы, вышедшие из окна
+	log := r.logs[key]
+	start := 0
+	for start < len(log) && !log[start].After(now.Add(-window)) {
+		start++
+	}
+	log = log[start:]
+
+	allowed := len(log) < limit
+	if allowed {
+		log = append(log, now)
+	}
+
+	if len(log) == 0 {
+		delete(r.logs, key)
+	} else {
+		r.logs[key] = log
+	}
+
+	result := model.RateLimitResult{
+		Allowed:   allowed,
+		Limit:     limit,
+		Remaining: max(limit-len(log), 0),
+	}
+
+	if len(log) > 0 {
+		result.ResetAfter = log[0].Add(window).Sub(now)
+	}
+
+	if !allowed {
+		result.RetryAfter = result.ResetAfter
+	}
+
+	return result, nil
+}
+
+// MemoryTokenBucketRateLimiter Корзина токенов в памяти процесса, повторяющая TokenBucketRateLimiter.
+// Предназначена для тестов: состояние не разделяется между экземплярами сервера.
+type MemoryTokenBucketRateLimiter struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	buckets map[string]memoryBucket
+}
+
+type memoryBucket struct {
+	tokens float64
+	ts     time.Time
+}
+
+// NewMemoryTokenBucketRateLimiter Часы now подменяются в тестах, чтобы не ждать наполнения корзины
+func NewMemoryTokenBucketRateLimiter(now func() time.Time) *MemoryTokenBucketRateLimiter {
+	return &MemoryTokenBucketRateLimiter{
+		now:     now,
+		buckets: make(map[string]memoryBucket),
+	}
+}
+
+func (r *MemoryTokenBucketRateLimiter) Allow(
+	_ context.Context,
+	key string,
+	limit int,
+	windowSeconds int,
+) (model.RateLimitResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	capacity := float64(limit)
+	window := time.Duration(windowSeconds) * time.Second
+
+	// Скорость наполнения в токенах за миллисекунду, как в tokenBucketScript
+	rate := capacity / float64(window.Milliseconds())
+
+	bucket, ok := r.buckets[key]
+	if !ok || now.Sub(bucket.ts) >= window {
+		bucket = memoryBucket{tokens: capacity, ts: now}
+	}
+
+	elapsed := max(now.Sub(bucket.ts).Milliseconds(), 0)
+	tokens := math.Min(capacity, bucket.tokens+float64(elapsed)*rate)
+
+	allowed := tokens >= 1
+	if allowed {
+		tokens--
+	}
+
+	r.buckets[key] = memoryBucket{tokens: tokens, ts: now}
+
+	result := model.RateLimitResult{
+		Allowed:    allowed,
+		Limit:      limit,
+		Remaining:  int(math.Floor(tokens)),
+		ResetAfter: time.Duration(math.Ceil((capacity-tokens)/rate)) * time.Millisecond,
+	}
+
+	if !allowed {
+		result.RetryAfter = time.Duration(math.Ceil((1-tokens)/rate)) * time.Millisecond
+	}
+
+	return result, nil
+}
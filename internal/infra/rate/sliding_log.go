@@ -0,0 +1,86 @@
+package rate
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+
+	"palback/internal/domain/model"
+	tokens "palback/internal/pkg/token"
+)
+
+// slidingLogScript Журнал времени запросов в отсортированном множестве. Учитываются запросы
+// за последние windowSeconds, время берется у Redis, чтобы не зависеть от часов экземпляров сервера.
+var slidingLogScript = redis.NewScript(1, `
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local window = tonumber(ARGV[1])
+local limit = tonumber(ARGV[2])
+
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+
+local count = redis.call('ZCARD', KEYS[1])
+local allowed = 0
+if count < limit then
+	redis.call('ZADD', KEYS[1], now, now .. ':' .. ARGV[3])
+	count = count + 1
+	allowed = 1
+end
+redis.call('PEXPIRE', KEYS[1], window)
+
+local reset = 0
+local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+if oldest[2] then
+	reset = tonumber(oldest[2]) + window - now
+end
+
+return {allowed, count, reset}
+`)
+
+// SlidingLogRateLimiter Точное ограничение числа запросов в скользящем окне
+type SlidingLogRateLimiter struct {
+	pool *redis.Pool
+}
+
+func NewSlidingLogRateLimiter(pool *redis.Pool) *SlidingLogRateLimiter {
+	return &SlidingLogRateLimiter{pool: pool}
+}
+
+func (r *SlidingLogRateLimiter) Allow(
+	ctx context.Context,
+	key string,
+	limit int,
+	windowSeconds int,
+) (model.RateLimitResult, error) {
+	// Запросы в одну миллисекунду различаются случайной частью
+	member, err := tokens.GenerateVerificationToken()
+	if err != nil {
+		return model.RateLimitResult{}, fmt.Errorf("generate log member failed: %w", err)
+	}
+
+	conn := r.pool.Get()
+	defer conn.Close()
+
+	reply, err := redis.Int64s(slidingLogScript.DoContext(ctx, conn, key, windowSeconds*1000, limit, member[:8]))
+	if err != nil {
+		return model.RateLimitResult{}, fmt.Errorf("redis sliding log script failed: %w", err)
+	}
+
+	allowed, count, reset := reply[0] == 1, int(reply[1]), time.Duration(reply[2])*time.Millisecond
+
+	result := model.RateLimitResult{
+		Allowed:    allowed,
+		Limit:      limit,
+		Remaining:  max(limit-count, 0),
+		ResetAfter: reset,
+	}
+
+	// Место освободится, когда из окна выйдет самый старый запрос
+	if !allowed {
+		result.RetryAfter = reset
+	}
+
+	return result, nil
+}
@@ -0,0 +1,75 @@
+package rate
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+
+	"palback/internal/domain/model"
+)
+
+// tokenBucketScript Корзина на limit токенов, которая полностью наполняется за windowSeconds.
+// Допускает всплески до limit запросов, а затем равномерный поток.
+var tokenBucketScript = redis.NewScript(1, `
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local capacity = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local rate = capacity / window
+
+local data = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(data[1]) or capacity
+local ts = tonumber(data[2]) or now
+tokens = math.min(capacity, tokens + math.max(now - ts, 0) * rate)
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], window)
+
+local retry = 0
+if allowed == 0 then
+	retry = math.ceil((1 - tokens) / rate)
+end
+local reset = math.ceil((capacity - tokens) / rate)
+
+return {allowed, math.floor(tokens), retry, reset}
+`)
+
+// TokenBucketRateLimiter Ограничение частоты запросов корзиной токенов
+type TokenBucketRateLimiter struct {
+	pool *redis.Pool
+}
+
+func NewTokenBucketRateLimiter(pool *redis.Pool) *TokenBucketRateLimiter {
+	return &TokenBucketRateLimiter{pool: pool}
+}
+
+func (r *TokenBucketRateLimiter) Allow(
+	ctx context.Context,
+	key string,
+	limit int,
+	windowSeconds int,
+) (model.RateLimitResult, error) {
+	conn := r.pool.Get()
+	defer conn.Close()
+
+	reply, err := redis.Int64s(tokenBucketScript.DoContext(ctx, conn, key, limit, windowSeconds*1000))
+	if err != nil {
+		return model.RateLimitResult{}, fmt.Errorf("redis token bucket script failed: %w", err)
+	}
+
+	return model.RateLimitResult{
+		Allowed:    reply[0] == 1,
+		Limit:      limit,
+		Remaining:  int(reply[1]),
+		RetryAfter: time.Duration(reply[2]) * time.Millisecond,
+		ResetAfter: time.Duration(reply[3]) * time.Millisecond,
+	}, nil
+}
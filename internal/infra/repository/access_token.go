@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+
+	"palback/internal/domain/model"
+	localErrors "palback/internal/pkg/errors"
+)
+
+type AccessTokenRepo struct {
+	db *sql.DB
+}
+
+func NewAccessTokenRepo(db *sql.DB) *AccessTokenRepo {
+	return &AccessTokenRepo{
+		db: db,
+	}
+}
+
+type accessTokenDTO struct {
+	ID         int            `json:"id"`
+	UserID     int            `json:"user_id"`
+	Name       string         `json:"name"`
+	Prefix     string         `json:"prefix"`
+	TokenHash  string         `json:"token_hash"`
+	Scopes     pq.StringArray `json:"scopes"`
+	CreatedAt  time.Time      `json:"created_at"`
+	LastUsedAt sql.NullTime   `json:"last_used_at"`
+	ExpiresAt  sql.NullTime   `json:"expires_at"`
+}
+
+func (dto *accessTokenDTO) ToModel() model.AccessToken {
+	token := model.AccessToken{
+		ID:        dto.ID,
+		UserID:    dto.UserID,
+		Name:      dto.Name,
+		Prefix:    dto.Prefix,
+		TokenHash: dto.TokenHash,
+		Scopes:    make([]model.AccessTokenScope, 0, len(dto.Scopes)),
+		CreatedAt: dto.CreatedAt,
+	}
+
+	for _, scope := range dto.Scopes {
+		token.Scopes = append(token.Scopes, model.AccessTokenScope(scope))
+	}
+
+	if dto.LastUsedAt.Valid {
+		token.LastUsedAt = &dto.LastUsedAt.Time
+	}
+
+	if dto.ExpiresAt.Valid {
+		token.ExpiresAt = &dto.ExpiresAt.Time
+	}
+
+	return token
+}
+
+func (dto *accessTokenDTO) scanFields() []any {
+	return []any{
+		&dto.ID,
+		&dto.UserID,
+		&dto.Name,
+		&dto.Prefix,
+		&dto.TokenHash,
+		&dto.Scopes,
+		&dto.CreatedAt,
+		&dto.LastUsedAt,
+		&dto.ExpiresAt,
+	}
+}
+
+const accessTokenFields = `id, user_id, name, prefix, token_hash, scopes, created_at, last_used_at, expires_at`
+
+func (r *AccessTokenRepo) GetByHash(ctx context.Context, tokenHash string) (*model.AccessToken, error) {
+	q := `select ` + accessTokenFields + ` from access_tokens where token_hash = $1`
+
+	var dto accessTokenDTO
+
+	err := r.db.QueryRowContext(ctx, q, tokenHash).Scan(dto.scanFields()...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, localErrors.ErrNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	token := dto.ToModel()
+
+	return &token, nil
+}
+
+func (r *AccessTokenRepo) GetByUser(ctx context.Context, userID int) ([]model.AccessToken, error) {
+	q := `select ` + accessTokenFields + ` from access_tokens where user_id = $1 order by created_at desc`
+
+	rows, err := r.db.QueryContext(ctx, q, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []model.AccessToken
+
+	for rows.Next() {
+		var dto accessTokenDTO
+
+		err := rows.Scan(dto.scanFields()...)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, dto.ToModel())
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *AccessTokenRepo) Create(ctx context.Context, token model.AccessToken) (*model.AccessToken, error) {
+	q := `insert into access_tokens (user_id, name, prefix, token_hash, scopes, expires_at)
+		values ($1, $2, $3, $4, $5, $6)
+		returning id, created_at`
+
+	scopes := make([]string, 0, len(token.Scopes))
+	for _, scope := range token.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+
+	err := r.db.QueryRowContext(ctx, q,
+		token.UserID,
+		token.Name,
+		token.Prefix,
+		token.TokenHash,
+		pq.Array(scopes),
+		token.ExpiresAt,
+	).Scan(&token.ID, &token.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// UpdateLastUsed Отметить использование токена. Время обновляется не чаще раза в минуту.
+func (r *AccessTokenRepo) UpdateLastUsed(ctx context.Context, id int) error {
+	q := `update access_tokens set last_used_at = now()
+		where id = $1 and (last_used_at is null or last_used_at < now() - interval '1 minute')`
+
+	_, err := r.db.ExecContext(ctx, q, id)
+
+	return err
+}
+
+// Delete Удалить токен пользователя. Чужой токен считается не найденным.
+func (r *AccessTokenRepo) Delete(ctx context.Context, userID, id int) error {
+	q := `delete from access_tokens where id = $1 and user_id = $2`
+
+	result, err := r.db.ExecContext(ctx, q, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return localErrors.ErrNotFound
+	}
+
+	return nil
+}
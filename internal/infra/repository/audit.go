@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"palback/internal/domain/model"
+)
+
+type AuditRepo struct {
+	db *sql.DB
+}
+
+func NewAuditRepo(db *sql.DB) *AuditRepo {
+	return &AuditRepo{
+		db: db,
+	}
+}
+
+type auditDTO struct {
+	ID         int            `json:"id"`
+	Action     string         `json:"action"`
+	ActorID    sql.NullInt64  `json:"actor_id"`
+	IP         string         `json:"ip"`
+	UserAgent  string         `json:"user_agent"`
+	EntityType string         `json:"entity_type"`
+	EntityID   string         `json:"entity_id"`
+	Payload    sql.NullString `json:"payload"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+func (dto *auditDTO) ToModel() model.AuditEntry {
+	entry := model.AuditEntry{
+		ID:         dto.ID,
+		Action:     model.AuditAction(dto.Action),
+		IP:         dto.IP,
+		UserAgent:  dto.UserAgent,
+		EntityType: dto.EntityType,
+		EntityID:   dto.EntityID,
+		CreatedAt:  dto.CreatedAt,
+	}
+
+	if dto.ActorID.Valid {
+		actorID := int(dto.ActorID.Int64)
+		entry.ActorID = &actorID
+	}
+
+	if dto.Payload.Valid {
+		entry.Payload = []byte(dto.Payload.String)
+	}
+
+	return entry
+}
+
+func (dto *auditDTO) scanFields() []any {
+	return []any{
+		&dto.ID,
+		&dto.Action,
+		&dto.ActorID,
+		&dto.IP,
+		&dto.UserAgent,
+		&dto.EntityType,
+		&dto.EntityID,
+		&dto.Payload,
+		&dto.CreatedAt,
+	}
+}
+
+const auditFields = `id, action, actor_id, ip, user_agent, entity_type, entity_id, payload, created_at`
+
+// GetList Получить записи журнала, удовлетворяющие фильтру, начиная с последних
+func (r *AuditRepo) GetList(ctx context.Context, filter model.AuditFilter) ([]model.AuditEntry, error) {
+	conditions, args := auditFilterConditions(filter)
+
+	q := `select ` + auditFields + ` from audit_log` + conditions
+
+	args = append(args, filter.Limit, filter.Offset)
+	q += fmt.Sprintf(` order by id desc limit $%d offset $%d`, len(args)-1, len(args))
+
+	rows, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []model.AuditEntry
+
+	for rows.Next() {
+		var dto auditDTO
+
+		err := rows.Scan(dto.scanFields()...)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, dto.ToModel())
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Count Получить количество записей журнала, удовлетворяющих фильтру, без учета страницы
+func (r *AuditRepo) Count(ctx context.Context, filter model.AuditFilter) (int, error) {
+	conditions, args := auditFilterConditions(filter)
+
+	var count int
+
+	err := r.db.QueryRowContext(ctx, `select count(*) from audit_log`+conditions, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *AuditRepo) Create(ctx context.Context, entry model.AuditEntry) error {
+	q := `
+insert into audit_log (action, actor_id, ip, user_agent, entity_type, entity_id, payload)
+values ($1, $2, $3, $4, $5, $6, $7)
+`
+
+	_, err := r.db.ExecContext(ctx, q,
+		entry.Action,
+		entry.ActorID,
+		entry.IP,
+		entry.UserAgent,
+		entry.EntityType,
+		entry.EntityID,
+		nullJSON(entry.Payload),
+	)
+
+	return err
+}
+
+// auditFilterConditions Условие where для фильтра журнала аудита
+func auditFilterConditions(filter model.AuditFilter) (string, []any) {
+	var (
+		conditions []string
+		args       []any
+	)
+
+	if filter.Action != "" {
+		args = append(args, filter.Action)
+		conditions = append(conditions, fmt.Sprintf("action = $%d", len(args)))
+	}
+
+	if filter.ActorID != nil {
+		args = append(args, *filter.ActorID)
+		conditions = append(conditions, fmt.Sprintf("actor_id = $%d", len(args)))
+	}
+
+	if filter.EntityType != "" {
+		args = append(args, filter.EntityType)
+		conditions = append(conditions, fmt.Sprintf("entity_type = $%d", len(args)))
+	}
+
+	if filter.EntityID != "" {
+		args = append(args, filter.EntityID)
+		conditions = append(conditions, fmt.Sprintf("entity_id = $%d", len(args)))
+	}
+
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", len(args)))
+	}
+
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conditions = append(conditions, fmt.Sprintf("created_at < $%d", len(args)))
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+
+	return ` where ` + strings.Join(conditions, " and "), args
+}
@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"palback/internal/domain/model"
+	localErrors "palback/internal/pkg/errors"
+	"palback/internal/pkg/locale"
+)
+
+type CityRepo struct {
+	db *sql.DB
+}
+
+func NewCityRepo(db *sql.DB) *CityRepo {
+	return &CityRepo{
+		db: db,
+	}
+}
+
+type cityDTO struct {
+	ID         int           `json:"id"`
+	CountryID  string        `json:"country_id"`
+	RegionID   sql.NullInt64 `json:"region_id"`
+	CityTypeID int           `json:"city_type_id"`
+	Name       string        `json:"name"`
+	Latitude   float64       `json:"latitude"`
+	Longitude  float64       `json:"longitude"`
+}
+
+func (dto *cityDTO) ToModel() model.City {
+	city := model.City{
+		ID:         dto.ID,
+		CountryID:  dto.CountryID,
+		CityTypeID: dto.CityTypeID,
+		Name:       dto.Name,
+		Latitude:   dto.Latitude,
+		Longitude:  dto.Longitude,
+	}
+
+	if dto.RegionID.Valid {
+		regionID := int(dto.RegionID.Int64)
+		city.RegionID = &regionID
+	}
+
+	return city
+}
+
+// Get Получить информацию об одном населенном пункте
+func (r *CityRepo) Get(ctx context.Context, id int) (*model.City, error) {
+	q := `
+select c.id, c.country_id, c.region_id, c.city_type_id, coalesce(t.name, c.name), c.latitude, c.longitude
+from cities c
+left join city_translations t on t.city_id = c.id and t.lang = $1
+where c.id = $2
+`
+
+	var dto cityDTO
+
+	err := r.db.QueryRowContext(ctx, q, locale.Lang(ctx), id).Scan(
+		&dto.ID,
+		&dto.CountryID,
+		&dto.RegionID,
+		&dto.CityTypeID,
+		&dto.Name,
+		&dto.Latitude,
+		&dto.Longitude,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, localErrors.ErrNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	city := dto.ToModel()
+	return &city, nil
+}
+
+// GetByCountry Получить список населенных пунктов страны
+func (r *CityRepo) GetByCountry(ctx context.Context, countryID string) ([]model.City, error) {
+	q := `
+select c.id, c.country_id, c.region_id, c.city_type_id, coalesce(t.name, c.name) as name, c.latitude, c.longitude
+from cities c
+left join city_translations t on t.city_id = c.id and t.lang = $1
+where c.country_id = $2
+order by name
+`
+
+	rows, err := r.db.QueryContext(ctx, q, locale.Lang(ctx), countryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cities []model.City
+
+	for rows.Next() {
+		var dto cityDTO
+
+		err := rows.Scan(
+			&dto.ID,
+			&dto.CountryID,
+			&dto.RegionID,
+			&dto.CityTypeID,
+			&dto.Name,
+			&dto.Latitude,
+			&dto.Longitude,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		cities = append(cities, dto.ToModel())
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return cities, nil
+}
+
+func (r *CityRepo) Create(ctx context.Context, city model.City) (*model.City, error) {
+	q := `
+insert into cities (country_id, region_id, city_type_id, name, latitude, longitude)
+values ($1, $2, $3, $4, $5, $6)
+returning id
+`
+
+	var id int
+	err := r.db.QueryRowContext(ctx, q,
+		city.CountryID,
+		city.RegionID,
+		city.CityTypeID,
+		city.Name,
+		city.Latitude,
+		city.Longitude,
+	).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+
+	city.ID = id
+
+	return &city, nil
+}
+
+func (r *CityRepo) Update(ctx context.Context, id int, city model.City) error {
+	q := `
+update cities
+set country_id = $1, region_id = $2, city_type_id = $3, name = $4, latitude = $5, longitude = $6
+where id = $7
+`
+
+	result, err := r.db.ExecContext(ctx, q,
+		city.CountryID,
+		city.RegionID,
+		city.CityTypeID,
+		city.Name,
+		city.Latitude,
+		city.Longitude,
+		id,
+	)
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return localErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *CityRepo) Delete(ctx context.Context, id int) error {
+	q := `delete from cities where id=$1`
+
+	result, err := r.db.ExecContext(ctx, q, id)
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return localErrors.ErrNotFound
+	}
+
+	return nil
+}
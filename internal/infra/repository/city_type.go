@@ -7,6 +7,7 @@ import (
 	
 	"palback/internal/domain/model"
 	localErrors "palback/internal/pkg/errors"
+	"palback/internal/pkg/locale"
 )
 
 type CityTypeRepo struct {
@@ -37,11 +38,16 @@ func (dto *cityTypeDTO) ToModel() model.CityType {
 
 // Get Получить информацию об одном населенном пункте
 func (r *CityTypeRepo) Get(ctx context.Context, id int) (*model.CityType, error) {
-	q := `select id, name, short_name, weight from city_types where id = $1`
+	q := `
+select ct.id, coalesce(t.name, ct.name), coalesce(t.short_name, ct.short_name), ct.weight
+from city_types ct
+left join city_type_translations t on t.city_type_id = ct.id and t.lang = $1
+where ct.id = $2
+`
 
 	var dto cityTypeDTO
 
-	err := r.db.QueryRowContext(ctx, q, id).Scan(&dto.ID, &dto.Name, &dto.ShortName, &dto.Weight)
+	err := r.db.QueryRowContext(ctx, q, locale.Lang(ctx), id).Scan(&dto.ID, &dto.Name, &dto.ShortName, &dto.Weight)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, localErrors.ErrNotFound
 	}
@@ -56,9 +62,14 @@ func (r *CityTypeRepo) Get(ctx context.Context, id int) (*model.CityType, error)
 
 // GetAll Получить информацию обо всех населенных пунктах
 func (r *CityTypeRepo) GetAll(ctx context.Context) ([]model.CityType, error) {
-	q := `select id, name, short_name, weight from city_types order by weight`
-
-	rows, err := r.db.QueryContext(ctx, q)
+	q := `
+select ct.id, coalesce(t.name, ct.name), coalesce(t.short_name, ct.short_name), ct.weight
+from city_types ct
+left join city_type_translations t on t.city_type_id = ct.id and t.lang = $1
+order by ct.weight
+`
+
+	rows, err := r.db.QueryContext(ctx, q, locale.Lang(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -9,6 +9,7 @@ import (
 
 	"palback/internal/domain/model"
 	localErrors "palback/internal/pkg/errors"
+	"palback/internal/pkg/locale"
 	"palback/internal/usecase"
 )
 
@@ -40,11 +41,16 @@ func (dto *countryDTO) ToModel() model.Country {
 
 // Get Получить информацию об одной стране
 func (r *CountryRepo) Get(ctx context.Context, id string) (*model.Country, error) {
-	q := `select c.id, c.name, c.has_regions, c.weight from countries c where c.id = $1`
+	q := `
+select c.id, coalesce(t.name, c.name), c.has_regions, c.weight
+from countries c
+left join country_translations t on t.country_id = c.id and t.lang = $1
+where c.id = $2
+`
 
 	var dto countryDTO
 
-	err := r.db.QueryRowContext(ctx, q, id).Scan(
+	err := r.db.QueryRowContext(ctx, q, locale.Lang(ctx), id).Scan(
 		&dto.ID,
 		&dto.Name,
 		&dto.HasRegions,
@@ -64,9 +70,14 @@ func (r *CountryRepo) Get(ctx context.Context, id string) (*model.Country, error
 
 // GetAll Получить информацию обо всех странах
 func (r *CountryRepo) GetAll(ctx context.Context) ([]model.Country, error) {
-	q := `select id, name, has_regions, weight from countries order by weight desc, name`
-
-	rows, err := r.db.QueryContext(ctx, q)
+	q := `
+select c.id, coalesce(t.name, c.name) as name, c.has_regions, c.weight
+from countries c
+left join country_translations t on t.country_id = c.id and t.lang = $1
+order by c.weight desc, name
+`
+
+	rows, err := r.db.QueryContext(ctx, q, locale.Lang(ctx))
 	if err != nil {
 		return nil, err
 	}
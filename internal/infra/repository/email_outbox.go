@@ -0,0 +1,312 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"palback/internal/domain/model"
+	localErrors "palback/internal/pkg/errors"
+)
+
+type EmailOutboxRepo struct {
+	db *sql.DB
+}
+
+func NewEmailOutboxRepo(db *sql.DB) *EmailOutboxRepo {
+	return &EmailOutboxRepo{
+		db: db,
+	}
+}
+
+// execer и queryRower Общие методы *sql.DB и *sql.Tx, чтобы запись можно было добавить как отдельно,
+// так и в транзакции вместе с другими
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type emailMessageDTO struct {
+	ID            int          `json:"id"`
+	Kind          string       `json:"kind"`
+	ToEmail       string       `json:"to_email"`
+	Payload       []byte       `json:"payload"`
+	Status        string       `json:"status"`
+	Attempts      int          `json:"attempts"`
+	LastError     string       `json:"last_error"`
+	NextAttemptAt time.Time    `json:"next_attempt_at"`
+	CreatedAt     time.Time    `json:"created_at"`
+	SentAt        sql.NullTime `json:"sent_at"`
+}
+
+// emailPayloadDTO Данные шаблона письма в колонке payload
+type emailPayloadDTO struct {
+	Token     string     `json:"token,omitempty"`
+	PlaceName string     `json:"place_name,omitempty"`
+	State     string     `json:"state,omitempty"`
+	Reason    string     `json:"reason,omitempty"`
+	Time      *time.Time `json:"time,omitempty"`
+}
+
+func (dto *emailMessageDTO) ToModel() (model.EmailMessage, error) {
+	var payload emailPayloadDTO
+
+	if err := json.Unmarshal(dto.Payload, &payload); err != nil {
+		return model.EmailMessage{}, fmt.Errorf("неверные данные письма %d: %w", dto.ID, err)
+	}
+
+	message := model.EmailMessage{
+		ID:      dto.ID,
+		Kind:    model.EmailKind(dto.Kind),
+		ToEmail: dto.ToEmail,
+		Payload: model.EmailPayload{
+			Token:     payload.Token,
+			PlaceName: payload.PlaceName,
+			State:     model.SubmissionState(payload.State),
+			Reason:    payload.Reason,
+			Time:      payload.Time,
+		},
+		Status:        model.EmailStatus(dto.Status),
+		Attempts:      dto.Attempts,
+		LastError:     dto.LastError,
+		NextAttemptAt: dto.NextAttemptAt,
+		CreatedAt:     dto.CreatedAt,
+	}
+
+	if dto.SentAt.Valid {
+		message.SentAt = &dto.SentAt.Time
+	}
+
+	return message, nil
+}
+
+func (dto *emailMessageDTO) scanFields() []any {
+	return []any{
+		&dto.ID,
+		&dto.Kind,
+		&dto.ToEmail,
+		&dto.Payload,
+		&dto.Status,
+		&dto.Attempts,
+		&dto.LastError,
+		&dto.NextAttemptAt,
+		&dto.CreatedAt,
+		&dto.SentAt,
+	}
+}
+
+const emailMessageFields = `id, kind, to_email, payload, status, attempts, last_error, next_attempt_at, created_at, sent_at`
+
+func (r *EmailOutboxRepo) Get(ctx context.Context, id int) (*model.EmailMessage, error) {
+	q := `select ` + emailMessageFields + ` from email_outbox where id = $1`
+
+	var dto emailMessageDTO
+
+	err := r.db.QueryRowContext(ctx, q, id).Scan(dto.scanFields()...)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, localErrors.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	message, err := dto.ToModel()
+	if err != nil {
+		return nil, err
+	}
+
+	return &message, nil
+}
+
+// GetList Получить письма, удовлетворяющие фильтру, начиная с последних
+func (r *EmailOutboxRepo) GetList(ctx context.Context, filter model.EmailFilter) ([]model.EmailMessage, error) {
+	conditions, args := emailFilterConditions(filter)
+
+	q := `select ` + emailMessageFields + ` from email_outbox` + conditions
+
+	args = append(args, filter.Limit, filter.Offset)
+	q += fmt.Sprintf(` order by id desc limit $%d offset $%d`, len(args)-1, len(args))
+
+	return r.getList(ctx, q, args...)
+}
+
+// Count Получить количество писем, удовлетворяющих фильтру, без учета страницы
+func (r *EmailOutboxRepo) Count(ctx context.Context, filter model.EmailFilter) (int, error) {
+	conditions, args := emailFilterConditions(filter)
+
+	var count int
+
+	err := r.db.QueryRowContext(ctx, `select count(*) from email_outbox`+conditions, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// ClaimDue Выбрать письма, которые пора отправить, и отложить их на время lease. Если отправитель
+// не успеет отметить результат, например из-за остановки сервера, письма снова станут доступны
+// после lease. Письма, уже выбранные другим экземпляром сервера, пропускаются.
+func (r *EmailOutboxRepo) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]model.EmailMessage, error) {
+	q := `
+update email_outbox set next_attempt_at = now() + make_interval(secs => $2)
+where id in (
+    select id from email_outbox
+    where status = 'pending' and next_attempt_at <= now()
+    order by next_attempt_at, id
+    limit $1
+    for update skip locked
+)
+returning ` + emailMessageFields
+
+	return r.getList(ctx, q, limit, lease.Seconds())
+}
+
+func (r *EmailOutboxRepo) getList(ctx context.Context, q string, args ...any) ([]model.EmailMessage, error) {
+	rows, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []model.EmailMessage
+
+	for rows.Next() {
+		var dto emailMessageDTO
+
+		err := rows.Scan(dto.scanFields()...)
+		if err != nil {
+			return nil, err
+		}
+
+		message, err := dto.ToModel()
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, message)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *EmailOutboxRepo) Create(ctx context.Context, message model.EmailMessage) error {
+	return insertEmailMessage(ctx, r.db, message)
+}
+
+// MarkSent Отметить письмо отправленным. Данные шаблона, среди которых могут быть токены, больше не нужны и удаляются.
+func (r *EmailOutboxRepo) MarkSent(ctx context.Context, id int) error {
+	q := `
+update email_outbox
+set status = 'sent', attempts = attempts + 1, last_error = '', payload = '{}', sent_at = now()
+where id = $1
+`
+
+	return r.exec(ctx, q, id)
+}
+
+// MarkFailed Учесть неудачную попытку и отложить следующую на retryAfter
+func (r *EmailOutboxRepo) MarkFailed(ctx context.Context, id int, lastError string, retryAfter time.Duration) error {
+	q := `
+update email_outbox
+set attempts = attempts + 1, last_error = $2, next_attempt_at = now() + make_interval(secs => $3)
+where id = $1
+`
+
+	return r.exec(ctx, q, id, lastError, retryAfter.Seconds())
+}
+
+// MarkDead Учесть последнюю неудачную попытку, после которой письмо больше не отправляется
+func (r *EmailOutboxRepo) MarkDead(ctx context.Context, id int, lastError string) error {
+	q := `update email_outbox set status = 'dead', attempts = attempts + 1, last_error = $2 where id = $1`
+
+	return r.exec(ctx, q, id, lastError)
+}
+
+// Requeue Вернуть неотправленное письмо в очередь с обнуленным счетчиком попыток
+func (r *EmailOutboxRepo) Requeue(ctx context.Context, id int) error {
+	q := `
+update email_outbox
+set status = 'pending', attempts = 0, last_error = '', next_attempt_at = now()
+where id = $1 and status <> 'sent'
+`
+
+	return r.exec(ctx, q, id)
+}
+
+func (r *EmailOutboxRepo) exec(ctx context.Context, q string, args ...any) error {
+	result, err := r.db.ExecContext(ctx, q, args...)
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return localErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+func insertEmailMessage(ctx context.Context, db execer, message model.EmailMessage) error {
+	payload, err := json.Marshal(emailPayloadDTO{
+		Token:     message.Payload.Token,
+		PlaceName: message.Payload.PlaceName,
+		State:     string(message.Payload.State),
+		Reason:    message.Payload.Reason,
+		Time:      message.Payload.Time,
+	})
+	if err != nil {
+		return fmt.Errorf("ошибка сериализации данных письма: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx,
+		`insert into email_outbox (kind, to_email, payload) values ($1, $2, $3)`,
+		message.Kind,
+		message.ToEmail,
+		string(payload),
+	)
+
+	return err
+}
+
+// emailFilterConditions Условие where для фильтра писем
+func emailFilterConditions(filter model.EmailFilter) (string, []any) {
+	var (
+		conditions []string
+		args       []any
+	)
+
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	if filter.Kind != "" {
+		args = append(args, filter.Kind)
+		conditions = append(conditions, fmt.Sprintf("kind = $%d", len(args)))
+	}
+
+	if filter.ToEmail != "" {
+		args = append(args, filter.ToEmail)
+		conditions = append(conditions, fmt.Sprintf("to_email = $%d", len(args)))
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+
+	return ` where ` + strings.Join(conditions, " and "), args
+}
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"palback/internal/domain/model"
+	localErrors "palback/internal/pkg/errors"
+	"palback/internal/usecase"
+)
+
+type IdentityRepo struct {
+	db *sql.DB
+}
+
+func NewIdentityRepo(db *sql.DB) *IdentityRepo {
+	return &IdentityRepo{
+		db: db,
+	}
+}
+
+type identityDTO struct {
+	ID        int       `json:"id"`
+	UserID    int       `json:"user_id"`
+	Provider  string    `json:"provider"`
+	Subject   string    `json:"subject"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (dto *identityDTO) ToModel() model.Identity {
+	return model.Identity{
+		ID:        dto.ID,
+		UserID:    dto.UserID,
+		Provider:  dto.Provider,
+		Subject:   dto.Subject,
+		Email:     dto.Email,
+		CreatedAt: dto.CreatedAt,
+	}
+}
+
+func (dto *identityDTO) scanFields() []any {
+	return []any{
+		&dto.ID,
+		&dto.UserID,
+		&dto.Provider,
+		&dto.Subject,
+		&dto.Email,
+		&dto.CreatedAt,
+	}
+}
+
+const identityFields = `id, user_id, provider, subject, email, created_at`
+
+func (r *IdentityRepo) getOne(ctx context.Context, q string, args ...any) (*model.Identity, error) {
+	var dto identityDTO
+
+	err := r.db.QueryRowContext(ctx, q, args...).Scan(dto.scanFields()...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, localErrors.ErrNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	identity := dto.ToModel()
+
+	return &identity, nil
+}
+
+func (r *IdentityRepo) Get(ctx context.Context, id int) (*model.Identity, error) {
+	q := `select ` + identityFields + ` from identities where id = $1`
+
+	return r.getOne(ctx, q, id)
+}
+
+func (r *IdentityRepo) GetBySubject(ctx context.Context, provider, subject string) (*model.Identity, error) {
+	q := `select ` + identityFields + ` from identities where provider = $1 and subject = $2`
+
+	return r.getOne(ctx, q, provider, subject)
+}
+
+func (r *IdentityRepo) GetByUser(ctx context.Context, userID int) ([]model.Identity, error) {
+	q := `select ` + identityFields + ` from identities where user_id = $1 order by created_at`
+
+	rows, err := r.db.QueryContext(ctx, q, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []model.Identity
+
+	for rows.Next() {
+		var dto identityDTO
+
+		err := rows.Scan(dto.scanFields()...)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, dto.ToModel())
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *IdentityRepo) Create(ctx context.Context, identity model.Identity) (*model.Identity, error) {
+	q := `insert into identities (user_id, provider, subject, email)
+		values ($1, $2, $3, $4)
+		returning id, created_at`
+
+	err := r.db.QueryRowContext(ctx, q,
+		identity.UserID,
+		identity.Provider,
+		identity.Subject,
+		identity.Email,
+	).Scan(&identity.ID, &identity.CreatedAt)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "identities_provider_subject_key"),
+			strings.Contains(err.Error(), "identities_user_provider_key"):
+			return nil, usecase.ErrIdentityAlreadyLinked
+		default:
+			return nil, err
+		}
+	}
+
+	return &identity, nil
+}
+
+func (r *IdentityRepo) Delete(ctx context.Context, id int) error {
+	q := `delete from identities where id = $1`
+
+	result, err := r.db.ExecContext(ctx, q, id)
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return localErrors.ErrNotFound
+	}
+
+	return nil
+}
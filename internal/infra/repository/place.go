@@ -0,0 +1,317 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"palback/internal/domain/model"
+	localErrors "palback/internal/pkg/errors"
+	"palback/internal/pkg/geo"
+	"palback/internal/pkg/locale"
+)
+
+type PlaceRepo struct {
+	db *sql.DB
+}
+
+func NewPlaceRepo(db *sql.DB) *PlaceRepo {
+	return &PlaceRepo{
+		db: db,
+	}
+}
+
+type placeDTO struct {
+	ID           int           `json:"id"`
+	PlaceTypeID  int           `json:"place_type_id"`
+	CityID       int           `json:"city_id"`
+	Name         string        `json:"name"`
+	Address      string        `json:"address"`
+	Latitude     float64       `json:"latitude"`
+	Longitude    float64       `json:"longitude"`
+	Description  string        `json:"description"`
+	FoundingYear sql.NullInt64 `json:"founding_year"`
+	Status       string        `json:"status"`
+}
+
+func (dto *placeDTO) ToModel() model.Place {
+	place := model.Place{
+		ID:          dto.ID,
+		PlaceTypeID: dto.PlaceTypeID,
+		CityID:      dto.CityID,
+		Name:        dto.Name,
+		Address:     dto.Address,
+		Latitude:    dto.Latitude,
+		Longitude:   dto.Longitude,
+		Description: dto.Description,
+		Status:      model.PlaceStatus(dto.Status),
+	}
+
+	if dto.FoundingYear.Valid {
+		year := int(dto.FoundingYear.Int64)
+		place.FoundingYear = &year
+	}
+
+	return place
+}
+
+func (dto *placeDTO) scanFields() []any {
+	return []any{
+		&dto.ID,
+		&dto.PlaceTypeID,
+		&dto.CityID,
+		&dto.Name,
+		&dto.Address,
+		&dto.Latitude,
+		&dto.Longitude,
+		&dto.Description,
+		&dto.FoundingYear,
+		&dto.Status,
+	}
+}
+
+const placeFields = `id, place_type_id, city_id, name, address, latitude, longitude, description, founding_year, status`
+
+// placeTranslatedFields Поля святого места с названием на языке из первого параметра запроса, см. placeTranslatedSource
+const placeTranslatedFields = `p.id, p.place_type_id, p.city_id, coalesce(t.name, p.name) as name, p.address, p.latitude, p.longitude,
+p.description, p.founding_year, p.status`
+
+const placeTranslatedSource = `places p left join place_translations t on t.place_id = p.id and t.lang = $1`
+
+// Get Получить информацию об одном святом месте
+func (r *PlaceRepo) Get(ctx context.Context, id int) (*model.Place, error) {
+	q := `select ` + placeTranslatedFields + ` from ` + placeTranslatedSource + ` where p.id = $2`
+
+	var dto placeDTO
+
+	err := r.db.QueryRowContext(ctx, q, locale.Lang(ctx), id).Scan(dto.scanFields()...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, localErrors.ErrNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	place := dto.ToModel()
+	return &place, nil
+}
+
+// GetList Получить список святых мест, удовлетворяющих фильтру
+func (r *PlaceRepo) GetList(ctx context.Context, filter model.PlaceFilter) ([]model.Place, error) {
+	var conditions []string
+
+	args := []any{locale.Lang(ctx)}
+
+	if filter.CityID != nil {
+		args = append(args, *filter.CityID)
+		conditions = append(conditions, fmt.Sprintf("city_id = $%d", len(args)))
+	}
+
+	if filter.PlaceTypeID != nil {
+		args = append(args, *filter.PlaceTypeID)
+		conditions = append(conditions, fmt.Sprintf("place_type_id = $%d", len(args)))
+	}
+
+	if filter.BoundingBox != nil {
+		conditions, args = appendBoundingBoxConditions(conditions, args, *filter.BoundingBox)
+	}
+
+	q := `select ` + placeTranslatedFields + ` from ` + placeTranslatedSource
+	if len(conditions) > 0 {
+		q += ` where ` + strings.Join(conditions, " and ")
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	q += fmt.Sprintf(` order by name, id limit $%d offset $%d`, len(args)-1, len(args))
+
+	rows, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var places []model.Place
+
+	for rows.Next() {
+		var dto placeDTO
+
+		err := rows.Scan(dto.scanFields()...)
+		if err != nil {
+			return nil, err
+		}
+
+		places = append(places, dto.ToModel())
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return places, nil
+}
+
+// GetNearby Получить святые места в пределах радиуса от точки, упорядоченные по расстоянию.
+// Точки сначала отбираются по индексу координат в описанном прямоугольнике,
+// затем для них вычисляется точное расстояние по формуле гаверсинусов.
+func (r *PlaceRepo) GetNearby(ctx context.Context, filter model.PlaceNearbyFilter) ([]model.PlaceWithDistance, error) {
+	args := []any{locale.Lang(ctx), filter.Latitude, filter.Longitude}
+	distance := fmt.Sprintf(`%f * 2 * asin(sqrt(
+    power(sin(radians(latitude - $2) / 2), 2) +
+    cos(radians($2)) * cos(radians(latitude)) * power(sin(radians(longitude - $3) / 2), 2)
+))`, geo.EarthRadiusKm)
+
+	conditions, args := appendBoundingBoxConditions(
+		nil,
+		args,
+		geo.BoundingBoxAround(filter.Latitude, filter.Longitude, filter.RadiusKm),
+	)
+
+	if filter.PlaceTypeID != nil {
+		args = append(args, *filter.PlaceTypeID)
+		conditions = append(conditions, fmt.Sprintf("place_type_id = $%d", len(args)))
+	}
+
+	args = append(args, filter.RadiusKm, filter.Limit)
+	q := fmt.Sprintf(`
+select %s, distance
+from (
+    select %s, %s as distance
+    from %s
+    where %s
+) p
+where distance <= $%d
+order by distance
+limit $%d
+`, placeFields, placeTranslatedFields, distance, placeTranslatedSource, strings.Join(conditions, " and "), len(args)-1, len(args))
+
+	rows, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var places []model.PlaceWithDistance
+
+	for rows.Next() {
+		var (
+			dto        placeDTO
+			distanceKm float64
+		)
+
+		err := rows.Scan(append(dto.scanFields(), &distanceKm)...)
+		if err != nil {
+			return nil, err
+		}
+
+		places = append(places, model.PlaceWithDistance{
+			Place:      dto.ToModel(),
+			DistanceKm: distanceKm,
+		})
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return places, nil
+}
+
+func (r *PlaceRepo) Create(ctx context.Context, place model.Place) (*model.Place, error) {
+	return insertPlace(ctx, r.db, place)
+}
+
+func (r *PlaceRepo) Update(ctx context.Context, id int, place model.Place) error {
+	return updatePlace(ctx, r.db, id, place)
+}
+
+func (r *PlaceRepo) Delete(ctx context.Context, id int) error {
+	q := `delete from places where id=$1`
+
+	result, err := r.db.ExecContext(ctx, q, id)
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return localErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+func insertPlace(ctx context.Context, db queryRower, place model.Place) (*model.Place, error) {
+	q := `
+insert into places (place_type_id, city_id, name, address, latitude, longitude, description, founding_year, status)
+values ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+returning id
+`
+
+	var id int
+	err := db.QueryRowContext(ctx, q,
+		place.PlaceTypeID,
+		place.CityID,
+		place.Name,
+		place.Address,
+		place.Latitude,
+		place.Longitude,
+		place.Description,
+		place.FoundingYear,
+		place.Status,
+	).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+
+	place.ID = id
+
+	return &place, nil
+}
+
+func updatePlace(ctx context.Context, db execer, id int, place model.Place) error {
+	q := `
+update places
+set place_type_id = $1, city_id = $2, name = $3, address = $4, latitude = $5, longitude = $6,
+    description = $7, founding_year = $8, status = $9, updated_at = now()
+where id = $10
+`
+
+	result, err := db.ExecContext(ctx, q,
+		place.PlaceTypeID,
+		place.CityID,
+		place.Name,
+		place.Address,
+		place.Latitude,
+		place.Longitude,
+		place.Description,
+		place.FoundingYear,
+		place.Status,
+		id,
+	)
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return localErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// appendBoundingBoxConditions Добавить к запросу условия попадания координат в прямоугольник
+func appendBoundingBoxConditions(conditions []string, args []any, box geo.BoundingBox) ([]string, []any) {
+	args = append(args, box.MinLatitude, box.MaxLatitude)
+	conditions = append(conditions, fmt.Sprintf("latitude between $%d and $%d", len(args)-1, len(args)))
+
+	args = append(args, box.MinLongitude, box.MaxLongitude)
+	if box.CrossesAntimeridian() {
+		conditions = append(conditions, fmt.Sprintf("(longitude >= $%d or longitude <= $%d)", len(args)-1, len(args)))
+	} else {
+		conditions = append(conditions, fmt.Sprintf("longitude between $%d and $%d", len(args)-1, len(args)))
+	}
+
+	return conditions, args
+}
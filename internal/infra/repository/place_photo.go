@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"palback/internal/domain/model"
+	localErrors "palback/internal/pkg/errors"
+)
+
+type PlacePhotoRepo struct {
+	db *sql.DB
+}
+
+func NewPlacePhotoRepo(db *sql.DB) *PlacePhotoRepo {
+	return &PlacePhotoRepo{
+		db: db,
+	}
+}
+
+type placePhotoDTO struct {
+	ID          int           `json:"id"`
+	PlaceID     int           `json:"place_id"`
+	AuthorID    sql.NullInt64 `json:"author_id"`
+	ObjectKey   string        `json:"object_key"`
+	ContentType string        `json:"content_type"`
+	Size        int64         `json:"size"`
+	Caption     string        `json:"caption"`
+	License     string        `json:"license"`
+	Position    int           `json:"position"`
+	IsCover     bool          `json:"is_cover"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+func (dto *placePhotoDTO) ToModel() model.PlacePhoto {
+	photo := model.PlacePhoto{
+		ID:          dto.ID,
+		PlaceID:     dto.PlaceID,
+		ObjectKey:   dto.ObjectKey,
+		ContentType: dto.ContentType,
+		Size:        dto.Size,
+		Caption:     dto.Caption,
+		License:     dto.License,
+		Position:    dto.Position,
+		IsCover:     dto.IsCover,
+		CreatedAt:   dto.CreatedAt,
+	}
+
+	if dto.AuthorID.Valid {
+		authorID := int(dto.AuthorID.Int64)
+		photo.AuthorID = &authorID
+	}
+
+	return photo
+}
+
+func (dto *placePhotoDTO) scanFields() []any {
+	return []any{
+		&dto.ID,
+		&dto.PlaceID,
+		&dto.AuthorID,
+		&dto.ObjectKey,
+		&dto.ContentType,
+		&dto.Size,
+		&dto.Caption,
+		&dto.License,
+		&dto.Position,
+		&dto.IsCover,
+		&dto.CreatedAt,
+	}
+}
+
+const placePhotoFields = `
+id, place_id, author_id, object_key, content_type, size, caption, license, position, is_cover, created_at
+`
+
+func (r *PlacePhotoRepo) Get(ctx context.Context, id int) (*model.PlacePhoto, error) {
+	q := `select ` + placePhotoFields + ` from place_photos where id = $1`
+
+	var dto placePhotoDTO
+
+	err := r.db.QueryRowContext(ctx, q, id).Scan(dto.scanFields()...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, localErrors.ErrNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	photo := dto.ToModel()
+	return &photo, nil
+}
+
+// GetByPlace Получить фотографии святого места в порядке их показа
+func (r *PlacePhotoRepo) GetByPlace(ctx context.Context, placeID int) ([]model.PlacePhoto, error) {
+	q := `select ` + placePhotoFields + ` from place_photos where place_id = $1 order by position, id`
+
+	rows, err := r.db.QueryContext(ctx, q, placeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var photos []model.PlacePhoto
+
+	for rows.Next() {
+		var dto placePhotoDTO
+
+		err := rows.Scan(dto.scanFields()...)
+		if err != nil {
+			return nil, err
+		}
+
+		photos = append(photos, dto.ToModel())
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return photos, nil
+}
+
+// Create Добавить фотографию в конец галереи святого места
+func (r *PlacePhotoRepo) Create(ctx context.Context, photo model.PlacePhoto) (*model.PlacePhoto, error) {
+	q := `
+insert into place_photos (place_id, author_id, object_key, content_type, size, caption, license, position)
+values ($1, $2, $3, $4, $5, $6, $7, (select coalesce(max(position), 0) + 1 from place_photos where place_id = $1))
+returning id, position, created_at
+`
+
+	err := r.db.QueryRowContext(ctx, q,
+		photo.PlaceID,
+		photo.AuthorID,
+		photo.ObjectKey,
+		photo.ContentType,
+		photo.Size,
+		photo.Caption,
+		photo.License,
+	).Scan(&photo.ID, &photo.Position, &photo.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &photo, nil
+}
+
+// Update Изменить описание фотографии и ее место в галерее
+func (r *PlacePhotoRepo) Update(ctx context.Context, id int, photo model.PlacePhoto) error {
+	q := `update place_photos set caption = $1, license = $2, position = $3 where id = $4`
+
+	result, err := r.db.ExecContext(ctx, q, photo.Caption, photo.License, photo.Position, id)
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return localErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// SetCover Сделать фотографию обложкой святого места, сняв отметку с предыдущей обложки
+func (r *PlacePhotoRepo) SetCover(ctx context.Context, placeID, id int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `update place_photos set is_cover = false where place_id = $1 and is_cover`, placeID)
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx,
+		`update place_photos set is_cover = true where id = $1 and place_id = $2`,
+		id, placeID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return localErrors.ErrNotFound
+	}
+
+	return tx.Commit()
+}
+
+func (r *PlacePhotoRepo) Delete(ctx context.Context, id int) error {
+	result, err := r.db.ExecContext(ctx, `delete from place_photos where id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return localErrors.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,316 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"palback/internal/domain/model"
+	localErrors "palback/internal/pkg/errors"
+)
+
+type PlaceSubmissionRepo struct {
+	db *sql.DB
+}
+
+func NewPlaceSubmissionRepo(db *sql.DB) *PlaceSubmissionRepo {
+	return &PlaceSubmissionRepo{
+		db: db,
+	}
+}
+
+type placeSubmissionDTO struct {
+	ID           int           `json:"id"`
+	PlaceID      sql.NullInt64 `json:"place_id"`
+	AuthorID     sql.NullInt64 `json:"author_id"`
+	ModeratorID  sql.NullInt64 `json:"moderator_id"`
+	Place        placeDTO      `json:"place"`
+	State        string        `json:"state"`
+	RejectReason string        `json:"reject_reason"`
+	CreatedAt    time.Time     `json:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at"`
+}
+
+func (dto *placeSubmissionDTO) ToModel() model.PlaceSubmission {
+	submission := model.PlaceSubmission{
+		ID:           dto.ID,
+		Place:        dto.Place.ToModel(),
+		State:        model.SubmissionState(dto.State),
+		RejectReason: dto.RejectReason,
+		CreatedAt:    dto.CreatedAt,
+		UpdatedAt:    dto.UpdatedAt,
+	}
+
+	if dto.PlaceID.Valid {
+		placeID := int(dto.PlaceID.Int64)
+		submission.PlaceID = &placeID
+		submission.Place.ID = placeID
+	}
+
+	if dto.AuthorID.Valid {
+		authorID := int(dto.AuthorID.Int64)
+		submission.AuthorID = &authorID
+	}
+
+	if dto.ModeratorID.Valid {
+		moderatorID := int(dto.ModeratorID.Int64)
+		submission.ModeratorID = &moderatorID
+	}
+
+	return submission
+}
+
+func (dto *placeSubmissionDTO) scanFields() []any {
+	return []any{
+		&dto.ID,
+		&dto.PlaceID,
+		&dto.AuthorID,
+		&dto.ModeratorID,
+		&dto.Place.PlaceTypeID,
+		&dto.Place.CityID,
+		&dto.Place.Name,
+		&dto.Place.Address,
+		&dto.Place.Latitude,
+		&dto.Place.Longitude,
+		&dto.Place.Description,
+		&dto.Place.FoundingYear,
+		&dto.Place.Status,
+		&dto.State,
+		&dto.RejectReason,
+		&dto.CreatedAt,
+		&dto.UpdatedAt,
+	}
+}
+
+const placeSubmissionFields = `
+id, place_id, author_id, moderator_id,
+place_type_id, city_id, name, address, latitude, longitude, description, founding_year, status,
+state, reject_reason, created_at, updated_at
+`
+
+func (r *PlaceSubmissionRepo) Get(ctx context.Context, id int) (*model.PlaceSubmission, error) {
+	q := `select ` + placeSubmissionFields + ` from place_submissions where id = $1`
+
+	var dto placeSubmissionDTO
+
+	err := r.db.QueryRowContext(ctx, q, id).Scan(dto.scanFields()...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, localErrors.ErrNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	submission := dto.ToModel()
+	return &submission, nil
+}
+
+// GetByAuthor Получить предложения пользователя, начиная с последних
+func (r *PlaceSubmissionRepo) GetByAuthor(ctx context.Context, authorID int) ([]model.PlaceSubmission, error) {
+	q := `select ` + placeSubmissionFields + ` from place_submissions where author_id = $1 order by updated_at desc`
+
+	return r.getList(ctx, q, authorID)
+}
+
+// GetByState Получить предложения в заданном состоянии, начиная с самых старых
+func (r *PlaceSubmissionRepo) GetByState(
+	ctx context.Context,
+	state model.SubmissionState,
+) ([]model.PlaceSubmission, error) {
+	q := `select ` + placeSubmissionFields + ` from place_submissions where state = $1 order by updated_at`
+
+	return r.getList(ctx, q, state)
+}
+
+func (r *PlaceSubmissionRepo) Create(
+	ctx context.Context,
+	submission model.PlaceSubmission,
+) (*model.PlaceSubmission, error) {
+	q := `
+insert into place_submissions (
+    place_id, author_id, place_type_id, city_id, name, address, latitude, longitude,
+    description, founding_year, status, state
+)
+values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+returning id, created_at, updated_at
+`
+
+	err := r.db.QueryRowContext(ctx, q,
+		submission.PlaceID,
+		submission.AuthorID,
+		submission.Place.PlaceTypeID,
+		submission.Place.CityID,
+		submission.Place.Name,
+		submission.Place.Address,
+		submission.Place.Latitude,
+		submission.Place.Longitude,
+		submission.Place.Description,
+		submission.Place.FoundingYear,
+		submission.Place.Status,
+		submission.State,
+	).Scan(&submission.ID, &submission.CreatedAt, &submission.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &submission, nil
+}
+
+// Update Изменить предложение, только если оно по-прежнему в состоянии from. Иначе его успели одобрить,
+// отклонить или изменить, и возвращается ErrNotFound. Святое место, на которое ссылается предложение,
+// задается при создании и публикации и здесь не меняется.
+func (r *PlaceSubmissionRepo) Update(
+	ctx context.Context,
+	id int,
+	from model.SubmissionState,
+	submission model.PlaceSubmission,
+) error {
+	q := `
+update place_submissions
+set moderator_id = $1, place_type_id = $2, city_id = $3, name = $4, address = $5,
+    latitude = $6, longitude = $7, description = $8, founding_year = $9, status = $10,
+    state = $11, reject_reason = $12, updated_at = now()
+where id = $13 and state = $14
+`
+
+	result, err := r.db.ExecContext(ctx, q,
+		submission.ModeratorID,
+		submission.Place.PlaceTypeID,
+		submission.Place.CityID,
+		submission.Place.Name,
+		submission.Place.Address,
+		submission.Place.Latitude,
+		submission.Place.Longitude,
+		submission.Place.Description,
+		submission.Place.FoundingYear,
+		submission.Place.Status,
+		submission.State,
+		submission.RejectReason,
+		id,
+		from,
+	)
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return localErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// Publish Опубликовать предложение в одной транзакции: создать по нему новое святое место или обновить
+// существующее и перевести предложение в состояние published. Предложение публикуется, только если оно
+// по-прежнему в состоянии from, поэтому повторное или одновременное одобрение не создаст второе место.
+// Возвращает опубликованное предложение и святое место до правки, для нового места — nil.
+func (r *PlaceSubmissionRepo) Publish(
+	ctx context.Context,
+	id, moderatorID int,
+	from model.SubmissionState,
+) (*model.PlaceSubmission, *model.Place, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	q := `select ` + placeSubmissionFields + ` from place_submissions where id = $1 and state = $2 for update`
+
+	var dto placeSubmissionDTO
+
+	err = tx.QueryRowContext(ctx, q, id, from).Scan(dto.scanFields()...)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, nil, localErrors.ErrNotFound
+		default:
+			return nil, nil, err
+		}
+	}
+
+	submission := dto.ToModel()
+
+	var before *model.Place
+
+	if submission.PlaceID == nil {
+		created, err := insertPlace(ctx, tx, submission.Place)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		submission.PlaceID = &created.ID
+	} else {
+		var current placeDTO
+
+		q = `select ` + placeFields + ` from places where id = $1 for update`
+
+		err = tx.QueryRowContext(ctx, q, *submission.PlaceID).Scan(current.scanFields()...)
+		if err != nil {
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return nil, nil, localErrors.ErrNotFound
+			default:
+				return nil, nil, err
+			}
+		}
+
+		place := current.ToModel()
+		before = &place
+
+		if err = updatePlace(ctx, tx, *submission.PlaceID, submission.Place); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	submission.Place.ID = *submission.PlaceID
+	submission.State = model.SubmissionStatePublished
+	submission.ModeratorID = &moderatorID
+
+	q = `
+update place_submissions
+set place_id = $1, moderator_id = $2, state = $3, updated_at = now()
+where id = $4
+returning updated_at
+`
+
+	err = tx.QueryRowContext(ctx, q, submission.PlaceID, moderatorID, submission.State, id).Scan(&submission.UpdatedAt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+
+	return &submission, before, nil
+}
+
+func (r *PlaceSubmissionRepo) getList(ctx context.Context, q string, args ...any) ([]model.PlaceSubmission, error) {
+	rows, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var submissions []model.PlaceSubmission
+
+	for rows.Next() {
+		var dto placeSubmissionDTO
+
+		err := rows.Scan(dto.scanFields()...)
+		if err != nil {
+			return nil, err
+		}
+
+		submissions = append(submissions, dto.ToModel())
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return submissions, nil
+}
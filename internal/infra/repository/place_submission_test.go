@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"palback/internal/domain/model"
+	localErrors "palback/internal/pkg/errors"
+)
+
+func TestPlaceSubmissionRepo_Publish(t *testing.T) {
+	db := testDB(t)
+	repo := NewPlaceSubmissionRepo(db)
+	ctx := context.Background()
+
+	ids := seedPlaces(t, db, []testPlace{{name: "храм", placeTypeID: 1, latitude: 55.75, longitude: 37.61}})
+
+	var authorID int
+
+	err := db.QueryRow(
+		`insert into users (username, email, password) values ('author', 'author@example.com', '') returning id`,
+	).Scan(&authorID)
+	if err != nil {
+		t.Fatalf("ошибка добавления пользователя: %v", err)
+	}
+
+	var cityID int
+	if err = db.QueryRow(`select city_id from places where id = $1`, ids["храм"]).Scan(&cityID); err != nil {
+		t.Fatalf("ошибка получения города: %v", err)
+	}
+
+	create := func(placeID *int, name string) int {
+		submission, err := repo.Create(ctx, model.PlaceSubmission{
+			PlaceID:  placeID,
+			AuthorID: &authorID,
+			Place: model.Place{
+				PlaceTypeID: 1,
+				CityID:      cityID,
+				Name:        name,
+				Latitude:    55.76,
+				Longitude:   37.62,
+				Status:      model.PlaceStatusActive,
+			},
+			State: model.SubmissionStatePending,
+		})
+		if err != nil {
+			t.Fatalf("ошибка добавления предложения: %v", err)
+		}
+
+		return submission.ID
+	}
+
+	countPlaces := func() int {
+		var count int
+		if err := db.QueryRow(`select count(*) from places`).Scan(&count); err != nil {
+			t.Fatalf("ошибка подсчета мест: %v", err)
+		}
+
+		return count
+	}
+
+	t.Run("новое место создается один раз", func(t *testing.T) {
+		id := create(nil, "новая часовня")
+		before := countPlaces()
+
+		published, previous, err := repo.Publish(ctx, id, authorID, model.SubmissionStatePending)
+		if err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+
+		if previous != nil || published.PlaceID == nil || published.State != model.SubmissionStatePublished {
+			t.Fatalf("неверный результат публикации: %+v, до правки %+v", published, previous)
+		}
+
+		_, _, err = repo.Publish(ctx, id, authorID, model.SubmissionStatePending)
+		if !errors.Is(err, localErrors.ErrNotFound) {
+			t.Fatalf("повторная публикация: ожидалась ErrNotFound, получено %v", err)
+		}
+
+		if after := countPlaces(); after != before+1 {
+			t.Fatalf("мест стало %d, ожидалось %d", after, before+1)
+		}
+
+		stored, err := repo.Get(ctx, id)
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+
+		if stored.PlaceID == nil || *stored.PlaceID != *published.PlaceID || stored.State != model.SubmissionStatePublished {
+			t.Fatalf("предложение сохранено неверно: %+v", stored)
+		}
+	})
+
+	t.Run("правка обновляет место", func(t *testing.T) {
+		placeID := ids["храм"]
+		id := create(&placeID, "храм после правки")
+
+		_, previous, err := repo.Publish(ctx, id, authorID, model.SubmissionStatePending)
+		if err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+
+		if previous == nil || previous.Name != "храм" {
+			t.Fatalf("неверное место до правки: %+v", previous)
+		}
+
+		var name string
+		if err = db.QueryRow(`select name from places where id = $1`, placeID).Scan(&name); err != nil {
+			t.Fatalf("ошибка получения места: %v", err)
+		}
+
+		if name != "храм после правки" {
+			t.Fatalf("название места %q не обновлено", name)
+		}
+	})
+
+	t.Run("изменение устаревшего состояния не применяется", func(t *testing.T) {
+		id := create(nil, "часовня на холме")
+
+		published, _, err := repo.Publish(ctx, id, authorID, model.SubmissionStatePending)
+		if err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+
+		// Отклонение, прочитавшее предложение до одобрения
+		rejected := *published
+		rejected.PlaceID = nil
+		rejected.State = model.SubmissionStateRejected
+		rejected.RejectReason = "дубликат"
+
+		err = repo.Update(ctx, id, model.SubmissionStatePending, rejected)
+		if !errors.Is(err, localErrors.ErrNotFound) {
+			t.Fatalf("Update: ожидалась ErrNotFound, получено %v", err)
+		}
+
+		stored, err := repo.Get(ctx, id)
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+
+		if stored.State != model.SubmissionStatePublished || stored.PlaceID == nil || stored.RejectReason != "" {
+			t.Fatalf("опубликованное предложение изменено: %+v", stored)
+		}
+	})
+}
@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"slices"
+	"testing"
+
+	"palback/internal/domain/model"
+	"palback/internal/pkg/geo"
+)
+
+// testPlace Точка для тестовых святых мест
+type testPlace struct {
+	name        string
+	placeTypeID int
+	latitude    float64
+	longitude   float64
+}
+
+// seedPlaces Добавить страну, город и святые места, вернуть id мест по названиям
+func seedPlaces(t *testing.T, db *sql.DB, places []testPlace) map[string]int {
+	t.Helper()
+
+	var cityID int
+
+	_, err := db.Exec(`insert into countries (id, name) values ('ru', 'Россия')`)
+	if err == nil {
+		err = db.QueryRow(
+			`insert into cities (country_id, city_type_id, name, latitude, longitude)
+values ('ru', 1, 'Москва', 55.7558, 37.6173) returning id`,
+		).Scan(&cityID)
+	}
+
+	if err != nil {
+		t.Fatalf("ошибка добавления города: %v", err)
+	}
+
+	ids := make(map[string]int, len(places))
+
+	for _, place := range places {
+		var id int
+
+		err = db.QueryRow(
+			`insert into places (place_type_id, city_id, name, latitude, longitude) values ($1, $2, $3, $4, $5) returning id`,
+			place.placeTypeID, cityID, place.name, place.latitude, place.longitude,
+		).Scan(&id)
+		if err != nil {
+			t.Fatalf("ошибка добавления места %s: %v", place.name, err)
+		}
+
+		ids[place.name] = id
+	}
+
+	return ids
+}
+
+func placeNames(places []model.Place) []string {
+	names := make([]string, 0, len(places))
+	for _, place := range places {
+		names = append(names, place.Name)
+	}
+
+	return names
+}
+
+func TestPlaceRepo_GetNearby(t *testing.T) {
+	db := testDB(t)
+	repo := NewPlaceRepo(db)
+
+	// 0.045° по широте ≈ 5 км, 0.18° ≈ 20 км
+	seedPlaces(t, db, []testPlace{
+		{name: "центр", placeTypeID: 1, latitude: 55.7558, longitude: 37.6173},
+		{name: "север 5 км", placeTypeID: 4, latitude: 55.8008, longitude: 37.6173},
+		{name: "север 20 км", placeTypeID: 1, latitude: 55.9358, longitude: 37.6173},
+		{name: "восток 1°", placeTypeID: 1, latitude: 55.7558, longitude: 38.6173},
+		{name: "запад у 180°", placeTypeID: 1, latitude: 0, longitude: 179.95},
+		{name: "восток у 180°", placeTypeID: 1, latitude: 0, longitude: -179.95},
+	})
+
+	monastery := 4
+
+	tests := []struct {
+		name      string
+		filter    model.PlaceNearbyFilter
+		want      []string
+		distances []float64
+	}{
+		{
+			name:      "в радиусе по возрастанию расстояния",
+			filter:    model.PlaceNearbyFilter{Latitude: 55.7558, Longitude: 37.6173, RadiusKm: 10, Limit: 10},
+			want:      []string{"центр", "север 5 км"},
+			distances: []float64{0, 5.0},
+		},
+		{
+			name:      "больший радиус",
+			filter:    model.PlaceNearbyFilter{Latitude: 55.7558, Longitude: 37.6173, RadiusKm: 30, Limit: 10},
+			want:      []string{"центр", "север 5 км", "север 20 км"},
+			distances: []float64{0, 5.0, 20.0},
+		},
+		{
+			name:      "ограничение количества",
+			filter:    model.PlaceNearbyFilter{Latitude: 55.7558, Longitude: 37.6173, RadiusKm: 30, Limit: 1},
+			want:      []string{"центр"},
+			distances: []float64{0},
+		},
+		{
+			name:      "фильтр по типу",
+			filter:    model.PlaceNearbyFilter{Latitude: 55.7558, Longitude: 37.6173, RadiusKm: 30, PlaceTypeID: &monastery, Limit: 10},
+			want:      []string{"север 5 км"},
+			distances: []float64{5.0},
+		},
+		{
+			name:      "через 180-й меридиан",
+			filter:    model.PlaceNearbyFilter{Latitude: 0, Longitude: 179.99, RadiusKm: 20, Limit: 10},
+			want:      []string{"запад у 180°", "восток у 180°"},
+			distances: []float64{4.4, 6.7},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			places, err := repo.GetNearby(context.Background(), tt.filter)
+			if err != nil {
+				t.Fatalf("GetNearby: %v", err)
+			}
+
+			var names []string
+			for _, place := range places {
+				names = append(names, place.Name)
+			}
+
+			if !slices.Equal(names, tt.want) {
+				t.Fatalf("получены места %v, ожидались %v", names, tt.want)
+			}
+
+			for i, place := range places {
+				if math.Abs(place.DistanceKm-tt.distances[i]) > 0.1 {
+					t.Errorf("расстояние до %q = %.2f км, ожидалось %.1f км", place.Name, place.DistanceKm, tt.distances[i])
+				}
+			}
+		})
+	}
+}
+
+func TestPlaceRepo_GetListBoundingBox(t *testing.T) {
+	db := testDB(t)
+	repo := NewPlaceRepo(db)
+
+	seedPlaces(t, db, []testPlace{
+		{name: "а внутри", placeTypeID: 1, latitude: 55.75, longitude: 37.61},
+		{name: "б внутри", placeTypeID: 1, latitude: 55.80, longitude: 37.70},
+		{name: "в южнее", placeTypeID: 1, latitude: 55.50, longitude: 37.61},
+		{name: "г восточнее", placeTypeID: 1, latitude: 55.75, longitude: 38.00},
+		{name: "д у 180° с запада", placeTypeID: 1, latitude: 10, longitude: 179.5},
+		{name: "е у 180° с востока", placeTypeID: 1, latitude: 10, longitude: -179.5},
+		{name: "ж на нулевом меридиане", placeTypeID: 1, latitude: 10, longitude: 0},
+	})
+
+	tests := []struct {
+		name string
+		box  geo.BoundingBox
+		want []string
+	}{
+		{
+			name: "обычный прямоугольник",
+			box:  geo.BoundingBox{MinLatitude: 55.7, MinLongitude: 37.5, MaxLatitude: 55.9, MaxLongitude: 37.8},
+			want: []string{"а внутри", "б внутри"},
+		},
+		{
+			name: "границы включаются",
+			box:  geo.BoundingBox{MinLatitude: 55.75, MinLongitude: 37.61, MaxLatitude: 55.75, MaxLongitude: 38.00},
+			want: []string{"а внутри", "г восточнее"},
+		},
+		{
+			name: "через 180-й меридиан",
+			box:  geo.BoundingBox{MinLatitude: 0, MinLongitude: 179, MaxLatitude: 20, MaxLongitude: -179},
+			want: []string{"д у 180° с запада", "е у 180° с востока"},
+		},
+		{
+			name: "описанный вокруг окружности",
+			box:  geo.BoundingBoxAround(55.75, 37.61, 10),
+			want: []string{"а внутри", "б внутри"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			places, err := repo.GetList(context.Background(), model.PlaceFilter{BoundingBox: &tt.box, Limit: 10})
+			if err != nil {
+				t.Fatalf("GetList: %v", err)
+			}
+
+			if names := placeNames(places); !slices.Equal(names, tt.want) {
+				t.Fatalf("получены места %v, ожидались %v", names, tt.want)
+			}
+		})
+	}
+}
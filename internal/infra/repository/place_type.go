@@ -7,6 +7,7 @@ import (
 	
 	"palback/internal/domain/model"
 	localErrors "palback/internal/pkg/errors"
+	"palback/internal/pkg/locale"
 )
 
 type PlaceTypeRepo struct {
@@ -35,11 +36,16 @@ func (dto *placeTypeDTO) ToModel() model.PlaceType {
 
 // Get Получить информацию об одном населенном пункте
 func (r *PlaceTypeRepo) Get(ctx context.Context, id int) (*model.PlaceType, error) {
-	q := `select id, name, weight from place_types where id = $1`
+	q := `
+select pt.id, coalesce(t.name, pt.name), pt.weight
+from place_types pt
+left join place_type_translations t on t.place_type_id = pt.id and t.lang = $1
+where pt.id = $2
+`
 
 	var dto placeTypeDTO
 
-	err := r.db.QueryRowContext(ctx, q, id).Scan(&dto.ID, &dto.Name, &dto.Weight)
+	err := r.db.QueryRowContext(ctx, q, locale.Lang(ctx), id).Scan(&dto.ID, &dto.Name, &dto.Weight)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, localErrors.ErrNotFound
 	}
@@ -54,9 +60,14 @@ func (r *PlaceTypeRepo) Get(ctx context.Context, id int) (*model.PlaceType, erro
 
 // GetAll Получить информацию обо всех населенных пунктах
 func (r *PlaceTypeRepo) GetAll(ctx context.Context) ([]model.PlaceType, error) {
-	q := `select id, name, weight from place_types order by weight`
-
-	rows, err := r.db.QueryContext(ctx, q)
+	q := `
+select pt.id, coalesce(t.name, pt.name), pt.weight
+from place_types pt
+left join place_type_translations t on t.place_type_id = pt.id and t.lang = $1
+order by pt.weight
+`
+
+	rows, err := r.db.QueryContext(ctx, q, locale.Lang(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -8,6 +8,7 @@ import (
 
 	"palback/internal/domain/model"
 	localErrors "palback/internal/pkg/errors"
+	"palback/internal/pkg/locale"
 	"palback/internal/usecase"
 )
 
@@ -36,11 +37,16 @@ func (dto *regionDTO) ToModel() model.Region {
 }
 
 func (r *RegionRepo) Get(ctx context.Context, id int) (*model.Region, error) {
-	q := `select r.id, r.country_id, r.name from regions r where r.id = $1`
+	q := `
+select r.id, r.country_id, coalesce(t.name, r.name)
+from regions r
+left join region_translations t on t.region_id = r.id and t.lang = $1
+where r.id = $2
+`
 
 	var dto regionDTO
 
-	err := r.db.QueryRowContext(ctx, q, id).Scan(&dto.ID, &dto.CountryID, &dto.Name)
+	err := r.db.QueryRowContext(ctx, q, locale.Lang(ctx), id).Scan(&dto.ID, &dto.CountryID, &dto.Name)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, localErrors.ErrNotFound
 	}
@@ -55,9 +61,15 @@ func (r *RegionRepo) Get(ctx context.Context, id int) (*model.Region, error) {
 }
 
 func (r *RegionRepo) GetByCountry(ctx context.Context, countryId string) ([]model.Region, error) {
-	q := `select id, country_id, name from regions where country_id = $1 order by name`
-
-	rows, err := r.db.QueryContext(ctx, q, countryId)
+	q := `
+select r.id, r.country_id, coalesce(t.name, r.name) as name
+from regions r
+left join region_translations t on t.region_id = r.id and t.lang = $1
+where r.country_id = $2
+order by name
+`
+
+	rows, err := r.db.QueryContext(ctx, q, locale.Lang(ctx), countryId)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"palback/internal/domain/model"
+	localErrors "palback/internal/pkg/errors"
+)
+
+type RevisionRepo struct {
+	db *sql.DB
+}
+
+func NewRevisionRepo(db *sql.DB) *RevisionRepo {
+	return &RevisionRepo{
+		db: db,
+	}
+}
+
+type revisionDTO struct {
+	ID        int            `json:"id"`
+	Entity    string         `json:"entity"`
+	EntityID  string         `json:"entity_id"`
+	AuthorID  sql.NullInt64  `json:"author_id"`
+	Action    string         `json:"action"`
+	Before    sql.NullString `json:"before"`
+	After     sql.NullString `json:"after"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+func (dto *revisionDTO) ToModel() model.Revision {
+	revision := model.Revision{
+		ID:        dto.ID,
+		Entity:    model.RevisionEntity(dto.Entity),
+		EntityID:  dto.EntityID,
+		Action:    model.RevisionAction(dto.Action),
+		CreatedAt: dto.CreatedAt,
+	}
+
+	if dto.AuthorID.Valid {
+		authorID := int(dto.AuthorID.Int64)
+		revision.AuthorID = &authorID
+	}
+
+	if dto.Before.Valid {
+		revision.Before = []byte(dto.Before.String)
+	}
+
+	if dto.After.Valid {
+		revision.After = []byte(dto.After.String)
+	}
+
+	return revision
+}
+
+func (dto *revisionDTO) scanFields() []any {
+	return []any{
+		&dto.ID,
+		&dto.Entity,
+		&dto.EntityID,
+		&dto.AuthorID,
+		&dto.Action,
+		&dto.Before,
+		&dto.After,
+		&dto.CreatedAt,
+	}
+}
+
+const revisionFields = `id, entity, entity_id, author_id, action, before, after, created_at`
+
+func (r *RevisionRepo) Get(ctx context.Context, id int) (*model.Revision, error) {
+	q := `select ` + revisionFields + ` from revisions where id = $1`
+
+	var dto revisionDTO
+
+	err := r.db.QueryRowContext(ctx, q, id).Scan(dto.scanFields()...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, localErrors.ErrNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	revision := dto.ToModel()
+	return &revision, nil
+}
+
+// GetByEntity Получить историю изменений записи, начиная с последних
+func (r *RevisionRepo) GetByEntity(
+	ctx context.Context,
+	entity model.RevisionEntity,
+	entityID string,
+) ([]model.Revision, error) {
+	q := `select ` + revisionFields + ` from revisions where entity = $1 and entity_id = $2 order by id desc`
+
+	rows, err := r.db.QueryContext(ctx, q, entity, entityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var revisions []model.Revision
+
+	for rows.Next() {
+		var dto revisionDTO
+
+		err := rows.Scan(dto.scanFields()...)
+		if err != nil {
+			return nil, err
+		}
+
+		revisions = append(revisions, dto.ToModel())
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+func (r *RevisionRepo) Create(ctx context.Context, revision model.Revision) (*model.Revision, error) {
+	q := `
+insert into revisions (entity, entity_id, author_id, action, before, after)
+values ($1, $2, $3, $4, $5, $6)
+returning id, created_at
+`
+
+	err := r.db.QueryRowContext(ctx, q,
+		revision.Entity,
+		revision.EntityID,
+		revision.AuthorID,
+		revision.Action,
+		nullJSON(revision.Before),
+		nullJSON(revision.After),
+	).Scan(&revision.ID, &revision.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &revision, nil
+}
+
+// nullJSON Подготовить JSON для записи в поле jsonb, пустое значение записывается как null
+func nullJSON(data []byte) sql.NullString {
+	return sql.NullString{
+		String: string(data),
+		Valid:  len(data) > 0,
+	}
+}
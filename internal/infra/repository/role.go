@@ -2,44 +2,156 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"palback/internal/domain/model"
+	localErrors "palback/internal/pkg/errors"
+
+	"github.com/lib/pq"
 )
 
 type RoleRepo struct {
-	roles    []model.Role
-	rolesMap map[model.RoleID]*model.Role
+	db *sql.DB
 }
 
-func NewRoleRepo() *RoleRepo {
-	roles := []model.Role{
-		{
-			ID:   model.RoleAdmin,
-			Name: "администратор",
-		},
-		{
-			ID:   model.RoleUser,
-			Name: "пользователь",
-		},
+func NewRoleRepo(db *sql.DB) *RoleRepo {
+	return &RoleRepo{
+		db: db,
 	}
+}
 
-	rolesMap := make(map[model.RoleID]*model.Role)
-	rolesMap[model.RoleAdmin] = &roles[0]
-	rolesMap[model.RoleUser] = &roles[1]
+type roleDTO struct {
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	Permissions pq.StringArray `json:"permissions"`
+}
 
-	return &RoleRepo{
-		roles:    roles,
-		rolesMap: rolesMap,
+func (dto *roleDTO) ToModel() model.Role {
+	role := model.Role{
+		ID:          model.RoleID(dto.ID),
+		Name:        dto.Name,
+		Permissions: make([]model.Permission, 0, len(dto.Permissions)),
+	}
+
+	for _, permission := range dto.Permissions {
+		role.Permissions = append(role.Permissions, model.Permission(permission))
+	}
+
+	return role
+}
+
+func (dto *roleDTO) scanFields() []any {
+	return []any{
+		&dto.ID,
+		&dto.Name,
+		&dto.Permissions,
 	}
 }
 
-func (r *RoleRepo) Get(_ context.Context, id model.RoleID) (*model.Role, error) {
-	return r.rolesMap[id], nil
+// roleQuery Роли вместе с их правами, условие подставляется перед группировкой
+const roleQuery = `
+select r.id, r.name,
+	coalesce(array_agg(p.permission order by p.permission) filter (where p.permission is not null), '{}')
+from roles r
+left join role_permissions p on p.role_id = r.id
+%s
+group by r.id, r.name
+order by r.id
+`
+
+func (r *RoleRepo) Get(ctx context.Context, id model.RoleID) (*model.Role, error) {
+	roles, err := r.getList(ctx, fmt.Sprintf(roleQuery, `where r.id = $1`), id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(roles) == 0 {
+		return nil, localErrors.ErrNotFound
+	}
+
+	return &roles[0], nil
+}
+
+func (r *RoleRepo) GetAll(ctx context.Context) ([]model.Role, error) {
+	return r.getList(ctx, fmt.Sprintf(roleQuery, ``))
+}
+
+func (r *RoleRepo) GetAllMap(ctx context.Context) (map[model.RoleID]*model.Role, error) {
+	roles, err := r.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rolesMap := make(map[model.RoleID]*model.Role, len(roles))
+	for i := range roles {
+		rolesMap[roles[i].ID] = &roles[i]
+	}
+
+	return rolesMap, nil
 }
 
-func (r *RoleRepo) GetAll(_ context.Context) ([]model.Role, error) {
-	return r.roles, nil
+func (r *RoleRepo) getList(ctx context.Context, q string, args ...any) ([]model.Role, error) {
+	rows, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []model.Role
+
+	for rows.Next() {
+		var dto roleDTO
+
+		err := rows.Scan(dto.scanFields()...)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, dto.ToModel())
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
 }
 
-func (r *RoleRepo) GetAllMap(_ context.Context) (map[model.RoleID]*model.Role, error) {
-	return r.rolesMap, nil
+// UpdatePermissions Заменить права роли
+func (r *RoleRepo) UpdatePermissions(ctx context.Context, id model.RoleID, permissions []model.Permission) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Блокировка роли не дает параллельным изменениям прав перемешаться
+	var lockedID string
+
+	err = tx.QueryRowContext(ctx, `select id from roles where id = $1 for update`, id).Scan(&lockedID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return localErrors.ErrNotFound
+	}
+
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `delete from role_permissions where role_id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	for _, permission := range permissions {
+		_, err = tx.ExecContext(ctx,
+			`insert into role_permissions (role_id, permission) values ($1, $2)`,
+			id, permission,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
 }
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"palback/internal/domain/model"
+	localErrors "palback/internal/pkg/errors"
+	"palback/internal/usecase"
+)
+
+type TranslationRepo struct {
+	db *sql.DB
+}
+
+func NewTranslationRepo(db *sql.DB) *TranslationRepo {
+	return &TranslationRepo{
+		db: db,
+	}
+}
+
+// translationTable Таблица переводов записей справочника и ее колонка с id записи
+type translationTable struct {
+	name     string
+	idColumn string
+}
+
+var translationTables = map[model.TranslationEntity]translationTable{
+	model.TranslationEntityCountry:   {name: "country_translations", idColumn: "country_id"},
+	model.TranslationEntityRegion:    {name: "region_translations", idColumn: "region_id"},
+	model.TranslationEntityCityType:  {name: "city_type_translations", idColumn: "city_type_id"},
+	model.TranslationEntityCity:      {name: "city_translations", idColumn: "city_id"},
+	model.TranslationEntityPlaceType: {name: "place_type_translations", idColumn: "place_type_id"},
+	model.TranslationEntityPlace:     {name: "place_translations", idColumn: "place_id"},
+}
+
+func getTranslationTable(entity model.TranslationEntity) (translationTable, error) {
+	table, ok := translationTables[entity]
+	if !ok {
+		return table, usecase.ErrTranslationInvalidEntity
+	}
+
+	return table, nil
+}
+
+// shortNameField Выражение для сокращенного названия; оно есть только у типов населенных пунктов
+func shortNameField(entity model.TranslationEntity) string {
+	if entity.HasShortName() {
+		return "short_name"
+	}
+
+	return "''"
+}
+
+// GetByEntity Получить переводы записи справочника на все языки
+func (r *TranslationRepo) GetByEntity(
+	ctx context.Context,
+	entity model.TranslationEntity,
+	entityID string,
+) ([]model.Translation, error) {
+	table, err := getTranslationTable(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	q := fmt.Sprintf(`select lang, name, %s from %s where %s = $1 order by lang`,
+		shortNameField(entity), table.name, table.idColumn)
+
+	rows, err := r.db.QueryContext(ctx, q, entityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var translations []model.Translation
+
+	for rows.Next() {
+		translation := model.Translation{
+			Entity:   entity,
+			EntityID: entityID,
+		}
+
+		err := rows.Scan(&translation.Lang, &translation.Name, &translation.ShortName)
+		if err != nil {
+			return nil, err
+		}
+
+		translations = append(translations, translation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return translations, nil
+}
+
+// Save Добавить перевод или заменить существующий перевод на тот же язык
+func (r *TranslationRepo) Save(ctx context.Context, translation model.Translation) error {
+	table, err := getTranslationTable(translation.Entity)
+	if err != nil {
+		return err
+	}
+
+	var (
+		q    string
+		args = []any{translation.EntityID, translation.Lang, translation.Name}
+	)
+
+	if translation.Entity.HasShortName() {
+		q = fmt.Sprintf(`
+insert into %s (%s, lang, name, short_name) values ($1, $2, $3, $4)
+on conflict (%s, lang) do update set name = excluded.name, short_name = excluded.short_name
+`, table.name, table.idColumn, table.idColumn)
+		args = append(args, translation.ShortName)
+	} else {
+		q = fmt.Sprintf(`
+insert into %s (%s, lang, name) values ($1, $2, $3)
+on conflict (%s, lang) do update set name = excluded.name
+`, table.name, table.idColumn, table.idColumn)
+	}
+
+	_, err = r.db.ExecContext(ctx, q, args...)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "_translation_lang"):
+			return usecase.ErrTranslationLangNotSupported
+		case strings.Contains(err.Error(), "violates foreign key constraint"):
+			return usecase.ErrTranslationEntityNotFound
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *TranslationRepo) Delete(ctx context.Context, entity model.TranslationEntity, entityID, lang string) error {
+	table, err := getTranslationTable(entity)
+	if err != nil {
+		return err
+	}
+
+	q := fmt.Sprintf(`delete from %s where %s = $1 and lang = $2`, table.name, table.idColumn)
+
+	result, err := r.db.ExecContext(ctx, q, entityID, lang)
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return localErrors.ErrNotFound
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"palback/internal/domain/model"
 	localErrors "palback/internal/pkg/errors"
 	"palback/internal/usecase"
@@ -22,18 +23,29 @@ func NewUserRepo(db *sql.DB) *UserRepo {
 }
 
 type userDTO struct {
-	ID             int       `json:"id"`
-	RoleID         string    `json:"role_id"`
-	Username       string    `json:"username"`
-	Email          string    `json:"email"`
-	Password       string    `json:"password"`
-	CreatedAt      time.Time `json:"created_at"`
-	EmailVerified  bool      `json:"email_verified"`
-	SessionVersion int64     `json:"session_version"`
+	ID             int           `json:"id"`
+	RoleID         string        `json:"role_id"`
+	Username       string        `json:"username"`
+	Email          string        `json:"email"`
+	Password       string        `json:"password"`
+	CreatedAt      time.Time     `json:"created_at"`
+	EmailVerified  bool          `json:"email_verified"`
+	SessionVersion int64         `json:"session_version"`
+	DisplayName    string        `json:"display_name"`
+	About          string        `json:"about"`
+	CityID         sql.NullInt64 `json:"city_id"`
+	AvatarKey      string        `json:"avatar_key"`
+
+	DeletionRequestedAt sql.NullTime `json:"deletion_requested_at"`
+	TOTPSecret          string       `json:"totp_secret"`
+	FailedLoginCount    int          `json:"failed_login_count"`
+	LastFailedLoginAt   sql.NullTime `json:"last_failed_login_at"`
+	LockedUntil         sql.NullTime `json:"locked_until"`
+	BlockedAt           sql.NullTime `json:"blocked_at"`
 }
 
 func (dto *userDTO) ToModel() model.User {
-	return model.User{
+	user := model.User{
 		ID:             dto.ID,
 		RoleID:         model.RoleID(dto.RoleID),
 		Username:       dto.Username,
@@ -42,19 +54,43 @@ func (dto *userDTO) ToModel() model.User {
 		CreatedAt:      dto.CreatedAt,
 		EmailVerified:  dto.EmailVerified,
 		SessionVersion: dto.SessionVersion,
+		Profile: model.UserProfile{
+			DisplayName: dto.DisplayName,
+			About:       dto.About,
+		},
+		AvatarKey:  dto.AvatarKey,
+		TOTPSecret: dto.TOTPSecret,
+		LoginFailures: model.LoginFailures{
+			Count: dto.FailedLoginCount,
+		},
 	}
-}
 
-func (r *UserRepo) Get(ctx context.Context, id int) (*model.User, error) {
-	q := `
-select id, role_id, username, email, password, created_at, email_verified, session_version 
-from users 
-where id = $1
-`
+	if dto.CityID.Valid {
+		cityID := int(dto.CityID.Int64)
+		user.Profile.CityID = &cityID
+	}
 
-	var dto userDTO
+	if dto.DeletionRequestedAt.Valid {
+		user.DeletionRequestedAt = &dto.DeletionRequestedAt.Time
+	}
+
+	if dto.LastFailedLoginAt.Valid {
+		user.LoginFailures.LastFailedAt = &dto.LastFailedLoginAt.Time
+	}
 
-	err := r.db.QueryRowContext(ctx, q, id).Scan(
+	if dto.LockedUntil.Valid {
+		user.LoginFailures.LockedUntil = &dto.LockedUntil.Time
+	}
+
+	if dto.BlockedAt.Valid {
+		user.BlockedAt = &dto.BlockedAt.Time
+	}
+
+	return user
+}
+
+func (dto *userDTO) scanFields() []any {
+	return []any{
 		&dto.ID,
 		&dto.RoleID,
 		&dto.Username,
@@ -63,76 +99,117 @@ where id = $1
 		&dto.CreatedAt,
 		&dto.EmailVerified,
 		&dto.SessionVersion,
-	)
-	if errors.Is(err, sql.ErrNoRows) {
-		return nil, localErrors.ErrNotFound
+		&dto.DisplayName,
+		&dto.About,
+		&dto.CityID,
+		&dto.AvatarKey,
+		&dto.DeletionRequestedAt,
+		&dto.TOTPSecret,
+		&dto.FailedLoginCount,
+		&dto.LastFailedLoginAt,
+		&dto.LockedUntil,
+		&dto.BlockedAt,
 	}
-
-	user := dto.ToModel()
-
-	return &user, nil
 }
 
-func (r *UserRepo) GetByIdentifier(ctx context.Context, identifier string) (*model.User, error) {
-	q := `
-SELECT id, role_id, username, email, password, created_at, email_verified, session_version 
-FROM users 
-WHERE username = $1 OR email = $2
+const userFields = `
+id, role_id, username, email, password, created_at, email_verified, session_version,
+display_name, about, city_id, avatar_key, deletion_requested_at, totp_secret,
+failed_login_count, last_failed_login_at, locked_until, blocked_at
 `
 
+// getOne Получить одного пользователя по запросу
+func (r *UserRepo) getOne(ctx context.Context, q string, args ...any) (*model.User, error) {
 	var dto userDTO
 
-	err := r.db.QueryRowContext(ctx, q, identifier, identifier).Scan(
-		&dto.ID,
-		&dto.RoleID,
-		&dto.Username,
-		&dto.Email,
-		&dto.Password,
-		&dto.CreatedAt,
-		&dto.EmailVerified,
-		&dto.SessionVersion,
-	)
+	err := r.db.QueryRowContext(ctx, q, args...).Scan(dto.scanFields()...)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, localErrors.ErrNotFound
 	}
 
+	if err != nil {
+		return nil, err
+	}
+
 	user := dto.ToModel()
 
 	return &user, nil
 }
 
+func (r *UserRepo) Get(ctx context.Context, id int) (*model.User, error) {
+	q := `select ` + userFields + ` from users where id = $1`
+
+	return r.getOne(ctx, q, id)
+}
+
+func (r *UserRepo) GetByIdentifier(ctx context.Context, identifier string) (*model.User, error) {
+	q := `select ` + userFields + ` from users where username = $1 or email = $2`
+
+	return r.getOne(ctx, q, identifier, identifier)
+}
+
 func (r *UserRepo) GetByEmail(ctx context.Context, email string) (*model.User, error) {
-	q := `
-SELECT id, role_id, username, email, password, created_at, email_verified, session_version 
-FROM users 
-WHERE email = $1
-`
+	q := `select ` + userFields + ` from users where email = $1`
 
-	var dto userDTO
+	return r.getOne(ctx, q, email)
+}
 
-	err := r.db.QueryRowContext(ctx, q, email).Scan(
-		&dto.ID,
-		&dto.RoleID,
-		&dto.Username,
-		&dto.Email,
-		&dto.Password,
-		&dto.CreatedAt,
-		&dto.EmailVerified,
-		&dto.SessionVersion,
-	)
-	if errors.Is(err, sql.ErrNoRows) {
-		return nil, localErrors.ErrNotFound
+// GetList Получить страницу пользователей, удовлетворяющих фильтру, в порядке регистрации
+func (r *UserRepo) GetList(ctx context.Context, filter model.UserFilter) ([]model.User, error) {
+	conditions, args := userFilterConditions(filter)
+
+	q := `select ` + userFields + ` from users` + conditions
+
+	args = append(args, filter.Limit, filter.Offset)
+	q += fmt.Sprintf(` order by id limit $%d offset $%d`, len(args)-1, len(args))
+
+	return r.getList(ctx, q, args...)
+}
+
+// Count Получить количество пользователей, удовлетворяющих фильтру, без учета страницы
+func (r *UserRepo) Count(ctx context.Context, filter model.UserFilter) (int, error) {
+	conditions, args := userFilterConditions(filter)
+
+	var count int
+
+	err := r.db.QueryRowContext(ctx, `select count(*) from users`+conditions, args...).Scan(&count)
+	if err != nil {
+		return 0, err
 	}
 
-	user := dto.ToModel()
+	return count, nil
+}
 
-	return &user, nil
+// userFilterConditions Условие where для фильтра пользователей
+func userFilterConditions(filter model.UserFilter) (string, []any) {
+	if filter.Search == "" {
+		return "", nil
+	}
+
+	pattern := "%" + likeEscaper.Replace(filter.Search) + "%"
+
+	return ` where username ilike $1 or email ilike $1`, []any{pattern}
 }
 
-func (r *UserRepo) GetAll(ctx context.Context) ([]model.User, error) {
-	q := `select id, role_id, username, email, password, created_at, email_verified, session_version from users`
+// likeEscaper Экранирование спецсимволов шаблона like в пользовательском вводе
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// GetDeletionDue Получить пользователей, запросивших удаление аккаунта не позднее указанного времени
+func (r *UserRepo) GetDeletionDue(ctx context.Context, requestedBefore time.Time) ([]model.User, error) {
+	q := `select ` + userFields + ` from users where deletion_requested_at <= $1`
+
+	return r.getList(ctx, q, requestedBefore)
+}
 
-	rows, err := r.db.QueryContext(ctx, q)
+// GetWithLoginFailures Получить пользователей с неудачными попытками входа, начиная с последних
+func (r *UserRepo) GetWithLoginFailures(ctx context.Context) ([]model.User, error) {
+	q := `select ` + userFields + ` from users where failed_login_count > 0 order by last_failed_login_at desc`
+
+	return r.getList(ctx, q)
+}
+
+func (r *UserRepo) getList(ctx context.Context, q string, args ...any) ([]model.User, error) {
+	rows, err := r.db.QueryContext(ctx, q, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -143,16 +220,7 @@ func (r *UserRepo) GetAll(ctx context.Context) ([]model.User, error) {
 	for rows.Next() {
 		var dto userDTO
 
-		err := rows.Scan(
-			&dto.ID,
-			&dto.RoleID,
-			&dto.Username,
-			&dto.Email,
-			&dto.Password,
-			&dto.CreatedAt,
-			&dto.EmailVerified,
-			&dto.SessionVersion,
-		)
+		err := rows.Scan(dto.scanFields()...)
 		if err != nil {
 			return nil, err
 		}
@@ -168,6 +236,35 @@ func (r *UserRepo) GetAll(ctx context.Context) ([]model.User, error) {
 }
 
 func (r *UserRepo) Create(ctx context.Context, user model.User) (*model.User, error) {
+	return insertUser(ctx, r.db, user)
+}
+
+// CreateWithEmail Добавить пользователя и поставить письмо в очередь на отправку в одной транзакции:
+// письмо не уходит, если пользователя добавить не удалось, и не теряется, если пользователь добавлен
+func (r *UserRepo) CreateWithEmail(ctx context.Context, user model.User, message model.EmailMessage) (*model.User, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	created, err := insertUser(ctx, tx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = insertEmailMessage(ctx, tx, message); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func insertUser(ctx context.Context, db queryRower, user model.User) (*model.User, error) {
 	q := `INSERT INTO users (role_id, username, email, password, email_verified) 
          VALUES ($1, $2, $3, $4, $5)
          RETURNING id, created_at`
@@ -177,7 +274,7 @@ func (r *UserRepo) Create(ctx context.Context, user model.User) (*model.User, er
 		createdAt time.Time
 	)
 
-	err := r.db.QueryRowContext(ctx, q,
+	err := db.QueryRowContext(ctx, q,
 		user.RoleID,
 		user.Username,
 		user.Email,
@@ -222,7 +319,7 @@ func (r *UserRepo) UpdateEmailVerified(ctx context.Context, email string) error
 }
 
 func (r *UserRepo) UpdatePassword(ctx context.Context, email, hashedPassword string) error {
-	q := `update users set password = $1, session_version = session_version + 1 where email = $2`
+	q := `update users set password = $1 where email = $2`
 
 	result, err := r.db.ExecContext(ctx, q, hashedPassword, email)
 	if err != nil {
@@ -236,6 +333,38 @@ func (r *UserRepo) UpdatePassword(ctx context.Context, email, hashedPassword str
 	return nil
 }
 
+// UpdateRole Изменить роль пользователя
+func (r *UserRepo) UpdateRole(ctx context.Context, id int, roleID model.RoleID) error {
+	q := `update users set role_id = $1 where id = $2`
+
+	result, err := r.db.ExecContext(ctx, q, roleID, id)
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return localErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// UpdateBlocked Заблокировать пользователя с указанного времени или снять блокировку, передав nil
+func (r *UserRepo) UpdateBlocked(ctx context.Context, id int, blockedAt *time.Time) error {
+	q := `update users set blocked_at = $1 where id = $2`
+
+	result, err := r.db.ExecContext(ctx, q, blockedAt, id)
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return localErrors.ErrNotFound
+	}
+
+	return nil
+}
+
 func (r *UserRepo) IncrementSessionVersion(ctx context.Context, email string) error {
 	q := `update users set session_version = session_version + 1 where email = $1`
 
@@ -251,8 +380,118 @@ func (r *UserRepo) IncrementSessionVersion(ctx context.Context, email string) er
 	return nil
 }
 
-func (r *UserRepo) Delete(ctx context.Context, id int) error {
-	q := `delete from users where id=$1`
+// UpdateEmail Изменить e-mail пользователя. Новый адрес считается подтвержденным.
+func (r *UserRepo) UpdateEmail(ctx context.Context, id int, email string) error {
+	q := `update users set email = $1, email_verified = true where id = $2`
+
+	result, err := r.db.ExecContext(ctx, q, email, id)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "users_email_key"):
+			return usecase.ErrUserEmailNotUnique
+		default:
+			return err
+		}
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return localErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// UpdateProfile Изменить данные профиля пользователя
+func (r *UserRepo) UpdateProfile(ctx context.Context, id int, profile model.UserProfile) error {
+	q := `update users set display_name = $1, about = $2, city_id = $3 where id = $4`
+
+	result, err := r.db.ExecContext(ctx, q, profile.DisplayName, profile.About, profile.CityID, id)
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return localErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// UpdateAvatar Изменить ключ аватара в хранилище, пустой ключ означает отсутствие аватара
+func (r *UserRepo) UpdateAvatar(ctx context.Context, id int, avatarKey string) error {
+	q := `update users set avatar_key = $1 where id = $2`
+
+	result, err := r.db.ExecContext(ctx, q, avatarKey, id)
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return localErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// UpdateDeletionRequested Запросить удаление аккаунта в указанное время или отменить запрос, передав nil
+func (r *UserRepo) UpdateDeletionRequested(ctx context.Context, id int, requestedAt *time.Time) error {
+	q := `update users set deletion_requested_at = $1 where id = $2`
+
+	result, err := r.db.ExecContext(ctx, q, requestedAt, id)
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return localErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// IncrementLoginFailures Учесть неудачную попытку входа. Возвращает число неудачных попыток подряд.
+// После окончания блокировки счет начинается заново, иначе первая же ошибка снова заблокировала бы вход.
+func (r *UserRepo) IncrementLoginFailures(ctx context.Context, id int) (int, error) {
+	q := `update users set
+			failed_login_count = case when locked_until <= now() then 1 else failed_login_count + 1 end,
+			locked_until = case when locked_until <= now() then null else locked_until end,
+			last_failed_login_at = now()
+		where id = $1
+		returning failed_login_count`
+
+	var count int
+
+	err := r.db.QueryRowContext(ctx, q, id).Scan(&count)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, localErrors.ErrNotFound
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// UpdateLockedUntil Заблокировать вход до указанного времени
+func (r *UserRepo) UpdateLockedUntil(ctx context.Context, id int, lockedUntil time.Time) error {
+	q := `update users set locked_until = $1 where id = $2`
+
+	result, err := r.db.ExecContext(ctx, q, lockedUntil, id)
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return localErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// ResetLoginFailures Сбросить счетчик неудачных попыток входа и снять блокировку
+func (r *UserRepo) ResetLoginFailures(ctx context.Context, id int) error {
+	q := `update users set failed_login_count = 0, last_failed_login_at = null, locked_until = null where id = $1`
 
 	result, err := r.db.ExecContext(ctx, q, id)
 	if err != nil {
@@ -265,3 +504,123 @@ func (r *UserRepo) Delete(ctx context.Context, id int) error {
 
 	return nil
 }
+
+// UpdateTwoFactor Включить двухфакторную аутентификацию с новыми резервными кодами
+// или выключить ее, передав пустой секрет. Прежние резервные коды удаляются.
+func (r *UserRepo) UpdateTwoFactor(ctx context.Context, id int, secret string, recoveryCodeHashes []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `update users set totp_secret = $1 where id = $2`, secret, id)
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return localErrors.ErrNotFound
+	}
+
+	if err = replaceRecoveryCodes(ctx, tx, id, recoveryCodeHashes); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UpdateRecoveryCodes Заменить резервные коды пользователя новыми
+func (r *UserRepo) UpdateRecoveryCodes(ctx context.Context, id int, recoveryCodeHashes []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err = replaceRecoveryCodes(ctx, tx, id, recoveryCodeHashes); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UseRecoveryCode Отметить резервный код использованным. Если кода нет или он уже использован, возвращается ErrNotFound.
+func (r *UserRepo) UseRecoveryCode(ctx context.Context, id int, codeHash string) error {
+	q := `update user_recovery_codes set used_at = now() where user_id = $1 and code_hash = $2 and used_at is null`
+
+	result, err := r.db.ExecContext(ctx, q, id, codeHash)
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return localErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+func replaceRecoveryCodes(ctx context.Context, tx *sql.Tx, userID int, hashes []string) error {
+	_, err := tx.ExecContext(ctx, `delete from user_recovery_codes where user_id = $1`, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, hash := range hashes {
+		_, err = tx.ExecContext(ctx,
+			`insert into user_recovery_codes (user_id, code_hash) values ($1, $2)`,
+			userID, hash,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *UserRepo) Delete(ctx context.Context, id int) error {
+	return r.delete(ctx, `delete from users where id=$1 returning email`, id)
+}
+
+// DeleteDeletionDue Удалить пользователя, только если он по-прежнему ждет удаления и запросил его
+// не позднее requestedBefore. Если удаление успели отменить входом, возвращается ErrNotFound.
+func (r *UserRepo) DeleteDeletionDue(ctx context.Context, id int, requestedBefore time.Time) error {
+	q := `
+delete from users
+where id=$1 and deletion_requested_at is not null and deletion_requested_at <= $2
+returning email
+`
+
+	return r.delete(ctx, q, id, requestedBefore)
+}
+
+// delete Удалить пользователя запросом q, возвращающим e-mail, вместе с письмами на этот адрес из очереди
+func (r *UserRepo) delete(ctx context.Context, q string, args ...any) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var email string
+
+	err = tx.QueryRowContext(ctx, q, args...).Scan(&email)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return localErrors.ErrNotFound
+		default:
+			return err
+		}
+	}
+
+	// В очереди писем остался бы адрес удаленного пользователя
+	_, err = tx.ExecContext(ctx, `delete from email_outbox where to_email = $1`, email)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"palback/internal/domain/model"
+	localErrors "palback/internal/pkg/errors"
+)
+
+func TestUserRepo_DeleteDeletionDue(t *testing.T) {
+	db := testDB(t)
+	repo := NewUserRepo(db)
+	outbox := NewEmailOutboxRepo(db)
+	ctx := context.Background()
+
+	var userID int
+
+	err := db.QueryRow(
+		`insert into users (username, email, password) values ('pilgrim', 'pilgrim@example.com', '') returning id`,
+	).Scan(&userID)
+	if err != nil {
+		t.Fatalf("ошибка добавления пользователя: %v", err)
+	}
+
+	err = outbox.Create(ctx, model.EmailMessage{Kind: model.EmailKindVerification, ToEmail: "pilgrim@example.com"})
+	if err != nil {
+		t.Fatalf("ошибка добавления письма: %v", err)
+	}
+
+	requestedAt := time.Now().Add(-time.Hour)
+	requestedBefore := time.Now()
+
+	t.Run("отмененное удаление", func(t *testing.T) {
+		err := repo.DeleteDeletionDue(ctx, userID, requestedBefore)
+		if !errors.Is(err, localErrors.ErrNotFound) {
+			t.Fatalf("ожидалась ErrNotFound, получено %v", err)
+		}
+
+		if _, err = repo.Get(ctx, userID); err != nil {
+			t.Fatalf("пользователь удален: %v", err)
+		}
+	})
+
+	t.Run("срок еще не истек", func(t *testing.T) {
+		if err := repo.UpdateDeletionRequested(ctx, userID, &requestedAt); err != nil {
+			t.Fatalf("UpdateDeletionRequested: %v", err)
+		}
+
+		err := repo.DeleteDeletionDue(ctx, userID, requestedAt.Add(-time.Minute))
+		if !errors.Is(err, localErrors.ErrNotFound) {
+			t.Fatalf("ожидалась ErrNotFound, получено %v", err)
+		}
+	})
+
+	t.Run("удаление вместе с письмами", func(t *testing.T) {
+		if err := repo.DeleteDeletionDue(ctx, userID, requestedBefore); err != nil {
+			t.Fatalf("DeleteDeletionDue: %v", err)
+		}
+
+		if _, err := repo.Get(ctx, userID); !errors.Is(err, localErrors.ErrNotFound) {
+			t.Fatalf("пользователь не удален: %v", err)
+		}
+
+		var count int
+		if err := db.QueryRow(`select count(*) from email_outbox where to_email = 'pilgrim@example.com'`).Scan(&count); err != nil {
+			t.Fatalf("ошибка подсчета писем: %v", err)
+		}
+
+		if count != 0 {
+			t.Fatalf("в очереди осталось %d писем на адрес удаленного пользователя", count)
+		}
+	})
+}
+
+func TestUserRepo_IncrementLoginFailures(t *testing.T) {
+	db := testDB(t)
+	repo := NewUserRepo(db)
+	ctx := context.Background()
+
+	var userID int
+
+	err := db.QueryRow(
+		`insert into users (username, email, password) values ('pilgrim', 'pilgrim@example.com', '') returning id`,
+	).Scan(&userID)
+	if err != nil {
+		t.Fatalf("ошибка добавления пользователя: %v", err)
+	}
+
+	for want := 1; want <= 3; want++ {
+		count, err := repo.IncrementLoginFailures(ctx, userID)
+		if err != nil {
+			t.Fatalf("IncrementLoginFailures: %v", err)
+		}
+
+		if count != want {
+			t.Fatalf("неудачных попыток %d, ожидалось %d", count, want)
+		}
+	}
+
+	t.Run("во время блокировки счет продолжается", func(t *testing.T) {
+		if err := repo.UpdateLockedUntil(ctx, userID, time.Now().Add(time.Hour)); err != nil {
+			t.Fatalf("UpdateLockedUntil: %v", err)
+		}
+
+		count, err := repo.IncrementLoginFailures(ctx, userID)
+		if err != nil {
+			t.Fatalf("IncrementLoginFailures: %v", err)
+		}
+
+		if count != 4 {
+			t.Fatalf("неудачных попыток %d, ожидалось 4", count)
+		}
+	})
+
+	t.Run("после блокировки счет начинается заново", func(t *testing.T) {
+		if err := repo.UpdateLockedUntil(ctx, userID, time.Now().Add(-time.Minute)); err != nil {
+			t.Fatalf("UpdateLockedUntil: %v", err)
+		}
+
+		count, err := repo.IncrementLoginFailures(ctx, userID)
+		if err != nil {
+			t.Fatalf("IncrementLoginFailures: %v", err)
+		}
+
+		if count != 1 {
+			t.Fatalf("неудачных попыток %d, ожидалась 1", count)
+		}
+
+		user, err := repo.Get(ctx, userID)
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+
+		if user.LoginFailures.LockedUntil != nil {
+			t.Fatalf("истекшая блокировка не снята: %v", user.LoginFailures.LockedUntil)
+		}
+	})
+}
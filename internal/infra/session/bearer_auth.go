@@ -0,0 +1,91 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"palback/internal/domain/model"
+	localErrors "palback/internal/pkg/errors"
+	tokens "palback/internal/pkg/token"
+	"palback/internal/usecase"
+)
+
+type AccessTokenGetterRepo interface {
+	GetByHash(ctx context.Context, tokenHash string) (*model.AccessToken, error)
+	UpdateLastUsed(ctx context.Context, id int) error
+}
+
+// BearerAuthenticator Аутентификация по персональному токену доступа в заголовке Authorization: Bearer
+type BearerAuthenticator struct {
+	tokens AccessTokenGetterRepo
+	users  UserGetterRepo
+}
+
+func NewBearerAuthenticator(tokens AccessTokenGetterRepo, users UserGetterRepo) *BearerAuthenticator {
+	return &BearerAuthenticator{
+		tokens: tokens,
+		users:  users,
+	}
+}
+
+func (a *BearerAuthenticator) GetUserID(ctx context.Context, r *http.Request) (int, error) {
+	value, ok := bearerToken(r)
+	if !ok {
+		return 0, usecase.ErrUnauthenticated
+	}
+
+	token, err := a.tokens.GetByHash(ctx, tokens.HashAccessToken(value))
+	if err != nil {
+		switch {
+		case errors.Is(err, localErrors.ErrNotFound):
+			return 0, usecase.ErrUnauthenticated
+		default:
+			return 0, err
+		}
+	}
+
+	if token.IsExpired(time.Now()) {
+		return 0, usecase.ErrSessionExpired
+	}
+
+	if !token.Allows(r.Method) {
+		return 0, usecase.ErrAccessTokenScopeDenied
+	}
+
+	user, err := a.users.Get(ctx, token.UserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, localErrors.ErrNotFound):
+			return 0, usecase.ErrUnauthenticated
+		default:
+			return 0, err
+		}
+	}
+
+	if user.IsBlocked() {
+		return 0, usecase.ErrUserBlocked
+	}
+
+	// Ошибка учета использования не должна мешать аутентификации
+	if err = a.tokens.UpdateLastUsed(ctx, token.ID); err != nil {
+		log.Printf("Ошибка обновления времени использования токена доступа: %v", err)
+	}
+
+	return token.UserID, nil
+}
+
+// bearerToken Получить токен из заголовка Authorization
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, value, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+
+	value = strings.TrimSpace(value)
+
+	return value, value != ""
+}
@@ -2,23 +2,32 @@ package session
 
 import (
 	"context"
+	"log"
 	"net/http"
 	"palback/internal/config"
 	"palback/internal/domain/model"
+	"palback/internal/pkg/actor"
 	"palback/internal/usecase"
+	"time"
 
 	"github.com/boj/redistore"
 	"github.com/gomodule/redigo/redis"
 	"github.com/gorilla/sessions"
 )
 
+// sessionName Имя cookie сессии
+const sessionName = "session"
+
 type UserGetterRepo interface {
 	Get(ctx context.Context, id int) (*model.User, error)
+	IncrementSessionVersion(ctx context.Context, email string) error
 }
 
 type RedigoAuthenticator struct {
-	store sessions.Store
-	repo  UserGetterRepo
+	store     *redistore.RediStore
+	redisPool *redis.Pool
+	maxAge    int
+	repo      UserGetterRepo
 }
 
 func NewRedigoAuthenticator(
@@ -31,33 +40,49 @@ func NewRedigoAuthenticator(
 		return nil, err
 	}
 
+	maxAge := 86400 * cfg.SessionDays
+
 	// Настройка cookie
 	store.Options = &sessions.Options{
 		Path:     "/",
-		MaxAge:   86400 * cfg.SessionDays, // 24 часа
+		MaxAge:   maxAge, // 24 часа
 		HttpOnly: true,
 		Secure:   cfg.IsProduction,
 		SameSite: http.SameSiteLaxMode,
 	}
 
 	return &RedigoAuthenticator{
-		store: store,
-		repo:  repo,
+		store:     store,
+		redisPool: redisPool,
+		maxAge:    maxAge,
+		repo:      repo,
 	}, nil
 }
 
-func (a *RedigoAuthenticator) Login(_ context.Context, w http.ResponseWriter, r *http.Request, user *model.User) error {
-	session, err := a.store.Get(r, "session")
+func (a *RedigoAuthenticator) Login(ctx context.Context, w http.ResponseWriter, r *http.Request, user *model.User) error {
+	session, err := a.store.Get(r, sessionName)
 	if err != nil {
 		return err
 	}
 	session.Values["user_id"] = user.ID
 	session.Values["session_version"] = user.SessionVersion
-	return session.Save(r, w)
+	if err = session.Save(r, w); err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	return a.saveSessionInfo(ctx, user.ID, session.ID, sessionInfo{
+		UserAgent:      r.UserAgent(),
+		IP:             actor.ClientInfo(ctx).IP,
+		SessionVersion: user.SessionVersion,
+		CreatedAt:      now,
+		LastSeenAt:     now,
+	})
 }
 
 func (a *RedigoAuthenticator) GetUserID(ctx context.Context, r *http.Request) (int, error) {
-	session, err := a.store.Get(r, "session")
+	session, err := a.store.Get(r, sessionName)
 
 	if err != nil {
 		return 0, err
@@ -79,17 +104,42 @@ func (a *RedigoAuthenticator) GetUserID(ctx context.Context, r *http.Request) (i
 		return 0, usecase.ErrSessionExpired
 	}
 
+	if userInfo.IsBlocked() {
+		return 0, usecase.ErrUserBlocked
+	}
+
+	// Ошибка учета активности не должна мешать аутентификации
+	if err = a.touchSession(ctx, r, userID, session.ID, userInfo.SessionVersion); err != nil {
+		log.Printf("Ошибка обновления сведений о сессии: %v", err)
+	}
+
 	return userID, nil
 }
 
-func (a *RedigoAuthenticator) Logout(_ context.Context, w http.ResponseWriter, r *http.Request) error {
-	session, err := a.store.Get(r, "session")
+func (a *RedigoAuthenticator) Logout(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	session, err := a.store.Get(r, sessionName)
 	if err != nil {
 		return err
 	}
 
+	userID, _ := session.Values["user_id"].(int)
+	sessionID := session.ID
+
 	// Удаляем cookie
 	session.Options.MaxAge = -1
 	session.Values = make(map[any]any)
-	return session.Save(r, w)
+	if err = session.Save(r, w); err != nil {
+		return err
+	}
+
+	if userID <= 0 || sessionID == "" {
+		return nil
+	}
+
+	conn := a.redisPool.Get()
+	defer conn.Close()
+
+	_, err = redis.DoContext(conn, ctx, "HDEL", userSessionsKey(userID), sessionID)
+
+	return err
 }
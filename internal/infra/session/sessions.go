@@ -0,0 +1,279 @@
+package session
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+
+	"palback/internal/domain/model"
+	"palback/internal/pkg/actor"
+	"palback/internal/usecase"
+)
+
+const (
+	// sessionKeyPrefix Префикс ключей redistore, под которыми хранятся данные сессий
+	sessionKeyPrefix = "session_"
+
+	// lastSeenInterval Как часто обновлять время последней активности, чтобы не писать в Redis на каждый запрос
+	lastSeenInterval = time.Minute
+)
+
+// sessionInfo Сведения о сессии, которые хранятся в хэше user_sessions:<id пользователя>
+// с ключом, равным id сессии redistore
+type sessionInfo struct {
+	UserAgent      string    `json:"user_agent"`
+	IP             string    `json:"ip"`
+	SessionVersion int64     `json:"session_version"`
+	CreatedAt      time.Time `json:"created_at"`
+	LastSeenAt     time.Time `json:"last_seen_at"`
+}
+
+func userSessionsKey(userID int) string {
+	return fmt.Sprintf("user_sessions:%d", userID)
+}
+
+// publicSessionID Идентификатор сессии для API. Настоящий id сессии наружу не отдается.
+func publicSessionID(sessionID string) string {
+	sum := sha256.Sum256([]byte(sessionID))
+	return hex.EncodeToString(sum[:8])
+}
+
+// saveSessionInfo Записать сведения о сессии. Хэш живет не дольше самой долгой сессии пользователя.
+func (a *RedigoAuthenticator) saveSessionInfo(ctx context.Context, userID int, sessionID string, info sessionInfo) error {
+	data, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+
+	conn := a.redisPool.Get()
+	defer conn.Close()
+
+	key := userSessionsKey(userID)
+
+	if _, err = redis.DoContext(conn, ctx, "HSET", key, sessionID, data); err != nil {
+		return err
+	}
+
+	_, err = redis.DoContext(conn, ctx, "EXPIRE", key, a.maxAge)
+
+	return err
+}
+
+func (a *RedigoAuthenticator) getSessionInfo(ctx context.Context, userID int, sessionID string) (*sessionInfo, error) {
+	conn := a.redisPool.Get()
+	defer conn.Close()
+
+	data, err := redis.Bytes(redis.DoContext(conn, ctx, "HGET", userSessionsKey(userID), sessionID))
+	if errors.Is(err, redis.ErrNil) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var info sessionInfo
+	if err = json.Unmarshal(data, &info); err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+// touchSession Обновить время последней активности сессии. Сессии, созданные до появления
+// учета, регистрируются при первом обращении.
+func (a *RedigoAuthenticator) touchSession(
+	ctx context.Context,
+	r *http.Request,
+	userID int,
+	sessionID string,
+	sessionVersion int64,
+) error {
+	info, err := a.getSessionInfo(ctx, userID, sessionID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	if info == nil {
+		info = &sessionInfo{
+			SessionVersion: sessionVersion,
+			CreatedAt:      now,
+		}
+	} else if now.Sub(info.LastSeenAt) < lastSeenInterval {
+		return nil
+	}
+
+	info.UserAgent = r.UserAgent()
+	info.IP = actor.ClientInfo(ctx).IP
+	info.LastSeenAt = now
+
+	return a.saveSessionInfo(ctx, userID, sessionID, *info)
+}
+
+func (a *RedigoAuthenticator) ListSessions(ctx context.Context, r *http.Request, userID int) ([]model.Session, error) {
+	user, err := a.repo.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	currentID := a.currentSessionID(r)
+
+	conn := a.redisPool.Get()
+	defer conn.Close()
+
+	items, err := redis.StringMap(redis.DoContext(conn, ctx, "HGETALL", userSessionsKey(userID)))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.Session, 0, len(items))
+
+	for sessionID, data := range items {
+		var info sessionInfo
+		if err = json.Unmarshal([]byte(data), &info); err != nil {
+			return nil, err
+		}
+
+		// Сессия истекла или устарела после смены пароля, сведения о ней больше не нужны
+		exists, err := redis.Bool(redis.DoContext(conn, ctx, "EXISTS", sessionKeyPrefix+sessionID))
+		if err != nil {
+			return nil, err
+		}
+
+		if !exists || info.SessionVersion != user.SessionVersion {
+			if err = a.deleteSession(ctx, conn, userID, sessionID); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		result = append(result, model.Session{
+			ID:         publicSessionID(sessionID),
+			UserAgent:  info.UserAgent,
+			IP:         info.IP,
+			CreatedAt:  info.CreatedAt,
+			LastSeenAt: info.LastSeenAt,
+			Current:    sessionID == currentID,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].LastSeenAt.After(result[j].LastSeenAt)
+	})
+
+	return result, nil
+}
+
+func (a *RedigoAuthenticator) RevokeSession(ctx context.Context, userID int, id string) error {
+	conn := a.redisPool.Get()
+	defer conn.Close()
+
+	sessionIDs, err := redis.Strings(redis.DoContext(conn, ctx, "HKEYS", userSessionsKey(userID)))
+	if err != nil {
+		return err
+	}
+
+	for _, sessionID := range sessionIDs {
+		if publicSessionID(sessionID) == id {
+			return a.deleteSession(ctx, conn, userID, sessionID)
+		}
+	}
+
+	return usecase.ErrSessionNotFound
+}
+
+// RevokeOtherSessions Завершить остальные сессии, увеличив версию сессий пользователя: после этого
+// GetUserID отклоняет все сессии со старой версией, в том числе не попавшие в список сессий.
+// Текущая сессия сохраняется с новой версией.
+func (a *RedigoAuthenticator) RevokeOtherSessions(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	userID int,
+) error {
+	session, err := a.store.Get(r, sessionName)
+	if err != nil {
+		return err
+	}
+
+	user, err := a.repo.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err = a.repo.IncrementSessionVersion(ctx, user.Email); err != nil {
+		return err
+	}
+
+	user, err = a.repo.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !session.IsNew {
+		session.Values["session_version"] = user.SessionVersion
+		if err = session.Save(r, w); err != nil {
+			return err
+		}
+	}
+
+	conn := a.redisPool.Get()
+	defer conn.Close()
+
+	sessionIDs, err := redis.Strings(redis.DoContext(conn, ctx, "HKEYS", userSessionsKey(userID)))
+	if err != nil {
+		return err
+	}
+
+	// Сессии со старой версией уже недействительны, их данные удаляются, чтобы не занимать место
+	for _, sessionID := range sessionIDs {
+		if sessionID == session.ID {
+			continue
+		}
+
+		if err = a.deleteSession(ctx, conn, userID, sessionID); err != nil {
+			return err
+		}
+	}
+
+	info, err := a.getSessionInfo(ctx, userID, session.ID)
+	if err != nil || info == nil {
+		return err
+	}
+
+	info.SessionVersion = user.SessionVersion
+
+	return a.saveSessionInfo(ctx, userID, session.ID, *info)
+}
+
+// deleteSession Удалить данные сессии из redistore и сведения о ней
+func (a *RedigoAuthenticator) deleteSession(ctx context.Context, conn redis.Conn, userID int, sessionID string) error {
+	if _, err := redis.DoContext(conn, ctx, "DEL", sessionKeyPrefix+sessionID); err != nil {
+		return err
+	}
+
+	_, err := redis.DoContext(conn, ctx, "HDEL", userSessionsKey(userID), sessionID)
+
+	return err
+}
+
+// currentSessionID Id сессии текущего запроса, пустая строка если сессии нет
+func (a *RedigoAuthenticator) currentSessionID(r *http.Request) string {
+	session, err := a.store.Get(r, sessionName)
+	if err != nil || session.IsNew {
+		return ""
+	}
+
+	return session.ID
+}
@@ -9,6 +9,16 @@ import (
 	"palback/internal/usecase"
 )
 
+// incrScript Увеличить счетчик и задать время жизни новому ключу одной операцией,
+// чтобы сбой между INCR и EXPIRE не оставил счетчик без срока
+var incrScript = redis.NewScript(1, `
+local count = redis.call('INCR', KEYS[1])
+if count == 1 and tonumber(ARGV[1]) > 0 then
+	redis.call('EXPIRE', KEYS[1], ARGV[1])
+end
+return count
+`)
+
 type RedisStorage struct {
 	redisPool *redis.Pool
 }
@@ -33,6 +43,25 @@ func (s *RedisStorage) Set(ctx context.Context, key, value string, expireSeconds
 	return err
 }
 
+// SetNX Записать значение одной командой SET NX, поэтому из одновременных запросов ключ запишет только один
+func (s *RedisStorage) SetNX(ctx context.Context, key, value string, expireSeconds int) (bool, error) {
+	conn := s.redisPool.Get()
+	defer conn.Close()
+
+	args := redis.Args{key, value, "NX"}
+	if expireSeconds > 0 {
+		args = args.Add("EX", expireSeconds)
+	}
+
+	reply, err := redis.DoContext(conn, ctx, "SET", args...)
+	if err != nil {
+		return false, err
+	}
+
+	// Если ключ уже есть, Redis возвращает nil вместо OK
+	return reply != nil, nil
+}
+
 func (s *RedisStorage) Get(ctx context.Context, key string) (string, error) {
 	conn := s.redisPool.Get()
 	defer conn.Close()
@@ -55,6 +84,37 @@ func (s *RedisStorage) Get(ctx context.Context, key string) (string, error) {
 	return redis.String(reply, err)
 }
 
+// GetDel Получить значение и удалить ключ одной командой GETDEL, поэтому значение получит только один из
+// одновременных запросов
+func (s *RedisStorage) GetDel(ctx context.Context, key string) (string, error) {
+	conn := s.redisPool.Get()
+	defer conn.Close()
+
+	reply, err := redis.DoContext(conn, ctx, "GETDEL", key)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, redis.ErrNil):
+			return "", usecase.ErrKeyNotFound
+		default:
+			return "", err
+		}
+	}
+
+	if reply == nil {
+		return "", usecase.ErrKeyNotFound
+	}
+
+	return redis.String(reply, err)
+}
+
+func (s *RedisStorage) Incr(ctx context.Context, key string, expireSeconds int) (int, error) {
+	conn := s.redisPool.Get()
+	defer conn.Close()
+
+	return redis.Int(incrScript.DoContext(ctx, conn, key, expireSeconds))
+}
+
 func (s *RedisStorage) Del(ctx context.Context, key string) error {
 	conn := s.redisPool.Get()
 	defer conn.Close()
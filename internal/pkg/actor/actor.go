@@ -0,0 +1,35 @@
+package actor
+
+import "context"
+
+type ctxKey struct{}
+
+// WithUserID Сохранить в контексте id пользователя, выполняющего запрос
+func WithUserID(ctx context.Context, userID int) context.Context {
+	return context.WithValue(ctx, ctxKey{}, userID)
+}
+
+// UserID Получить из контекста id пользователя, выполняющего запрос
+func UserID(ctx context.Context) (int, bool) {
+	userID, ok := ctx.Value(ctxKey{}).(int)
+	return userID, ok && userID > 0
+}
+
+type clientCtxKey struct{}
+
+// Client Сведения о клиенте, от которого пришел запрос
+type Client struct {
+	IP        string
+	UserAgent string
+}
+
+// WithClient Сохранить в контексте сведения о клиенте, от которого пришел запрос
+func WithClient(ctx context.Context, client Client) context.Context {
+	return context.WithValue(ctx, clientCtxKey{}, client)
+}
+
+// ClientInfo Получить из контекста сведения о клиенте. Вне HTTP-запроса возвращаются пустые значения.
+func ClientInfo(ctx context.Context) Client {
+	client, _ := ctx.Value(clientCtxKey{}).(Client)
+	return client
+}
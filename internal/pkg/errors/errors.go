@@ -2,7 +2,11 @@ package errors
 
 import "errors"
 
-var ErrNotFound = errors.New("запись не найдена")
+var (
+	ErrNotFound           = errors.New("запись не найдена")
+	ErrInvalidImage       = errors.New("не удалось прочитать изображение")
+	ErrImageTooManyPixels = errors.New("слишком много пикселей в изображении")
+)
 
 func IsOneOf(err error, targets ...error) bool {
 	for _, target := range targets {
@@ -0,0 +1,69 @@
+package geo
+
+import "math"
+
+// EarthRadiusKm Средний радиус Земли в километрах
+const EarthRadiusKm = 6371.0
+
+// kmPerDegree Длина одного градуса меридиана в километрах
+const kmPerDegree = 111.045
+
+type BoundingBox struct {
+	MinLatitude  float64
+	MinLongitude float64
+	MaxLatitude  float64
+	MaxLongitude float64
+}
+
+// CrossesAntimeridian Прямоугольник пересекает 180-й меридиан
+func (b BoundingBox) CrossesAntimeridian() bool {
+	return b.MinLongitude > b.MaxLongitude
+}
+
+// IsValid Проверить, что границы прямоугольника лежат в допустимых пределах
+func (b BoundingBox) IsValid() bool {
+	return b.MinLatitude >= -90 && b.MaxLatitude <= 90 &&
+		b.MinLatitude <= b.MaxLatitude &&
+		b.MinLongitude >= -180 && b.MinLongitude <= 180 &&
+		b.MaxLongitude >= -180 && b.MaxLongitude <= 180
+}
+
+// BoundingBoxAround Получить прямоугольник, описанный вокруг окружности заданного радиуса.
+// Используется для предварительного отбора точек по индексу перед точным расчётом расстояния.
+func BoundingBoxAround(latitude, longitude, radiusKm float64) BoundingBox {
+	deltaLat := radiusKm / kmPerDegree
+
+	box := BoundingBox{
+		MinLatitude:  math.Max(latitude-deltaLat, -90),
+		MaxLatitude:  math.Min(latitude+deltaLat, 90),
+		MinLongitude: -180,
+		MaxLongitude: 180,
+	}
+
+	// Вблизи полюсов окружность захватывает все долготы
+	cosLat := math.Cos(latitude * math.Pi / 180)
+	if box.MinLatitude == -90 || box.MaxLatitude == 90 || cosLat <= 0 {
+		return box
+	}
+
+	deltaLon := radiusKm / (kmPerDegree * cosLat)
+	if deltaLon >= 180 {
+		return box
+	}
+
+	box.MinLongitude = normalizeLongitude(longitude - deltaLon)
+	box.MaxLongitude = normalizeLongitude(longitude + deltaLon)
+
+	return box
+}
+
+func normalizeLongitude(lon float64) float64 {
+	switch {
+	case lon > 180:
+		return lon - 360
+	case lon < -180:
+		return lon + 360
+	default:
+		return lon
+	}
+}
@@ -7,3 +7,7 @@ func FromPtr[T any](src *T) (result T) {
 
 	return result
 }
+
+func ToPtr[T any](src T) *T {
+	return &src
+}
@@ -0,0 +1,38 @@
+package locale
+
+import "context"
+
+// Default Язык, на котором хранятся основные названия записей справочников
+const Default = "ru"
+
+type ctxKey struct{}
+
+// WithLang Сохранить в контексте язык, на котором нужно вернуть названия
+func WithLang(ctx context.Context, lang string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, lang)
+}
+
+// Lang Получить из контекста язык названий. Если язык не задан, возвращается язык по умолчанию.
+func Lang(ctx context.Context) string {
+	lang, ok := ctx.Value(ctxKey{}).(string)
+	if !ok || lang == "" {
+		return Default
+	}
+
+	return lang
+}
+
+// IsValid Проверить, что строка похожа на двухбуквенный код языка ISO 639-1
+func IsValid(lang string) bool {
+	if len(lang) != 2 {
+		return false
+	}
+
+	for _, r := range lang {
+		if r < 'a' || r > 'z' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -2,6 +2,7 @@ package token
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
 )
 
@@ -12,3 +13,21 @@ func GenerateVerificationToken() (string, error) {
 	}
 	return hex.EncodeToString(bytes), nil
 }
+
+// AccessTokenPrefix Начало персональных токенов доступа, по которому их легко найти в утечках
+const AccessTokenPrefix = "pal_"
+
+// GenerateAccessToken Сгенерировать персональный токен доступа
+func GenerateAccessToken() (string, error) {
+	bytes := make([]byte, 20)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return AccessTokenPrefix + hex.EncodeToString(bytes), nil
+}
+
+// HashAccessToken Хэш токена доступа для хранения и поиска
+func HashAccessToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
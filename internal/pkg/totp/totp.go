@@ -0,0 +1,137 @@
+// Package totp реализует одноразовые пароли по времени (RFC 6238) и резервные коды
+// для двухфакторной аутентификации.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// Period Время действия одного кода в секундах
+	Period = 30
+
+	// Digits Количество цифр в коде
+	Digits = 6
+
+	// Skew Сколько соседних интервалов принимается для компенсации расхождения часов
+	Skew = 1
+
+	secretSize       = 20
+	recoveryCodeSize = 10
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret Сгенерировать секрет в кодировке base32
+func GenerateSecret() (string, error) {
+	secret := make([]byte, secretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return encoding.EncodeToString(secret), nil
+}
+
+// ProvisioningURI Ссылка otpauth:// для добавления секрета в приложение-аутентификатор через QR-код
+func ProvisioningURI(issuer, account, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(Digits))
+	query.Set("period", fmt.Sprint(Period))
+
+	label := url.PathEscape(issuer + ":" + account)
+
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
+
+// Step Номер временного интервала для указанного момента
+func Step(t time.Time) int64 {
+	return t.Unix() / Period
+}
+
+// Code Код для указанного временного интервала
+func Code(secret string, step int64) (string, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", fmt.Errorf("неверный секрет: %w", err)
+	}
+
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	// Динамическое усечение, RFC 4226 раздел 5.3
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for range Digits {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", Digits, value%mod), nil
+}
+
+// Validate Проверить код с учетом допустимого расхождения часов.
+// Возвращает номер интервала, которому соответствует код, чтобы вызывающий мог запретить его повторное использование.
+func Validate(secret, code string, t time.Time) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != Digits {
+		return 0, false
+	}
+
+	current := Step(t)
+
+	for step := current - Skew; step <= current+Skew; step++ {
+		expected, err := Code(secret, step)
+		if err != nil {
+			return 0, false
+		}
+
+		if hmac.Equal([]byte(expected), []byte(code)) {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// GenerateRecoveryCodes Сгенерировать резервные коды вида xxxxx-xxxxx
+func GenerateRecoveryCodes(count int) ([]string, error) {
+	result := make([]string, 0, count)
+
+	for range count {
+		raw := make([]byte, recoveryCodeSize)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, err
+		}
+
+		code := strings.ToLower(encoding.EncodeToString(raw))[:recoveryCodeSize]
+		result = append(result, code[:recoveryCodeSize/2]+"-"+code[recoveryCodeSize/2:])
+	}
+
+	return result, nil
+}
+
+// HashRecoveryCode Хэш резервного кода для хранения. Регистр и дефисы при вводе не учитываются.
+func HashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	sum := sha256.Sum256([]byte(normalized))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+	"unicode/utf8"
+
+	"palback/internal/domain/model"
+	localErrors "palback/internal/pkg/errors"
+	tokens "palback/internal/pkg/token"
+	ucModel "palback/internal/usecase/model"
+	"palback/internal/usecase/port"
+)
+
+const (
+	accessTokenNameMaxLength = 100
+
+	// accessTokenVisiblePrefix Сколько первых символов токена сохраняется для узнавания в списке
+	accessTokenVisiblePrefix = len(tokens.AccessTokenPrefix) + 8
+
+	// accessTokensMaxCount Сколько токенов может быть у одного пользователя
+	accessTokensMaxCount = 20
+)
+
+type AccessTokenUseCase struct {
+	repo port.AccessTokenRepo
+}
+
+func NewAccessTokenUseCase(repo port.AccessTokenRepo) *AccessTokenUseCase {
+	return &AccessTokenUseCase{
+		repo: repo,
+	}
+}
+
+func (s *AccessTokenUseCase) GetByUser(ctx context.Context, userID int) ([]model.AccessToken, error) {
+	result, err := s.repo.GetByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения токенов доступа: %w", err)
+	}
+
+	return result, nil
+}
+
+// Create Выпустить персональный токен доступа. Значение токена возвращается только здесь.
+func (s *AccessTokenUseCase) Create(
+	ctx context.Context,
+	userID int,
+	name string,
+	scopes []model.AccessTokenScope,
+	expiresAt *time.Time,
+) (*ucModel.CreatedAccessToken, error) {
+	if name == "" {
+		return nil, ErrAccessTokenNameRequired
+	}
+
+	if utf8.RuneCountInString(name) > accessTokenNameMaxLength {
+		return nil, ErrAccessTokenNameTooLong
+	}
+
+	if len(scopes) == 0 {
+		return nil, ErrAccessTokenInvalidScope
+	}
+
+	for _, scope := range scopes {
+		if !scope.IsValid() {
+			return nil, ErrAccessTokenInvalidScope
+		}
+	}
+
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, ErrAccessTokenInvalidExpiry
+	}
+
+	existing, err := s.repo.GetByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения токенов доступа: %w", err)
+	}
+
+	if len(existing) >= accessTokensMaxCount {
+		return nil, ErrAccessTokenLimitReached
+	}
+
+	value, err := tokens.GenerateAccessToken()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка генерации токена: %w", err)
+	}
+
+	slices.Sort(scopes)
+
+	created, err := s.repo.Create(ctx, model.AccessToken{
+		UserID:    userID,
+		Name:      name,
+		Prefix:    value[:accessTokenVisiblePrefix],
+		TokenHash: tokens.HashAccessToken(value),
+		Scopes:    slices.Compact(scopes),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ошибка добавления токена доступа: %w", err)
+	}
+
+	return &ucModel.CreatedAccessToken{
+		AccessToken: *created,
+		Token:       value,
+	}, nil
+}
+
+// Revoke Отозвать токен доступа пользователя
+func (s *AccessTokenUseCase) Revoke(ctx context.Context, userID, id int) error {
+	err := s.repo.Delete(ctx, userID, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, localErrors.ErrNotFound):
+			return ErrAccessTokenNotFound
+		default:
+			return fmt.Errorf("ошибка отзыва токена доступа: %w", err)
+		}
+	}
+
+	return nil
+}
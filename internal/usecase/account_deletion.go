@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"palback/internal/domain/model"
+	localErrors "palback/internal/pkg/errors"
+	"palback/internal/usecase/port"
+)
+
+type AccountDeletionUseCase struct {
+	profileService UserProfileService
+	audit          AuditService
+	outbox         EmailOutboxService
+	gracePeriod    time.Duration
+	repo           port.UserRepo
+}
+
+func NewAccountDeletionUseCase(
+	profileService UserProfileService,
+	audit AuditService,
+	outbox EmailOutboxService,
+	gracePeriod time.Duration,
+	repo port.UserRepo,
+) *AccountDeletionUseCase {
+	return &AccountDeletionUseCase{
+		profileService: profileService,
+		audit:          audit,
+		outbox:         outbox,
+		gracePeriod:    gracePeriod,
+		repo:           repo,
+	}
+}
+
+// RequestDeletion Запросить удаление аккаунта после повторного ввода пароля.
+// Аккаунт удаляется по истечении льготного срока, вход на сайт в течение этого срока отменяет удаление.
+// Возвращает время, после которого аккаунт будет удален.
+func (s *AccountDeletionUseCase) RequestDeletion(ctx context.Context, userID int, password string) (time.Time, error) {
+	user, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, localErrors.ErrNotFound):
+			return time.Time{}, ErrUserNotFound
+		default:
+			return time.Time{}, fmt.Errorf("ошибка получения пользователя по id: %w", err)
+		}
+	}
+
+	if user.DeletionRequestedAt != nil {
+		return time.Time{}, ErrAccountDeletionAlreadyRequested
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
+	if err != nil {
+		return time.Time{}, ErrInvalidPassword
+	}
+
+	requestedAt := time.Now()
+
+	err = s.repo.UpdateDeletionRequested(ctx, userID, &requestedAt)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("ошибка сохранения запроса на удаление аккаунта: %w", err)
+	}
+
+	// Завершить все сессии пользователя: удаление отменяет только новый вход с паролем
+	err = s.repo.IncrementSessionVersion(ctx, user.Email)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("ошибка завершения сессий пользователя: %w", err)
+	}
+
+	deleteAt := requestedAt.Add(s.gracePeriod)
+
+	s.audit.Record(ctx, model.AuditActionAccountDeletionRequest, model.AuditEntityUser, strconv.Itoa(userID),
+		map[string]any{"delete_at": deleteAt})
+
+	err = s.outbox.Enqueue(ctx, accountDeletionEmail(user.Email, deleteAt))
+	if err != nil {
+		log.Printf("Ошибка постановки письма об удалении аккаунта на %s в очередь: %v", user.Email, err)
+	}
+
+	return deleteAt, nil
+}
+
+// PurgeExpired Удалить аккаунты, у которых истек льготный срок. Аватар удаляется из хранилища,
+// письма на адрес пользователя — из очереди, а фотографии, предложения и ревизии справочников
+// обезличиваются внешними ключами с on delete set null.
+// Аккаунт, вход в который отменил удаление уже после получения списка, не удаляется.
+func (s *AccountDeletionUseCase) PurgeExpired(ctx context.Context) error {
+	requestedBefore := time.Now().Add(-s.gracePeriod)
+
+	users, err := s.repo.GetDeletionDue(ctx, requestedBefore)
+	if err != nil {
+		return fmt.Errorf("ошибка получения аккаунтов для удаления: %w", err)
+	}
+
+	var errs []error
+
+	for _, user := range users {
+		err = s.repo.DeleteDeletionDue(ctx, user.ID, requestedBefore)
+		if errors.Is(err, localErrors.ErrNotFound) {
+			continue
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("ошибка удаления аккаунта %d: %w", user.ID, err))
+			continue
+		}
+
+		s.profileService.DeleteAvatarFiles(ctx, user)
+		s.auditDelete(ctx, user, "истек льготный срок")
+	}
+
+	return errors.Join(errs...)
+}
+
+// Delete Удалить аккаунт без льготного срока по решению администратора.
+// Собственный аккаунт так удалить нельзя.
+func (s *AccountDeletionUseCase) Delete(ctx context.Context, userID int) error {
+	if err := checkNotSelf(ctx, userID); err != nil {
+		return err
+	}
+
+	user, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, localErrors.ErrNotFound):
+			return ErrUserNotFound
+		default:
+			return fmt.Errorf("ошибка получения пользователя по id: %w", err)
+		}
+	}
+
+	err = s.repo.Delete(ctx, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, localErrors.ErrNotFound):
+			return ErrUserNotFound
+		default:
+			return fmt.Errorf("ошибка удаления аккаунта: %w", err)
+		}
+	}
+
+	s.profileService.DeleteAvatarFiles(ctx, *user)
+	s.auditDelete(ctx, *user, "удален администратором")
+
+	return nil
+}
+
+// auditDelete Записать удаление аккаунта. Имя и e-mail не сохраняются, чтобы удаленный аккаунт был обезличен:
+// запись ссылается только на id пользователя.
+func (s *AccountDeletionUseCase) auditDelete(ctx context.Context, user model.User, reason string) {
+	s.audit.Record(ctx, model.AuditActionUserDelete, model.AuditEntityUser, strconv.Itoa(user.ID), map[string]any{
+		"reason": reason,
+	})
+}
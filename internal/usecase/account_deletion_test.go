@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"palback/internal/domain/model"
+	localErrors "palback/internal/pkg/errors"
+	"palback/internal/usecase/port"
+)
+
+// fakeDeletionRepo Пользователи, ожидающие удаления. Удаление отменено у пользователей из cancelled.
+type fakeDeletionRepo struct {
+	port.UserRepo
+
+	due       []model.User
+	cancelled map[int]bool
+	deleted   []int
+}
+
+func (r *fakeDeletionRepo) GetDeletionDue(context.Context, time.Time) ([]model.User, error) {
+	return r.due, nil
+}
+
+func (r *fakeDeletionRepo) DeleteDeletionDue(_ context.Context, id int, _ time.Time) error {
+	if r.cancelled[id] {
+		return localErrors.ErrNotFound
+	}
+
+	r.deleted = append(r.deleted, id)
+
+	return nil
+}
+
+// fakeAvatarFiles Запоминает пользователей, чьи файлы аватара удалены
+type fakeAvatarFiles struct {
+	UserProfileService
+
+	deleted []int
+}
+
+func (s *fakeAvatarFiles) DeleteAvatarFiles(_ context.Context, user model.User) {
+	s.deleted = append(s.deleted, user.ID)
+}
+
+// fakeAudit Запоминает записи журнала аудита
+type fakeAudit struct {
+	AuditService
+
+	payloads []any
+}
+
+func (s *fakeAudit) Record(_ context.Context, _ model.AuditAction, _, _ string, payload any) {
+	s.payloads = append(s.payloads, payload)
+}
+
+func TestPurgeExpired_SkipsCancelledDeletion(t *testing.T) {
+	repo := &fakeDeletionRepo{
+		due: []model.User{
+			{ID: 1, Username: "stays", Email: "stays@example.com", AvatarKey: "users/1/a"},
+			{ID: 2, Username: "leaves", Email: "leaves@example.com", AvatarKey: "users/2/b"},
+		},
+		// Пользователь 1 вошел на сайт после получения списка
+		cancelled: map[int]bool{1: true},
+	}
+	avatars := &fakeAvatarFiles{}
+	audit := &fakeAudit{}
+
+	s := NewAccountDeletionUseCase(avatars, audit, nil, 24*time.Hour, repo)
+
+	if err := s.PurgeExpired(context.Background()); err != nil {
+		t.Fatalf("PurgeExpired: %v", err)
+	}
+
+	if len(repo.deleted) != 1 || repo.deleted[0] != 2 {
+		t.Fatalf("удалены %v, ожидался только 2", repo.deleted)
+	}
+
+	if len(avatars.deleted) != 1 || avatars.deleted[0] != 2 {
+		t.Fatalf("удалены аватары %v, ожидался только 2", avatars.deleted)
+	}
+
+	if len(audit.payloads) != 1 {
+		t.Fatalf("записей аудита %d, ожидалась 1", len(audit.payloads))
+	}
+
+	payload := audit.payloads[0].(map[string]any)
+	if _, ok := payload["email"]; ok {
+		t.Errorf("e-mail удаленного пользователя сохранен в аудите: %v", payload)
+	}
+
+	if _, ok := payload["username"]; ok {
+		t.Errorf("имя удаленного пользователя сохранено в аудите: %v", payload)
+	}
+}
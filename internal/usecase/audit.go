@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"palback/internal/domain/model"
+	"palback/internal/pkg/actor"
+	ucModel "palback/internal/usecase/model"
+	"palback/internal/usecase/port"
+)
+
+const (
+	defaultAuditListLimit = 50
+	maxAuditListLimit     = 500
+
+	// maxAuditExportRows Сколько записей не более выгружать в файл за один раз
+	maxAuditExportRows = 10000
+)
+
+type AuditUseCase struct {
+	repo port.AuditRepo
+}
+
+func NewAuditUseCase(repo port.AuditRepo) *AuditUseCase {
+	return &AuditUseCase{
+		repo: repo,
+	}
+}
+
+// Record Записать действие в журнал аудита. Исполнитель, IP-адрес и User-Agent берутся из контекста запроса.
+// Ошибка записи не отменяет само действие, поэтому только логируется.
+func (s *AuditUseCase) Record(
+	ctx context.Context,
+	action model.AuditAction,
+	entityType, entityID string,
+	payload any,
+) {
+	client := actor.ClientInfo(ctx)
+
+	entry := model.AuditEntry{
+		Action:     action,
+		IP:         client.IP,
+		UserAgent:  client.UserAgent,
+		EntityType: entityType,
+		EntityID:   entityID,
+	}
+
+	if userID, ok := actor.UserID(ctx); ok {
+		entry.ActorID = &userID
+	}
+
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			log.Printf("Ошибка сериализации записи аудита %s: %v", action, err)
+			return
+		}
+
+		entry.Payload = data
+	}
+
+	if err := s.repo.Create(ctx, entry); err != nil {
+		log.Printf("Ошибка записи в журнал аудита %s: %v", action, err)
+	}
+}
+
+// GetList Получить страницу журнала аудита, начиная с последних записей
+func (s *AuditUseCase) GetList(ctx context.Context, filter model.AuditFilter) (result ucModel.AuditList, err error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAuditListLimit
+	}
+
+	if filter.Limit > maxAuditListLimit {
+		filter.Limit = maxAuditListLimit
+	}
+
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	result.Items, err = s.repo.GetList(ctx, filter)
+	if err != nil {
+		return result, fmt.Errorf("ошибка при получении журнала аудита: %w", err)
+	}
+
+	result.Total, err = s.repo.Count(ctx, filter)
+	if err != nil {
+		return result, fmt.Errorf("ошибка при подсчете записей журнала аудита: %w", err)
+	}
+
+	return result, nil
+}
+
+// Export Получить записи журнала для выгрузки, не более maxAuditExportRows последних
+func (s *AuditUseCase) Export(ctx context.Context, filter model.AuditFilter) ([]model.AuditEntry, error) {
+	filter.Limit = maxAuditExportRows
+	filter.Offset = 0
+
+	entries, err := s.repo.GetList(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при выгрузке журнала аудита: %w", err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,286 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"palback/internal/domain/model"
+	localErrors "palback/internal/pkg/errors"
+	"palback/internal/pkg/helpers"
+	ucModel "palback/internal/usecase/model"
+	"palback/internal/usecase/port"
+)
+
+type CityUseCase struct {
+	countryService  CountryService
+	regionService   RegionService
+	cityTypeService CityTypeService
+	revisionService RevisionService
+	repo            port.CityRepo
+}
+
+func NewCityUseCase(
+	countryService CountryService,
+	regionService RegionService,
+	cityTypeService CityTypeService,
+	revisionService RevisionService,
+	repo port.CityRepo,
+) *CityUseCase {
+	return &CityUseCase{
+		countryService:  countryService,
+		regionService:   regionService,
+		cityTypeService: cityTypeService,
+		revisionService: revisionService,
+		repo:            repo,
+	}
+}
+
+func (s *CityUseCase) Get(ctx context.Context, id int) (*ucModel.CityDetail, error) {
+	city, err := s.getCity(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	country, err := s.countryService.Get(ctx, city.CountryID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения страны по id: %w", err)
+	}
+
+	var region *model.Region
+	if city.RegionID != nil {
+		regionDetail, err := s.regionService.Get(ctx, *city.RegionID)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка получения региона по id: %w", err)
+		}
+
+		region = regionFromDetail(helpers.FromPtr(regionDetail))
+	}
+
+	cityType, err := s.cityTypeService.Get(ctx, city.CityTypeID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения типа населенного пункта по id: %w", err)
+	}
+
+	result := ucModel.CreateCityDetail(
+		helpers.FromPtr(city),
+		helpers.FromPtr(country),
+		region,
+		helpers.FromPtr(cityType),
+	)
+
+	return &result, nil
+}
+
+func (s *CityUseCase) GetByCountry(ctx context.Context, countryID string) (result ucModel.CityList, err error) {
+	country, err := s.countryService.Get(ctx, countryID)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrCountryNotFound):
+			return result, fmt.Errorf("ошибка получения страны: %w", err)
+		default:
+			return result, fmt.Errorf("ошибка проверки страны на существование: %w", err)
+		}
+	}
+
+	cities, err := s.repo.GetByCountry(ctx, countryID)
+	if err != nil {
+		return result, fmt.Errorf("ошибка при получении списка населенных пунктов: %w", err)
+	}
+
+	var regions []model.Region
+	if country.HasRegions {
+		regionList, err := s.regionService.GetByCountry(ctx, countryID)
+		if err != nil {
+			return result, fmt.Errorf("ошибка при получении списка регионов: %w", err)
+		}
+
+		regions = make([]model.Region, 0, len(regionList.Items))
+		for _, item := range regionList.Items {
+			regions = append(regions, helpers.FromPtr(regionFromDetail(item)))
+		}
+	}
+
+	cityTypes, err := s.cityTypeService.GetAll(ctx)
+	if err != nil {
+		return result, fmt.Errorf("ошибка при получении списка типов населенных пунктов: %w", err)
+	}
+
+	result = ucModel.CreateCityList(cities, []model.Country{helpers.FromPtr(country)}, regions, cityTypes)
+
+	return result, nil
+}
+
+func (s *CityUseCase) Create(ctx context.Context, city model.City) (*ucModel.CityDetail, error) {
+	country, region, cityType, err := s.checkCity(ctx, city)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.repo.Create(ctx, city)
+
+	if err != nil {
+		switch {
+		default:
+			return nil, fmt.Errorf("ошибка добавления населенного пункта: %w", err)
+		}
+	}
+
+	s.revisionService.Record(ctx, model.RevisionEntityCity, strconv.Itoa(created.ID), model.RevisionActionCreate, nil, created)
+
+	result := ucModel.CreateCityDetail(helpers.FromPtr(created), helpers.FromPtr(country), region, helpers.FromPtr(cityType))
+
+	return &result, nil
+}
+
+func (s *CityUseCase) Update(ctx context.Context, id int, city model.City) error {
+	return s.update(ctx, id, city, model.RevisionActionUpdate)
+}
+
+func (s *CityUseCase) update(ctx context.Context, id int, city model.City, action model.RevisionAction) error {
+	before, err := s.getCity(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	_, _, _, err = s.checkCity(ctx, city)
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.Update(ctx, id, city)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, localErrors.ErrNotFound):
+			return ErrCityNotFound
+		default:
+			return fmt.Errorf("ошибка обновления населенного пункта: %w", err)
+		}
+	}
+
+	city.ID = id
+	s.revisionService.Record(ctx, model.RevisionEntityCity, strconv.Itoa(id), action, before, city)
+
+	return nil
+}
+
+func (s *CityUseCase) Delete(ctx context.Context, id int) error {
+	before, err := s.getCity(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.Delete(ctx, id)
+
+	if errors.Is(err, localErrors.ErrNotFound) {
+		return ErrCityNotFound
+	}
+
+	if err != nil {
+		return fmt.Errorf("ошибка удаления населенного пункта: %w", err)
+	}
+
+	s.revisionService.Record(ctx, model.RevisionEntityCity, strconv.Itoa(id), model.RevisionActionDelete, before, nil)
+
+	return nil
+}
+
+func (s *CityUseCase) GetRevisions(ctx context.Context, id int) (ucModel.RevisionList, error) {
+	return s.revisionService.GetByEntity(ctx, model.RevisionEntityCity, strconv.Itoa(id))
+}
+
+// Restore Вернуть населенный пункт к состоянию после указанной ревизии. Удаленный пункт так не восстанавливается.
+func (s *CityUseCase) Restore(ctx context.Context, id int, revisionID int) error {
+	city, err := revisionSnapshot[model.City](
+		ctx,
+		s.revisionService,
+		model.RevisionEntityCity,
+		strconv.Itoa(id),
+		revisionID,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = s.update(ctx, id, *city, model.RevisionActionRestore)
+	if errors.Is(err, ErrCityNotFound) {
+		return ErrRevisionEntityDeleted
+	}
+
+	return err
+}
+
+func (s *CityUseCase) getCity(ctx context.Context, id int) (*model.City, error) {
+	city, err := s.repo.Get(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, localErrors.ErrNotFound):
+			return nil, ErrCityNotFound
+		default:
+			return nil, fmt.Errorf("ошибка получения населенного пункта по id: %w", err)
+		}
+	}
+
+	return city, nil
+}
+
+// checkCity Проверить, что страна, регион, тип и координаты населенного пункта согласованы между собой
+func (s *CityUseCase) checkCity(
+	ctx context.Context,
+	city model.City,
+) (*model.Country, *model.Region, *model.CityType, error) {
+	if !validCoordinates(city.Latitude, city.Longitude) {
+		return nil, nil, nil, ErrInvalidCoordinates
+	}
+
+	country, err := s.countryService.Get(ctx, city.CountryID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("ошибка проверки страны населенного пункта: %w", err)
+	}
+
+	var region *model.Region
+
+	switch {
+	case country.HasRegions && city.RegionID == nil:
+		return nil, nil, nil, ErrCityRegionRequired
+	case !country.HasRegions && city.RegionID != nil:
+		return nil, nil, nil, ErrCountryHasNotRegions
+	case city.RegionID != nil:
+		regionDetail, err := s.regionService.Get(ctx, *city.RegionID)
+		if err != nil {
+			switch {
+			case errors.Is(err, localErrors.ErrNotFound):
+				return nil, nil, nil, ErrRegionNotFound
+			default:
+				return nil, nil, nil, fmt.Errorf("ошибка проверки региона населенного пункта: %w", err)
+			}
+		}
+
+		if regionDetail.Country.ID != country.ID {
+			return nil, nil, nil, ErrRegionNotInCountry
+		}
+
+		region = regionFromDetail(helpers.FromPtr(regionDetail))
+	}
+
+	cityType, err := s.cityTypeService.Get(ctx, city.CityTypeID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("ошибка проверки типа населенного пункта: %w", err)
+	}
+
+	return country, region, cityType, nil
+}
+
+func regionFromDetail(src ucModel.RegionDetail) *model.Region {
+	return &model.Region{
+		ID:        src.ID,
+		CountryID: src.Country.ID,
+		Name:      src.Name,
+	}
+}
+
+func validCoordinates(latitude, longitude float64) bool {
+	return latitude >= -90 && latitude <= 90 && longitude >= -180 && longitude <= 180
+}
@@ -4,20 +4,24 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"strings"
 
 	"palback/internal/domain/model"
 	localErrors "palback/internal/pkg/errors"
+	ucModel "palback/internal/usecase/model"
 	"palback/internal/usecase/port"
 )
 
 type CountryUseCase struct {
-	repo port.CountryRepo
+	revisionService RevisionService
+	repo            port.CountryRepo
 }
 
-func NewCountryUseCase(repo port.CountryRepo) *CountryUseCase {
+func NewCountryUseCase(revisionService RevisionService, repo port.CountryRepo) *CountryUseCase {
 	return &CountryUseCase{
-		repo: repo,
+		revisionService: revisionService,
+		repo:            repo,
 	}
 }
 
@@ -56,11 +60,27 @@ func (c *CountryUseCase) Create(ctx context.Context, country model.Country) (*mo
 		}
 	}
 
+	c.revisionService.Record(ctx, model.RevisionEntityCountry, result.ID, model.RevisionActionCreate, nil, result)
+
 	return result, nil
 }
 
 func (c *CountryUseCase) Update(ctx context.Context, id string, country model.Country) error {
-	err := c.repo.Update(ctx, id, country)
+	return c.update(ctx, id, country, model.RevisionActionUpdate)
+}
+
+func (c *CountryUseCase) update(
+	ctx context.Context,
+	id string,
+	country model.Country,
+	action model.RevisionAction,
+) error {
+	before, err := c.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = c.repo.Update(ctx, id, country)
 
 	if err != nil {
 		switch {
@@ -73,11 +93,19 @@ func (c *CountryUseCase) Update(ctx context.Context, id string, country model.Co
 		}
 	}
 
+	country.ID = id
+	c.revisionService.Record(ctx, model.RevisionEntityCountry, id, action, before, country)
+
 	return nil
 }
 
 func (c *CountryUseCase) Delete(ctx context.Context, id string) error {
-	err := c.repo.Delete(ctx, id)
+	before, err := c.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = c.repo.Delete(ctx, id)
 
 	if errors.Is(err, localErrors.ErrNotFound) {
 		return ErrCountryNotFound
@@ -87,15 +115,63 @@ func (c *CountryUseCase) Delete(ctx context.Context, id string) error {
 		return fmt.Errorf("ошибка удаления страны: %w", err)
 	}
 
+	c.revisionService.Record(ctx, model.RevisionEntityCountry, id, model.RevisionActionDelete, before, nil)
+
 	return nil
 }
 
+// Order Изменить порядок стран. В историю записывается каждая страна, у которой изменился вес.
 func (c *CountryUseCase) Order(ctx context.Context, ids []string) error {
-	err := c.repo.Order(ctx, ids)
+	before, err := c.repo.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("ошибка при получении списка стран: %w", err)
+	}
+
+	err = c.repo.Order(ctx, ids)
 
 	if err != nil {
 		return fmt.Errorf("ошибка сортировки стран: %w", err)
 	}
 
+	// Новый вес берется из базы, а не вычисляется по ids, чтобы история совпадала с сохраненными данными
+	after, err := c.repo.GetAll(ctx)
+	if err != nil {
+		log.Printf("Ошибка получения стран для записи сортировки в историю: %v", err)
+		return nil
+	}
+
+	previous := make(map[string]model.Country, len(before))
+	for _, country := range before {
+		previous[country.ID] = country
+	}
+
+	for _, country := range after {
+		prev, ok := previous[country.ID]
+		if !ok || prev.Weight == country.Weight {
+			continue
+		}
+
+		c.revisionService.Record(ctx, model.RevisionEntityCountry, country.ID, model.RevisionActionUpdate, prev, country)
+	}
+
 	return nil
 }
+
+func (c *CountryUseCase) GetRevisions(ctx context.Context, id string) (ucModel.RevisionList, error) {
+	return c.revisionService.GetByEntity(ctx, model.RevisionEntityCountry, id)
+}
+
+// Restore Вернуть страну к состоянию после указанной ревизии. Удаленная страна так не восстанавливается.
+func (c *CountryUseCase) Restore(ctx context.Context, id string, revisionID int) error {
+	country, err := revisionSnapshot[model.Country](ctx, c.revisionService, model.RevisionEntityCountry, id, revisionID)
+	if err != nil {
+		return err
+	}
+
+	err = c.update(ctx, id, *country, model.RevisionActionRestore)
+	if errors.Is(err, ErrCountryNotFound) {
+		return ErrRevisionEntityDeleted
+	}
+
+	return err
+}
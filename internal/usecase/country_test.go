@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"palback/internal/domain/model"
+	"palback/internal/usecase/port"
+)
+
+// fakeCountryRepo Страны в памяти. Реализованы только GetAll и Order.
+type fakeCountryRepo struct {
+	port.CountryRepo
+
+	countries []model.Country
+}
+
+func (r *fakeCountryRepo) GetAll(context.Context) ([]model.Country, error) {
+	return append([]model.Country(nil), r.countries...), nil
+}
+
+func (r *fakeCountryRepo) Order(_ context.Context, ids []string) error {
+	for i, id := range ids {
+		for j := range r.countries {
+			if r.countries[j].ID == id {
+				r.countries[j].Weight = len(ids) - i
+			}
+		}
+	}
+
+	return nil
+}
+
+// fakeRevisionService Запоминает записанные ревизии
+type fakeRevisionService struct {
+	RevisionService
+
+	recorded []model.Country
+}
+
+func (s *fakeRevisionService) Record(
+	_ context.Context,
+	_ model.RevisionEntity,
+	_ string,
+	_ model.RevisionAction,
+	_, after any,
+) {
+	s.recorded = append(s.recorded, after.(model.Country))
+}
+
+func TestCountryOrder_RecordsRevisions(t *testing.T) {
+	repo := &fakeCountryRepo{countries: []model.Country{
+		{ID: "ru", Name: "Россия", Weight: 3},
+		{ID: "by", Name: "Беларусь", Weight: 2},
+		{ID: "ua", Name: "Украина", Weight: 1},
+	}}
+	revisions := &fakeRevisionService{}
+
+	c := NewCountryUseCase(revisions, repo)
+
+	// Вес России не меняется, Беларусь и Украина меняются местами
+	if err := c.Order(context.Background(), []string{"ru", "ua", "by"}); err != nil {
+		t.Fatalf("Order: %v", err)
+	}
+
+	want := map[string]int{"ua": 2, "by": 1}
+
+	if len(revisions.recorded) != len(want) {
+		t.Fatalf("записано ревизий %d, ожидалось %d: %+v", len(revisions.recorded), len(want), revisions.recorded)
+	}
+
+	for _, country := range revisions.recorded {
+		if weight, ok := want[country.ID]; !ok || country.Weight != weight {
+			t.Errorf("ревизия %s с весом %d, ожидался %d", country.ID, country.Weight, weight)
+		}
+	}
+}
@@ -0,0 +1,311 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"palback/internal/domain/model"
+	localErrors "palback/internal/pkg/errors"
+	ucModel "palback/internal/usecase/model"
+	"palback/internal/usecase/port"
+)
+
+const (
+	defaultEmailListLimit = 50
+	maxEmailListLimit     = 500
+
+	// emailBatchSize Сколько писем не более отправлять за один проход
+	emailBatchSize = 20
+
+	// emailLease На сколько откладываются выбранные для отправки письма: если за это время результат
+	// не отмечен, письма снова попадают в очередь
+	emailLease = 5 * time.Minute
+
+	// emailMaxRetryDelay Наибольшая задержка между попытками отправки
+	emailMaxRetryDelay = 6 * time.Hour
+
+	// emailMaxErrorLength Сколько символов ошибки отправки сохранять
+	emailMaxErrorLength = 1000
+
+	// emailTokenTTL Сколько секунд действует токен из письма. После этого письмо бесполезно,
+	// поэтому оно больше не отправляется.
+	emailTokenTTL = 3600
+)
+
+// EmailOutboxConfig Настройки повторной отправки писем
+type EmailOutboxConfig struct {
+	// MaxAttempts После скольких неудачных попыток письмо больше не отправляется
+	MaxAttempts int
+
+	// RetryDelay Задержка перед второй попыткой, перед каждой следующей она удваивается
+	RetryDelay time.Duration
+}
+
+type EmailOutboxUseCase struct {
+	audit  AuditService
+	mailer port.EmailSender
+	config EmailOutboxConfig
+	repo   port.EmailOutboxRepo
+}
+
+func NewEmailOutboxUseCase(
+	audit AuditService,
+	mailer port.EmailSender,
+	config EmailOutboxConfig,
+	repo port.EmailOutboxRepo,
+) *EmailOutboxUseCase {
+	return &EmailOutboxUseCase{
+		audit:  audit,
+		mailer: mailer,
+		config: config,
+		repo:   repo,
+	}
+}
+
+// Enqueue Поставить письмо в очередь. Письмо отправляется в фоне, поэтому ошибки отправки
+// не влияют на запрос, в котором оно создано.
+func (s *EmailOutboxUseCase) Enqueue(ctx context.Context, message model.EmailMessage) error {
+	err := s.repo.Create(ctx, message)
+	if err != nil {
+		return fmt.Errorf("ошибка добавления письма в очередь: %w", err)
+	}
+
+	return nil
+}
+
+// DeliverDue Отправить письма, которые пора отправить. После неудачной попытки следующая
+// откладывается с удвоением задержки, а после MaxAttempts попыток письмо больше не отправляется.
+func (s *EmailOutboxUseCase) DeliverDue(ctx context.Context) error {
+	messages, err := s.repo.ClaimDue(ctx, emailBatchSize, emailLease)
+	if err != nil {
+		return fmt.Errorf("ошибка получения писем для отправки: %w", err)
+	}
+
+	var errs []error
+
+	for _, message := range messages {
+		if err = s.deliver(ctx, message); err != nil {
+			errs = append(errs, fmt.Errorf("ошибка обработки письма %d: %w", message.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// GetList Получить страницу писем, начиная с последних
+func (s *EmailOutboxUseCase) GetList(
+	ctx context.Context,
+	filter model.EmailFilter,
+) (result ucModel.EmailMessageList, err error) {
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return result, ErrEmailInvalidStatus
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultEmailListLimit
+	}
+
+	if filter.Limit > maxEmailListLimit {
+		filter.Limit = maxEmailListLimit
+	}
+
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	result.Items, err = s.repo.GetList(ctx, filter)
+	if err != nil {
+		return result, fmt.Errorf("ошибка при получении писем: %w", err)
+	}
+
+	result.Total, err = s.repo.Count(ctx, filter)
+	if err != nil {
+		return result, fmt.Errorf("ошибка при подсчете писем: %w", err)
+	}
+
+	return result, nil
+}
+
+func (s *EmailOutboxUseCase) Get(ctx context.Context, id int) (*model.EmailMessage, error) {
+	message, err := s.repo.Get(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, localErrors.ErrNotFound):
+			return nil, ErrEmailNotFound
+		default:
+			return nil, fmt.Errorf("ошибка получения письма по id: %w", err)
+		}
+	}
+
+	return message, nil
+}
+
+// Resend Вернуть неотправленное письмо в очередь, чтобы оно ушло при следующем проходе.
+// Отправленные письма заново не отправляются: их данные, в том числе токены, уже удалены.
+// Письма с истекшим токеном тоже не отправляются: пользователю нужно запросить новое письмо.
+func (s *EmailOutboxUseCase) Resend(ctx context.Context, id int) error {
+	message, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if message.Status == model.EmailStatusSent {
+		return ErrEmailAlreadySent
+	}
+
+	if emailTokenExpired(*message, time.Now()) {
+		return ErrEmailTokenExpired
+	}
+
+	err = s.repo.Requeue(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, localErrors.ErrNotFound):
+			return ErrEmailAlreadySent
+		default:
+			return fmt.Errorf("ошибка возврата письма в очередь: %w", err)
+		}
+	}
+
+	s.audit.Record(ctx, model.AuditActionEmailResend, model.AuditEntityEmail, strconv.Itoa(id), map[string]any{
+		"kind":       message.Kind,
+		"to_email":   message.ToEmail,
+		"status":     message.Status,
+		"attempts":   message.Attempts,
+		"last_error": message.LastError,
+	})
+
+	return nil
+}
+
+// deliver Отправить письмо и отметить результат. Письмо, токен которого истечет до следующей
+// попытки, больше не отправляется.
+func (s *EmailOutboxUseCase) deliver(ctx context.Context, message model.EmailMessage) error {
+	if emailTokenExpired(message, time.Now()) {
+		return s.repo.MarkDead(ctx, message.ID, ErrEmailTokenExpired.Error())
+	}
+
+	sendErr := s.send(message)
+	if sendErr == nil {
+		return s.repo.MarkSent(ctx, message.ID)
+	}
+
+	lastError := sendErr.Error()
+	if runes := []rune(lastError); len(runes) > emailMaxErrorLength {
+		lastError = string(runes[:emailMaxErrorLength])
+	}
+
+	attempts := message.Attempts + 1
+	delay := s.retryDelay(attempts)
+
+	if attempts >= s.config.MaxAttempts || emailTokenExpired(message, time.Now().Add(delay)) {
+		return s.repo.MarkDead(ctx, message.ID, lastError)
+	}
+
+	return s.repo.MarkFailed(ctx, message.ID, lastError, delay)
+}
+
+// send Отправить письмо по шаблону его вида
+func (s *EmailOutboxUseCase) send(message model.EmailMessage) error {
+	payload := message.Payload
+
+	switch message.Kind {
+	case model.EmailKindVerification:
+		return s.mailer.SendVerificationEmail(message.ToEmail, payload.Token)
+	case model.EmailKindPasswordReset:
+		return s.mailer.SendPasswordResetEmail(message.ToEmail, payload.Token)
+	case model.EmailKindEmailChange:
+		return s.mailer.SendEmailChangeEmail(message.ToEmail, payload.Token)
+	case model.EmailKindSubmissionState:
+		return s.mailer.SendSubmissionStateEmail(message.ToEmail, payload.PlaceName, payload.State, payload.Reason)
+	case model.EmailKindAccountDeletion:
+		return s.mailer.SendAccountDeletionEmail(message.ToEmail, emailPayloadTime(payload))
+	case model.EmailKindAccountLocked:
+		return s.mailer.SendAccountLockedEmail(message.ToEmail, payload.Token, emailPayloadTime(payload))
+	default:
+		return fmt.Errorf("неизвестный вид письма %q", message.Kind)
+	}
+}
+
+// retryDelay Задержка перед следующей попыткой после attempts неудачных: RetryDelay, 2*RetryDelay, 4*RetryDelay...
+func (s *EmailOutboxUseCase) retryDelay(attempts int) time.Duration {
+	delay := s.config.RetryDelay
+
+	for i := 1; i < attempts && delay < emailMaxRetryDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, emailMaxRetryDelay)
+}
+
+// emailTokenExpired Истек ли к моменту now токен из письма. Письма без токена не истекают.
+func emailTokenExpired(message model.EmailMessage, now time.Time) bool {
+	if message.Payload.Token == "" {
+		return false
+	}
+
+	return !now.Before(message.CreatedAt.Add(emailTokenTTL * time.Second))
+}
+
+func emailPayloadTime(payload model.EmailPayload) time.Time {
+	if payload.Time == nil {
+		return time.Time{}
+	}
+
+	return *payload.Time
+}
+
+func verificationEmail(toEmail, token string) model.EmailMessage {
+	return model.EmailMessage{
+		Kind:    model.EmailKindVerification,
+		ToEmail: toEmail,
+		Payload: model.EmailPayload{Token: token},
+	}
+}
+
+func passwordResetEmail(toEmail, token string) model.EmailMessage {
+	return model.EmailMessage{
+		Kind:    model.EmailKindPasswordReset,
+		ToEmail: toEmail,
+		Payload: model.EmailPayload{Token: token},
+	}
+}
+
+func emailChangeEmail(toEmail, token string) model.EmailMessage {
+	return model.EmailMessage{
+		Kind:    model.EmailKindEmailChange,
+		ToEmail: toEmail,
+		Payload: model.EmailPayload{Token: token},
+	}
+}
+
+func submissionStateEmail(toEmail string, submission model.PlaceSubmission) model.EmailMessage {
+	return model.EmailMessage{
+		Kind:    model.EmailKindSubmissionState,
+		ToEmail: toEmail,
+		Payload: model.EmailPayload{
+			PlaceName: submission.Place.Name,
+			State:     submission.State,
+			Reason:    submission.RejectReason,
+		},
+	}
+}
+
+func accountDeletionEmail(toEmail string, deleteAt time.Time) model.EmailMessage {
+	return model.EmailMessage{
+		Kind:    model.EmailKindAccountDeletion,
+		ToEmail: toEmail,
+		Payload: model.EmailPayload{Time: &deleteAt},
+	}
+}
+
+func accountLockedEmail(toEmail, token string, lockedUntil time.Time) model.EmailMessage {
+	return model.EmailMessage{
+		Kind:    model.EmailKindAccountLocked,
+		ToEmail: toEmail,
+		Payload: model.EmailPayload{Token: token, Time: &lockedUntil},
+	}
+}
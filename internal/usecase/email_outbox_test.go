@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"palback/internal/domain/model"
+	"palback/internal/usecase/port"
+)
+
+// fakeOutboxRepo Одно письмо в очереди, запоминает отмеченный результат отправки
+type fakeOutboxRepo struct {
+	port.EmailOutboxRepo
+
+	message  model.EmailMessage
+	status   model.EmailStatus
+	requeued bool
+}
+
+func (r *fakeOutboxRepo) Get(context.Context, int) (*model.EmailMessage, error) {
+	message := r.message
+	return &message, nil
+}
+
+func (r *fakeOutboxRepo) ClaimDue(context.Context, int, time.Duration) ([]model.EmailMessage, error) {
+	return []model.EmailMessage{r.message}, nil
+}
+
+func (r *fakeOutboxRepo) MarkSent(context.Context, int) error {
+	r.status = model.EmailStatusSent
+	return nil
+}
+
+func (r *fakeOutboxRepo) MarkFailed(context.Context, int, string, time.Duration) error {
+	r.status = model.EmailStatusPending
+	return nil
+}
+
+func (r *fakeOutboxRepo) MarkDead(context.Context, int, string) error {
+	r.status = model.EmailStatusDead
+	return nil
+}
+
+func (r *fakeOutboxRepo) Requeue(context.Context, int) error {
+	r.requeued = true
+	return nil
+}
+
+// fakeMailer Считает отправленные письма. Реализованы только письма о сбросе пароля и о заявке.
+type fakeMailer struct {
+	port.EmailSender
+
+	err  error
+	sent int
+}
+
+func (m *fakeMailer) SendPasswordResetEmail(string, string) error {
+	m.sent++
+	return m.err
+}
+
+func (m *fakeMailer) SendSubmissionStateEmail(string, string, model.SubmissionState, string) error {
+	m.sent++
+	return m.err
+}
+
+func TestDeliverDue_TokenExpiry(t *testing.T) {
+	errSMTP := errors.New("smtp недоступен")
+
+	tests := []struct {
+		name     string
+		message  model.EmailMessage
+		age      time.Duration
+		sendErr  error
+		wantSent int
+		want     model.EmailStatus
+	}{
+		{
+			name:     "токен действует",
+			message:  passwordResetEmail("pilgrim@example.com", "token"),
+			age:      time.Minute,
+			wantSent: 1,
+			want:     model.EmailStatusSent,
+		},
+		{
+			name:    "токен истек",
+			message: passwordResetEmail("pilgrim@example.com", "token"),
+			age:     2 * time.Hour,
+			want:    model.EmailStatusDead,
+		},
+		{
+			name:     "повтор успеет до истечения токена",
+			message:  passwordResetEmail("pilgrim@example.com", "token"),
+			age:      time.Minute,
+			sendErr:  errSMTP,
+			wantSent: 1,
+			want:     model.EmailStatusPending,
+		},
+		{
+			name:     "токен истечет до повтора",
+			message:  passwordResetEmail("pilgrim@example.com", "token"),
+			age:      50 * time.Minute,
+			sendErr:  errSMTP,
+			wantSent: 1,
+			want:     model.EmailStatusDead,
+		},
+		{
+			name:     "письмо без токена не истекает",
+			message:  submissionStateEmail("pilgrim@example.com", model.PlaceSubmission{}),
+			age:      24 * time.Hour,
+			sendErr:  errSMTP,
+			wantSent: 1,
+			want:     model.EmailStatusPending,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.message.CreatedAt = time.Now().Add(-tt.age)
+
+			repo := &fakeOutboxRepo{message: tt.message}
+			mailer := &fakeMailer{err: tt.sendErr}
+			s := NewEmailOutboxUseCase(nil, mailer, EmailOutboxConfig{MaxAttempts: 5, RetryDelay: 15 * time.Minute}, repo)
+
+			if err := s.DeliverDue(context.Background()); err != nil {
+				t.Fatalf("DeliverDue: %v", err)
+			}
+
+			if mailer.sent != tt.wantSent {
+				t.Errorf("отправок %d, ожидалось %d", mailer.sent, tt.wantSent)
+			}
+
+			if repo.status != tt.want {
+				t.Errorf("состояние %q, ожидалось %q", repo.status, tt.want)
+			}
+		})
+	}
+}
+
+func TestResend_TokenExpired(t *testing.T) {
+	message := passwordResetEmail("pilgrim@example.com", "token")
+	message.Status = model.EmailStatusDead
+	message.CreatedAt = time.Now().Add(-2 * time.Hour)
+
+	repo := &fakeOutboxRepo{message: message}
+	s := NewEmailOutboxUseCase(&fakeAudit{}, nil, EmailOutboxConfig{}, repo)
+
+	if err := s.Resend(context.Background(), 1); !errors.Is(err, ErrEmailTokenExpired) {
+		t.Fatalf("ожидалась %v, получено %v", ErrEmailTokenExpired, err)
+	}
+
+	if repo.requeued {
+		t.Fatalf("письмо с истекшим токеном возвращено в очередь")
+	}
+}
@@ -13,16 +13,103 @@ var (
 
 	ErrCityTypeNotFound = errors.New("тип населенного пункта не найден")
 
+	ErrCityNotFound       = errors.New("населенный пункт не найден")
+	ErrCityRegionRequired = errors.New("для населенного пункта страны с регионами необходимо указать регион")
+	ErrRegionNotInCountry = errors.New("регион не относится к указанной стране")
+	ErrInvalidCoordinates = errors.New("неверные координаты: широта должна быть от -90 до 90, долгота от -180 до 180")
+
 	ErrPlaceTypeNotFound = errors.New("тип святого места не найден")
 
-	ErrUserNameNotUnique           = errors.New("имя пользователя должно быть уникальным")
-	ErrUserEmailNotUnique          = errors.New("e-mail пользователя должен быть уникальным")
-	ErrVerificationEmailSendFailed = errors.New("пользователь создан, но проверочное письмо отправить не удалось")
-	ErrUserInvalidCredentials      = errors.New("неверные логин и пароль")
-	ErrUnauthenticated             = errors.New("не аутентифицировано")
-	ErrUncheckedEmail              = errors.New("ваш e-mail должен быть подтвержден, проверьте почту и подвердите e-mail")
-	ErrInvalidToken                = errors.New("неверный или устаревший токен")
-	ErrSessionExpired              = errors.New("сессия устарела, требуется повторный вход на сайт")
+	ErrPlaceNotFound            = errors.New("святое место не найдено")
+	ErrPlaceInvalidStatus       = errors.New("неверный статус святого места")
+	ErrPlaceInvalidFoundingYear = errors.New("год основания святого места не может быть больше текущего")
+	ErrPlaceInvalidRadius       = errors.New("радиус поиска должен быть больше 0 и не более 500 км")
+	ErrInvalidBoundingBox       = errors.New("неверные границы области поиска")
+
+	ErrSubmissionNotFound       = errors.New("предложение не найдено")
+	ErrSubmissionForbidden      = errors.New("нет доступа к предложению")
+	ErrSubmissionNotEditable    = errors.New("предложение нельзя изменить в текущем состоянии")
+	ErrSubmissionInvalidState   = errors.New("недопустимый переход состояния предложения")
+	ErrSubmissionReasonRequired = errors.New("необходимо указать причину отклонения предложения")
+
+	ErrImageEmpty           = errors.New("файл изображения пуст")
+	ErrImageTooLarge        = errors.New("размер изображения превышает допустимый")
+	ErrImageTooManyPixels   = errors.New("разрешение изображения превышает допустимое")
+	ErrImageUnsupportedType = errors.New("неподдерживаемый формат изображения, допустимы JPEG, PNG и WebP")
+
+	ErrPhotoNotFound  = errors.New("фотография не найдена")
+	ErrPhotoForbidden = errors.New("нет доступа к фотографии")
+
+	ErrRevisionNotFound      = errors.New("ревизия не найдена")
+	ErrRevisionNotRestorable = errors.New("к данной ревизии нельзя вернуться")
+	ErrRevisionEntityDeleted = errors.New("запись удалена, вернуть ее к ревизии нельзя")
+
+	ErrTranslationNotFound         = errors.New("перевод не найден")
+	ErrTranslationInvalidEntity    = errors.New("неизвестный справочник, допустимы country, region, city_type, city, place_type и place")
+	ErrTranslationEntityNotFound   = errors.New("запись справочника не найдена")
+	ErrTranslationLangNotSupported = errors.New("язык не поддерживается")
+	ErrTranslationDefaultLang      = errors.New("название на языке по умолчанию изменяется в самой записи справочника")
+	ErrTranslationNameRequired     = errors.New("необходимо указать название")
+
+	ErrUserNotFound              = errors.New("пользователь не найден")
+	ErrUserBlocked               = errors.New("учетная запись заблокирована")
+	ErrUserSelfAdministration    = errors.New("нельзя изменить роль, заблокировать или удалить собственную учетную запись")
+	ErrProfileDisplayNameTooLong = errors.New("отображаемое имя не должно быть длиннее 100 символов")
+	ErrProfileAboutTooLong       = errors.New("текст о себе не должен быть длиннее 2000 символов")
+	ErrAvatarNotFound            = errors.New("аватар не найден")
+
+	ErrRoleNotFound          = errors.New("роль не найдена")
+	ErrRoleNotEditable       = errors.New("права роли администратора изменить нельзя")
+	ErrRoleAssignForbidden   = errors.New("назначать и снимать роль администратора может только администратор")
+	ErrRoleInvalidPermission = errors.New("неизвестное право, допустимы catalog.edit, places.moderate и users.manage")
+
+	ErrInvalidPassword                 = errors.New("неверный пароль")
+	ErrAccountDeletionAlreadyRequested = errors.New("удаление аккаунта уже запрошено")
+
+	ErrPasswordTooShort             = errors.New("пароль слишком короткий")
+	ErrPasswordTooLong              = errors.New("пароль не должен быть длиннее 72 байт")
+	ErrPasswordTooSimple            = errors.New("пароль должен содержать символы разных типов: строчные и заглавные буквы, цифры, другие символы")
+	ErrPasswordContainsPersonalData = errors.New("пароль не должен содержать имя пользователя или e-mail")
+	ErrPasswordBreached             = errors.New("этот пароль встречается в утечках данных, выберите другой")
+
+	ErrUserNameNotUnique      = errors.New("имя пользователя должно быть уникальным")
+	ErrEmailNotChanged        = errors.New("новый e-mail совпадает с текущим")
+	ErrUserEmailNotUnique     = errors.New("e-mail пользователя должен быть уникальным")
+	ErrUserInvalidCredentials = errors.New("неверные логин и пароль")
+	ErrLoginThrottled         = errors.New("слишком много неудачных попыток входа, повторите попытку позже")
+	ErrAccountLocked          = errors.New("вход временно заблокирован, инструкция по разблокировке отправлена на e-mail")
+	ErrUnauthenticated        = errors.New("не аутентифицировано")
+	ErrUncheckedEmail         = errors.New("ваш e-mail должен быть подтвержден, проверьте почту и подвердите e-mail")
+	ErrInvalidToken           = errors.New("неверный или устаревший токен")
+	ErrSessionExpired         = errors.New("сессия устарела, требуется повторный вход на сайт")
+	ErrSessionNotFound        = errors.New("сессия не найдена")
+
+	ErrAccessTokenNotFound      = errors.New("токен доступа не найден")
+	ErrAccessTokenNameRequired  = errors.New("необходимо указать название токена доступа")
+	ErrAccessTokenNameTooLong   = errors.New("название токена доступа не должно быть длиннее 100 символов")
+	ErrAccessTokenInvalidScope  = errors.New("неверные права токена доступа, допустимы read и write")
+	ErrAccessTokenInvalidExpiry = errors.New("срок действия токена доступа должен быть в будущем")
+	ErrAccessTokenLimitReached  = errors.New("достигнуто максимальное количество токенов доступа")
+	ErrAccessTokenScopeDenied   = errors.New("токен доступа не дает права на эту операцию")
+
+	ErrIdentityProviderNotFound = errors.New("провайдер входа не найден")
+	ErrIdentityNotFound         = errors.New("привязанная учетная запись не найдена")
+	ErrIdentityAlreadyLinked    = errors.New("учетная запись провайдера уже привязана")
+	ErrIdentityEmailNotVerified = errors.New("провайдер не подтвердил e-mail, вход невозможен")
+	ErrIdentityEmailConflict    = errors.New("пользователь с таким e-mail уже есть, но его e-mail не подтвержден")
+	ErrIdentityLastLoginMethod  = errors.New("нельзя отвязать единственный способ входа, сначала задайте пароль")
+
+	ErrTwoFactorInvalidCode     = errors.New("неверный код подтверждения")
+	ErrTwoFactorAlreadyEnabled  = errors.New("двухфакторная аутентификация уже включена")
+	ErrTwoFactorNotEnabled      = errors.New("двухфакторная аутентификация не включена")
+	ErrTwoFactorSetupNotStarted = errors.New("настройка двухфакторной аутентификации не начата или устарела")
+	ErrTwoFactorRequired        = errors.New("для администраторов двухфакторная аутентификация обязательна")
+	ErrTwoFactorTooManyAttempts = errors.New("слишком много неверных кодов подтверждения, повторите попытку позже")
+
+	ErrEmailNotFound      = errors.New("письмо не найдено")
+	ErrEmailAlreadySent   = errors.New("письмо уже отправлено")
+	ErrEmailInvalidStatus = errors.New("неверное состояние письма, допустимы pending, sent и dead")
+	ErrEmailTokenExpired  = errors.New("срок действия ссылки в письме истек")
 
 	ErrNoReplyFromKeyValueStorage = errors.New("нет ответа от key-value хранилища")
 	ErrKeyNotFound                = errors.New("ключ не найден")
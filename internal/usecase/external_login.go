@@ -0,0 +1,394 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"palback/internal/domain/model"
+	localErrors "palback/internal/pkg/errors"
+	tokens "palback/internal/pkg/token"
+	ucModel "palback/internal/usecase/model"
+	"palback/internal/usecase/port"
+)
+
+const (
+	// externalLoginStateTTL Сколько секунд ждать возврата пользователя от провайдера
+	externalLoginStateTTL = 600
+
+	// usernameAttempts Сколько вариантов имени пробовать при создании пользователя
+	usernameAttempts = 5
+)
+
+var usernameInvalidChars = regexp.MustCompile(`[^a-z0-9_.-]+`)
+
+// externalLoginState Параметры начатого входа через провайдера, хранящиеся в key-value хранилище по state
+type externalLoginState struct {
+	Provider     string `json:"provider"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+
+	// BindingHash Хэш значения, выданного браузеру, начавшему вход. Без него в Callback состояние чужое.
+	BindingHash string `json:"binding_hash"`
+
+	// LinkUserID Заполняется, если уже вошедший пользователь привязывает учетную запись провайдера
+	LinkUserID *int `json:"link_user_id,omitempty"`
+}
+
+type ExternalLoginUseCase struct {
+	userService UserService
+	kvStorage   port.KeyValueStorage
+	providers   map[string]port.IdentityProvider
+	userRepo    port.UserRepo
+	repo        port.IdentityRepo
+}
+
+func NewExternalLoginUseCase(
+	userService UserService,
+	kvStorage port.KeyValueStorage,
+	providers []port.IdentityProvider,
+	userRepo port.UserRepo,
+	repo port.IdentityRepo,
+) *ExternalLoginUseCase {
+	providersMap := make(map[string]port.IdentityProvider, len(providers))
+	for _, provider := range providers {
+		providersMap[provider.Name()] = provider
+	}
+
+	return &ExternalLoginUseCase{
+		userService: userService,
+		kvStorage:   kvStorage,
+		providers:   providersMap,
+		userRepo:    userRepo,
+		repo:        repo,
+	}
+}
+
+// Providers Имена настроенных провайдеров
+func (s *ExternalLoginUseCase) Providers() []string {
+	result := make([]string, 0, len(s.providers))
+	for name := range s.providers {
+		result = append(result, name)
+	}
+
+	slices.Sort(result)
+
+	return result
+}
+
+// Start Начать вход через провайдера. Возвращает адрес, на который нужно перенаправить пользователя,
+// и значение привязки, которое нужно сохранить в браузере и передать в Callback.
+// Если передан linkUserID, после возврата учетная запись провайдера привязывается к этому пользователю.
+func (s *ExternalLoginUseCase) Start(
+	ctx context.Context,
+	providerName string,
+	linkUserID *int,
+) (url, binding string, err error) {
+	provider, ok := s.providers[providerName]
+	if !ok {
+		return "", "", ErrIdentityProviderNotFound
+	}
+
+	var values [4]string
+
+	for i := range values {
+		value, err := tokens.GenerateVerificationToken()
+		if err != nil {
+			return "", "", fmt.Errorf("ошибка генерации токена: %w", err)
+		}
+
+		values[i] = value
+	}
+
+	stateToken, nonce, codeVerifier, binding := values[0], values[1], values[2], values[3]
+
+	value, err := json.Marshal(externalLoginState{
+		Provider:     providerName,
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		BindingHash:  tokens.HashAccessToken(binding),
+		LinkUserID:   linkUserID,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("ошибка подготовки состояния входа: %w", err)
+	}
+
+	err = s.kvStorage.Set(ctx, "oidc_state:"+stateToken, string(value), externalLoginStateTTL)
+	if err != nil {
+		return "", "", fmt.Errorf("ошибка сохранения состояния входа: %w", err)
+	}
+
+	return provider.AuthCodeURL(stateToken, nonce, codeVerifier), binding, nil
+}
+
+// Callback Завершить вход после возврата от провайдера. binding - значение, выданное в Start браузеру,
+// начавшему вход: без него чужой state не примут, и нельзя войти или привязать учетную запись от имени жертвы.
+// Учетная запись провайдера ищется среди привязанных, затем по подтвержденному e-mail среди пользователей;
+// если пользователя нет, он создается.
+func (s *ExternalLoginUseCase) Callback(
+	ctx context.Context,
+	providerName, stateToken, code, binding string,
+) (*ucModel.LoginResult, error) {
+	state, err := s.popState(ctx, stateToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if state.Provider != providerName || !state.boundTo(binding) {
+		return nil, ErrInvalidToken
+	}
+
+	provider, ok := s.providers[providerName]
+	if !ok {
+		return nil, ErrIdentityProviderNotFound
+	}
+
+	external, err := provider.Exchange(ctx, code, state.CodeVerifier, state.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка входа через провайдера %s: %w", providerName, err)
+	}
+
+	if state.LinkUserID != nil {
+		if err = s.link(ctx, *state.LinkUserID, *external); err != nil {
+			return nil, err
+		}
+
+		user, err := s.userService.Get(ctx, *state.LinkUserID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ucModel.LoginResult{User: user}, nil
+	}
+
+	userID, err := s.findOrCreateUser(ctx, *external)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.userService.LoginExternal(ctx, userID)
+}
+
+func (s *ExternalLoginUseCase) GetIdentities(ctx context.Context, userID int) ([]model.Identity, error) {
+	result, err := s.repo.GetByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения привязанных учетных записей: %w", err)
+	}
+
+	return result, nil
+}
+
+// Unlink Отвязать учетную запись провайдера. Пользователь без пароля не может отвязать последнюю.
+func (s *ExternalLoginUseCase) Unlink(ctx context.Context, userID, id int) error {
+	identity, err := s.repo.Get(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, localErrors.ErrNotFound):
+			return ErrIdentityNotFound
+		default:
+			return fmt.Errorf("ошибка получения привязанной учетной записи: %w", err)
+		}
+	}
+
+	if identity.UserID != userID {
+		return ErrIdentityNotFound
+	}
+
+	user, err := s.userRepo.Get(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("ошибка получения пользователя по id: %w", err)
+	}
+
+	if user.Password == "" {
+		identities, err := s.repo.GetByUser(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("ошибка получения привязанных учетных записей: %w", err)
+		}
+
+		if len(identities) <= 1 {
+			return ErrIdentityLastLoginMethod
+		}
+	}
+
+	err = s.repo.Delete(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, localErrors.ErrNotFound):
+			return ErrIdentityNotFound
+		default:
+			return fmt.Errorf("ошибка удаления привязанной учетной записи: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// popState Получить состояние входа. Состояние одноразовое и удаляется сразу после получения.
+func (s *ExternalLoginUseCase) popState(ctx context.Context, stateToken string) (*externalLoginState, error) {
+	if stateToken == "" {
+		return nil, ErrInvalidToken
+	}
+
+	value, err := s.kvStorage.GetDel(ctx, "oidc_state:"+stateToken)
+	if err != nil {
+		switch {
+		case localErrors.IsOneOf(err, ErrNoReplyFromKeyValueStorage, ErrKeyNotFound):
+			return nil, ErrInvalidToken
+		default:
+			return nil, fmt.Errorf("ошибка получения состояния входа: %w", err)
+		}
+	}
+
+	var state externalLoginState
+	if err = json.Unmarshal([]byte(value), &state); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	return &state, nil
+}
+
+// boundTo Проверить, что состояние выдано браузеру с этим значением привязки
+func (s externalLoginState) boundTo(binding string) bool {
+	if binding == "" || s.BindingHash == "" {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(tokens.HashAccessToken(binding)), []byte(s.BindingHash)) == 1
+}
+
+// link Привязать учетную запись провайдера к пользователю
+func (s *ExternalLoginUseCase) link(ctx context.Context, userID int, external model.ExternalIdentity) error {
+	identity, err := s.repo.GetBySubject(ctx, external.Provider, external.Subject)
+	switch {
+	case err == nil && identity.UserID == userID:
+		return nil
+	case err == nil:
+		return ErrIdentityAlreadyLinked
+	case !errors.Is(err, localErrors.ErrNotFound):
+		return fmt.Errorf("ошибка поиска привязанной учетной записи: %w", err)
+	}
+
+	_, err = s.repo.Create(ctx, model.Identity{
+		UserID:   userID,
+		Provider: external.Provider,
+		Subject:  external.Subject,
+		Email:    external.Email,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrIdentityAlreadyLinked):
+			return err
+		default:
+			return fmt.Errorf("ошибка привязки учетной записи: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// findOrCreateUser Найти пользователя, которому принадлежит учетная запись провайдера, или создать нового
+func (s *ExternalLoginUseCase) findOrCreateUser(ctx context.Context, external model.ExternalIdentity) (int, error) {
+	identity, err := s.repo.GetBySubject(ctx, external.Provider, external.Subject)
+	switch {
+	case err == nil:
+		return identity.UserID, nil
+	case !errors.Is(err, localErrors.ErrNotFound):
+		return 0, fmt.Errorf("ошибка поиска привязанной учетной записи: %w", err)
+	}
+
+	// Без подтвержденного e-mail нельзя ни найти существующего пользователя, ни безопасно создать нового
+	if !external.EmailVerified || external.Email == "" {
+		return 0, ErrIdentityEmailNotVerified
+	}
+
+	email := strings.ToLower(external.Email)
+
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	switch {
+	case err == nil:
+		// Иначе адрес мог заранее зарегистрировать кто-то другой, не имея к нему доступа
+		if !user.EmailVerified {
+			return 0, ErrIdentityEmailConflict
+		}
+	case errors.Is(err, localErrors.ErrNotFound):
+		user, err = s.createUser(ctx, email, external.Name)
+		if err != nil {
+			return 0, err
+		}
+	default:
+		return 0, fmt.Errorf("ошибка поиска пользователя по e-mail: %w", err)
+	}
+
+	if err = s.link(ctx, user.ID, external); err != nil {
+		return 0, err
+	}
+
+	return user.ID, nil
+}
+
+// createUser Создать пользователя без пароля. Войти он сможет через провайдера или задав пароль через сброс.
+func (s *ExternalLoginUseCase) createUser(ctx context.Context, email, name string) (*model.User, error) {
+	base := usernameFromEmail(email)
+
+	for attempt := range usernameAttempts {
+		username := base
+		if attempt > 0 {
+			suffix, err := tokens.GenerateVerificationToken()
+			if err != nil {
+				return nil, fmt.Errorf("ошибка генерации имени пользователя: %w", err)
+			}
+
+			username = base + "_" + suffix[:4]
+		}
+
+		user, err := s.userRepo.Create(ctx, model.User{
+			RoleID:        model.RoleUser,
+			Username:      username,
+			Email:         email,
+			EmailVerified: true,
+		})
+		if errors.Is(err, ErrUserNameNotUnique) {
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("ошибка добавления пользователя: %w", err)
+		}
+
+		if name != "" {
+			if runes := []rune(name); len(runes) > maxDisplayNameLength {
+				name = string(runes[:maxDisplayNameLength])
+			}
+
+			err = s.userRepo.UpdateProfile(ctx, user.ID, model.UserProfile{DisplayName: name})
+			if err != nil {
+				return nil, fmt.Errorf("ошибка заполнения профиля: %w", err)
+			}
+		}
+
+		return user, nil
+	}
+
+	return nil, ErrUserNameNotUnique
+}
+
+// usernameFromEmail Имя пользователя по первой части e-mail
+func usernameFromEmail(email string) string {
+	local, _, _ := strings.Cut(email, "@")
+
+	username := strings.Trim(usernameInvalidChars.ReplaceAllString(local, "_"), "_.-")
+	if username == "" {
+		return "user"
+	}
+
+	if len(username) > 30 {
+		username = username[:30]
+	}
+
+	return username
+}
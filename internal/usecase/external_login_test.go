@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+
+	"palback/internal/domain/model"
+	"palback/internal/usecase/port"
+)
+
+var errExchanged = errors.New("код обменян")
+
+// fakeIdentityProvider Провайдер, который кладет параметры входа в адрес и считает обмены кода.
+// Exchange всегда возвращает errExchanged, чтобы было видно, что Callback дошел до провайдера.
+type fakeIdentityProvider struct {
+	exchanges int
+}
+
+func (p *fakeIdentityProvider) Name() string {
+	return "mock"
+}
+
+func (p *fakeIdentityProvider) AuthCodeURL(state, nonce, _ string) string {
+	return "https://idp.example.com/authorize?" + url.Values{"state": {state}, "nonce": {nonce}}.Encode()
+}
+
+func (p *fakeIdentityProvider) Exchange(context.Context, string, string, string) (*model.ExternalIdentity, error) {
+	p.exchanges++
+	return nil, errExchanged
+}
+
+func newTestExternalLogin(t *testing.T) (*ExternalLoginUseCase, *fakeIdentityProvider) {
+	t.Helper()
+
+	provider := &fakeIdentityProvider{}
+	kvStorage := &fakeKeyValueStorage{values: make(map[string]string)}
+
+	return &ExternalLoginUseCase{
+		kvStorage: kvStorage,
+		providers: map[string]port.IdentityProvider{provider.Name(): provider},
+	}, provider
+}
+
+// startExternalLogin Начать вход и вернуть state из адреса провайдера и значение привязки браузера
+func startExternalLogin(t *testing.T, s *ExternalLoginUseCase) (state, binding string) {
+	t.Helper()
+
+	authURL, binding, err := s.Start(context.Background(), "mock", nil)
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatalf("url.Parse: %v", err)
+	}
+
+	return parsed.Query().Get("state"), binding
+}
+
+func TestExternalLoginCallback_RequiresBinding(t *testing.T) {
+	s, provider := newTestExternalLogin(t)
+	_, otherBinding := startExternalLogin(t, s)
+
+	tests := []struct {
+		name    string
+		binding string
+	}{
+		{name: "без cookie", binding: ""},
+		{name: "cookie другого входа", binding: otherBinding},
+		{name: "подобранное значение", binding: "binding"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, _ := startExternalLogin(t, s)
+
+			_, err := s.Callback(context.Background(), "mock", state, "code", tt.binding)
+			if !errors.Is(err, ErrInvalidToken) {
+				t.Fatalf("Callback err = %v, want %v", err, ErrInvalidToken)
+			}
+		})
+	}
+
+	if provider.exchanges != 0 {
+		t.Errorf("exchanges = %d, want 0", provider.exchanges)
+	}
+}
+
+func TestExternalLoginCallback_StateUsedOnce(t *testing.T) {
+	s, provider := newTestExternalLogin(t)
+	state, binding := startExternalLogin(t, s)
+
+	_, err := s.Callback(context.Background(), "mock", state, "code", binding)
+	if !errors.Is(err, errExchanged) {
+		t.Fatalf("Callback err = %v, want %v", err, errExchanged)
+	}
+
+	_, err = s.Callback(context.Background(), "mock", state, "code", binding)
+	if !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("repeated Callback err = %v, want %v", err, ErrInvalidToken)
+	}
+
+	if provider.exchanges != 1 {
+		t.Errorf("exchanges = %d, want 1", provider.exchanges)
+	}
+}
@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	localErrors "palback/internal/pkg/errors"
+)
+
+// sniffLength Количество байт, по которым http.DetectContentType определяет тип содержимого
+const sniffLength = 512
+
+// imageExtensions Допустимые типы загружаемых изображений и расширения, с которыми они сохраняются в хранилище
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+type uploadedImage struct {
+	Data        []byte
+	ContentType string
+	Extension   string
+}
+
+// readImage Прочитать загружаемое изображение, проверив его размер и определив тип по содержимому,
+// а не по заголовкам запроса
+func readImage(data io.Reader, size, maxSize int64) (*uploadedImage, error) {
+	if size <= 0 {
+		return nil, ErrImageEmpty
+	}
+
+	if size > maxSize {
+		return nil, ErrImageTooLarge
+	}
+
+	content, err := io.ReadAll(io.LimitReader(data, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения изображения: %w", err)
+	}
+
+	if int64(len(content)) > maxSize {
+		return nil, ErrImageTooLarge
+	}
+
+	contentType := http.DetectContentType(content[:min(len(content), sniffLength)])
+
+	ext, ok := imageExtensions[contentType]
+	if !ok {
+		return nil, ErrImageUnsupportedType
+	}
+
+	return &uploadedImage{
+		Data:        content,
+		ContentType: contentType,
+		Extension:   ext,
+	}, nil
+}
+
+// variantsError Преобразовать ошибку построения вариантов изображения
+func variantsError(err error) error {
+	switch {
+	case errors.Is(err, localErrors.ErrInvalidImage):
+		return ErrImageUnsupportedType
+	case errors.Is(err, localErrors.ErrImageTooManyPixels):
+		return ErrImageTooManyPixels
+	default:
+		return fmt.Errorf("ошибка подготовки вариантов изображения: %w", err)
+	}
+}
@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"palback/internal/domain/model"
+	localErrors "palback/internal/pkg/errors"
+	tokens "palback/internal/pkg/token"
+)
+
+const (
+	// loginDelayThreshold После скольких неудачных попыток подряд между попытками появляется задержка
+	loginDelayThreshold = 3
+
+	// loginMaxDelay Наибольшая задержка между попытками входа
+	loginMaxDelay = time.Minute
+)
+
+// LockoutConfig Настройки блокировки входа после неудачных попыток
+type LockoutConfig struct {
+	// MaxAttempts После скольких неудачных попыток подряд вход блокируется
+	MaxAttempts int
+
+	// Duration На сколько блокируется вход
+	Duration time.Duration
+}
+
+// checkLoginAllowed Проверить, можно ли сейчас пытаться войти в аккаунт.
+// Пароль при этом не проверяется, чтобы заблокированный аккаунт нельзя было продолжать подбирать.
+func (s *UserUseCase) checkLoginAllowed(user model.User, now time.Time) error {
+	if user.LoginFailures.IsLocked(now) {
+		return ErrAccountLocked
+	}
+
+	if retryAt := loginRetryAt(user.LoginFailures); retryAt != nil && now.Before(*retryAt) {
+		return ErrLoginThrottled
+	}
+
+	return nil
+}
+
+// registerLoginFailure Учесть неверный пароль. При достижении порога вход блокируется,
+// а владельцу отправляется письмо со ссылкой для снятия блокировки.
+func (s *UserUseCase) registerLoginFailure(ctx context.Context, user model.User) error {
+	count, err := s.repo.IncrementLoginFailures(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("ошибка учета неудачной попытки входа: %w", err)
+	}
+
+	// Нулевой порог отключает блокировку
+	if s.lockout.MaxAttempts <= 0 || count < s.lockout.MaxAttempts {
+		return ErrUserInvalidCredentials
+	}
+
+	lockedUntil := time.Now().Add(s.lockout.Duration)
+
+	err = s.repo.UpdateLockedUntil(ctx, user.ID, lockedUntil)
+	if err != nil {
+		return fmt.Errorf("ошибка блокировки входа: %w", err)
+	}
+
+	tokenStr, err := tokens.GenerateVerificationToken()
+	if err != nil {
+		return fmt.Errorf("ошибка генерации токена: %w", err)
+	}
+
+	err = s.kvStorage.Set(ctx, "unlock_account:"+tokenStr, strconv.Itoa(user.ID), emailTokenTTL)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения токена: %w", err)
+	}
+
+	err = s.outbox.Enqueue(ctx, accountLockedEmail(user.Email, tokenStr, lockedUntil))
+	if err != nil {
+		log.Printf("Ошибка постановки письма о блокировке входа на %s в очередь: %v", user.Email, err)
+	}
+
+	return ErrAccountLocked
+}
+
+// resetLoginFailures Сбросить неудачные попытки после верного пароля
+func (s *UserUseCase) resetLoginFailures(ctx context.Context, user model.User) error {
+	if user.LoginFailures.Count == 0 && user.LoginFailures.LockedUntil == nil {
+		return nil
+	}
+
+	err := s.repo.ResetLoginFailures(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("ошибка сброса неудачных попыток входа: %w", err)
+	}
+
+	return nil
+}
+
+// UnlockAccount Снять блокировку входа по ссылке из письма
+func (s *UserUseCase) UnlockAccount(ctx context.Context, token string) error {
+	if token == "" {
+		return ErrInvalidToken
+	}
+
+	value, err := s.kvStorage.Get(ctx, "unlock_account:"+token)
+	if err != nil {
+		switch {
+		case localErrors.IsOneOf(err, ErrNoReplyFromKeyValueStorage, ErrKeyNotFound):
+			return ErrInvalidToken
+		default:
+			return fmt.Errorf("ошибка получения токена: %w", err)
+		}
+	}
+
+	userID, err := strconv.Atoi(value)
+	if err != nil {
+		return ErrInvalidToken
+	}
+
+	if err = s.ClearLoginLockout(ctx, userID); err != nil {
+		return err
+	}
+
+	_ = s.kvStorage.Del(ctx, "unlock_account:"+token)
+
+	return nil
+}
+
+// GetLoginLockouts Получить пользователей с неудачными попытками входа, в том числе заблокированных
+func (s *UserUseCase) GetLoginLockouts(ctx context.Context) ([]model.User, error) {
+	users, err := s.repo.GetWithLoginFailures(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения блокировок входа: %w", err)
+	}
+
+	return users, nil
+}
+
+// ClearLoginLockout Снять блокировку входа и сбросить неудачные попытки пользователя
+func (s *UserUseCase) ClearLoginLockout(ctx context.Context, userID int) error {
+	err := s.repo.ResetLoginFailures(ctx, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, localErrors.ErrNotFound):
+			return ErrUserNotFound
+		default:
+			return fmt.Errorf("ошибка снятия блокировки входа: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// loginRetryAt Время, раньше которого нельзя повторить вход. Задержка удваивается с каждой
+// неудачной попыткой после порога, nil если задержки нет.
+func loginRetryAt(failures model.LoginFailures) *time.Time {
+	if failures.Count < loginDelayThreshold || failures.LastFailedAt == nil {
+		return nil
+	}
+
+	delay := loginMaxDelay
+	if shift := failures.Count - loginDelayThreshold; shift < 6 {
+		delay = min(time.Second<<shift, loginMaxDelay)
+	}
+
+	retryAt := failures.LastFailedAt.Add(delay)
+
+	return &retryAt
+}
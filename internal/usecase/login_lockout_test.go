@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"palback/internal/domain/model"
+	"palback/internal/usecase/port"
+)
+
+// fakeLockoutRepo Счетчик неудачных попыток входа одного пользователя
+type fakeLockoutRepo struct {
+	port.UserRepo
+
+	count       int
+	lockedUntil *time.Time
+}
+
+func (r *fakeLockoutRepo) IncrementLoginFailures(context.Context, int) (int, error) {
+	r.count++
+	return r.count, nil
+}
+
+func (r *fakeLockoutRepo) UpdateLockedUntil(_ context.Context, _ int, lockedUntil time.Time) error {
+	r.lockedUntil = &lockedUntil
+	return nil
+}
+
+// fakeOutbox Запоминает поставленные в очередь письма
+type fakeOutbox struct {
+	EmailOutboxService
+
+	messages []model.EmailMessage
+}
+
+func (s *fakeOutbox) Enqueue(_ context.Context, message model.EmailMessage) error {
+	s.messages = append(s.messages, message)
+	return nil
+}
+
+func TestRegisterLoginFailure_LocksAtThreshold(t *testing.T) {
+	repo := &fakeLockoutRepo{}
+	outbox := &fakeOutbox{}
+	kvStorage := &fakeKeyValueStorage{values: make(map[string]string)}
+
+	s := &UserUseCase{
+		repo:      repo,
+		outbox:    outbox,
+		kvStorage: kvStorage,
+		lockout:   LockoutConfig{MaxAttempts: 3, Duration: time.Hour},
+	}
+	user := model.User{ID: 1, Email: "pilgrim@example.com"}
+
+	for i := 1; i < 3; i++ {
+		if err := s.registerLoginFailure(context.Background(), user); !errors.Is(err, ErrUserInvalidCredentials) {
+			t.Fatalf("попытка %d: ожидалась %v, получено %v", i, ErrUserInvalidCredentials, err)
+		}
+	}
+
+	if repo.lockedUntil != nil {
+		t.Fatalf("вход заблокирован до достижения порога")
+	}
+
+	if err := s.registerLoginFailure(context.Background(), user); !errors.Is(err, ErrAccountLocked) {
+		t.Fatalf("ожидалась %v, получено %v", ErrAccountLocked, err)
+	}
+
+	if repo.lockedUntil == nil || time.Until(*repo.lockedUntil) <= 59*time.Minute {
+		t.Fatalf("вход заблокирован до %v, ожидалось примерно на час", repo.lockedUntil)
+	}
+
+	if len(outbox.messages) != 1 || outbox.messages[0].ToEmail != user.Email {
+		t.Fatalf("писем о блокировке %+v, ожидалось одно на %s", outbox.messages, user.Email)
+	}
+
+	if len(kvStorage.values) != 1 {
+		t.Fatalf("токенов снятия блокировки %d, ожидался 1", len(kvStorage.values))
+	}
+}
+
+func TestRegisterLoginFailure_ZeroThresholdDisablesLockout(t *testing.T) {
+	repo := &fakeLockoutRepo{count: 100}
+	s := &UserUseCase{repo: repo}
+
+	err := s.registerLoginFailure(context.Background(), model.User{ID: 1})
+	if !errors.Is(err, ErrUserInvalidCredentials) {
+		t.Fatalf("ожидалась %v, получено %v", ErrUserInvalidCredentials, err)
+	}
+
+	if repo.lockedUntil != nil {
+		t.Fatalf("вход заблокирован при нулевом пороге")
+	}
+}
+
+func TestCheckLoginAllowed(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		t := now.Add(d)
+		return &t
+	}
+
+	tests := []struct {
+		name     string
+		failures model.LoginFailures
+		want     error
+	}{
+		{"без неудачных попыток", model.LoginFailures{}, nil},
+		{"до порога задержки", model.LoginFailures{Count: 2, LastFailedAt: at(0)}, nil},
+		{"задержка после порога", model.LoginFailures{Count: 3, LastFailedAt: at(0)}, ErrLoginThrottled},
+		{"задержка прошла", model.LoginFailures{Count: 3, LastFailedAt: at(-time.Second)}, nil},
+		{"удвоенная задержка", model.LoginFailures{Count: 5, LastFailedAt: at(-3 * time.Second)}, ErrLoginThrottled},
+		{
+			"блокировка",
+			model.LoginFailures{Count: 10, LastFailedAt: at(-time.Hour), LockedUntil: at(time.Minute)},
+			ErrAccountLocked,
+		},
+		{
+			"блокировка истекла",
+			model.LoginFailures{Count: 10, LastFailedAt: at(-time.Hour), LockedUntil: at(-time.Minute)},
+			nil,
+		},
+	}
+
+	var s UserUseCase
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.checkLoginAllowed(model.User{LoginFailures: tt.failures}, now)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("ожидалась %v, получено %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestLoginRetryAt(t *testing.T) {
+	lastFailedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		count int
+		want  time.Duration
+	}{
+		{count: 2, want: 0},
+		{count: 3, want: time.Second},
+		{count: 4, want: 2 * time.Second},
+		{count: 8, want: 32 * time.Second},
+		{count: 9, want: loginMaxDelay},
+		{count: 100, want: loginMaxDelay},
+	}
+
+	for _, tt := range tests {
+		retryAt := loginRetryAt(model.LoginFailures{Count: tt.count, LastFailedAt: &lastFailedAt})
+
+		switch {
+		case tt.want == 0 && retryAt != nil:
+			t.Errorf("%d попыток: задержка до %v, ожидалось без задержки", tt.count, retryAt)
+		case tt.want != 0 && (retryAt == nil || retryAt.Sub(lastFailedAt) != tt.want):
+			t.Errorf("%d попыток: задержка до %v, ожидалась %v", tt.count, retryAt, tt.want)
+		}
+	}
+}
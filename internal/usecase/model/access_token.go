@@ -0,0 +1,9 @@
+package model
+
+import "palback/internal/domain/model"
+
+// CreatedAccessToken Только что выпущенный токен доступа вместе с его значением
+type CreatedAccessToken struct {
+	model.AccessToken
+	Token string
+}
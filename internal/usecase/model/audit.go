@@ -0,0 +1,10 @@
+package model
+
+import "palback/internal/domain/model"
+
+type AuditList struct {
+	Items []model.AuditEntry
+
+	// Total Количество записей, удовлетворяющих фильтру, без учета страницы
+	Total int
+}
@@ -0,0 +1,75 @@
+package model
+
+import "palback/internal/domain/model"
+
+type CityDetail struct {
+	ID        int
+	Country   model.Country
+	Region    *model.Region
+	CityType  model.CityType
+	Name      string
+	Latitude  float64
+	Longitude float64
+}
+
+func CreateCityDetail(
+	city model.City,
+	country model.Country,
+	region *model.Region,
+	cityType model.CityType,
+) CityDetail {
+	return CityDetail{
+		ID:        city.ID,
+		Country:   country,
+		Region:    region,
+		CityType:  cityType,
+		Name:      city.Name,
+		Latitude:  city.Latitude,
+		Longitude: city.Longitude,
+	}
+}
+
+type CityList struct {
+	Items []CityDetail
+}
+
+func CreateCityList(
+	cities []model.City,
+	countries []model.Country,
+	regions []model.Region,
+	cityTypes []model.CityType,
+) (result CityList) {
+	countryMap := make(map[string]model.Country)
+	for _, country := range countries {
+		countryMap[country.ID] = country
+	}
+
+	regionMap := make(map[int]model.Region)
+	for _, region := range regions {
+		regionMap[region.ID] = region
+	}
+
+	cityTypeMap := make(map[int]model.CityType)
+	for _, cityType := range cityTypes {
+		cityTypeMap[cityType.ID] = cityType
+	}
+
+	result.Items = make([]CityDetail, 0, len(cities))
+	for _, city := range cities {
+		var region *model.Region
+		if city.RegionID != nil {
+			if reg, ok := regionMap[*city.RegionID]; ok {
+				region = &reg
+			}
+		}
+
+		result.Items = append(result.Items, CreateCityDetail(
+			city,
+			countryMap[city.CountryID],
+			region,
+			cityTypeMap[city.CityTypeID],
+		))
+	}
+
+	return
+}
@@ -0,0 +1,10 @@
+package model
+
+import "palback/internal/domain/model"
+
+type EmailMessageList struct {
+	Items []model.EmailMessage
+
+	// Total Количество писем, удовлетворяющих фильтру, без учета страницы
+	Total int
+}
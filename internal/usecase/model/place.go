@@ -0,0 +1,91 @@
+package model
+
+import "palback/internal/domain/model"
+
+type PlaceDetail struct {
+	ID           int
+	PlaceType    model.PlaceType
+	City         CityDetail
+	Name         string
+	Address      string
+	Latitude     float64
+	Longitude    float64
+	Description  string
+	FoundingYear *int
+	Status       model.PlaceStatus
+}
+
+func CreatePlaceDetail(place model.Place, placeType model.PlaceType, city CityDetail) PlaceDetail {
+	return PlaceDetail{
+		ID:           place.ID,
+		PlaceType:    placeType,
+		City:         city,
+		Name:         place.Name,
+		Address:      place.Address,
+		Latitude:     place.Latitude,
+		Longitude:    place.Longitude,
+		Description:  place.Description,
+		FoundingYear: place.FoundingYear,
+		Status:       place.Status,
+	}
+}
+
+type PlaceList struct {
+	Items []PlaceDetail
+}
+
+func CreatePlaceList(
+	places []model.Place,
+	placeTypes []model.PlaceType,
+	cities map[int]CityDetail,
+) (result PlaceList) {
+	placeTypeMap := make(map[int]model.PlaceType)
+	for _, placeType := range placeTypes {
+		placeTypeMap[placeType.ID] = placeType
+	}
+
+	result.Items = make([]PlaceDetail, 0, len(places))
+	for _, place := range places {
+		result.Items = append(result.Items, CreatePlaceDetail(
+			place,
+			placeTypeMap[place.PlaceTypeID],
+			cities[place.CityID],
+		))
+	}
+
+	return
+}
+
+type PlaceNearbyDetail struct {
+	PlaceDetail
+	DistanceKm float64
+}
+
+type PlaceNearbyList struct {
+	Items []PlaceNearbyDetail
+}
+
+func CreatePlaceNearbyList(
+	places []model.PlaceWithDistance,
+	placeTypes []model.PlaceType,
+	cities map[int]CityDetail,
+) (result PlaceNearbyList) {
+	placeTypeMap := make(map[int]model.PlaceType)
+	for _, placeType := range placeTypes {
+		placeTypeMap[placeType.ID] = placeType
+	}
+
+	result.Items = make([]PlaceNearbyDetail, 0, len(places))
+	for _, place := range places {
+		result.Items = append(result.Items, PlaceNearbyDetail{
+			PlaceDetail: CreatePlaceDetail(
+				place.Place,
+				placeTypeMap[place.PlaceTypeID],
+				cities[place.CityID],
+			),
+			DistanceKm: place.DistanceKm,
+		})
+	}
+
+	return
+}
@@ -0,0 +1,65 @@
+package model
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"palback/internal/domain/model"
+)
+
+type FieldChange struct {
+	Before any
+	After  any
+}
+
+// RevisionDetail Ревизия с разницей по полям между сохраненными снимками до и после изменения
+type RevisionDetail struct {
+	model.Revision
+	Changes map[string]FieldChange
+}
+
+// CreateRevisionDetail Дополнить изменение списком полей, значения которых различаются до и после
+func CreateRevisionDetail(revision model.Revision) RevisionDetail {
+	before := make(map[string]any)
+	after := make(map[string]any)
+
+	if len(revision.Before) > 0 {
+		_ = json.Unmarshal(revision.Before, &before)
+	}
+
+	if len(revision.After) > 0 {
+		_ = json.Unmarshal(revision.After, &after)
+	}
+
+	changes := make(map[string]FieldChange)
+
+	for field, value := range after {
+		if prev, ok := before[field]; !ok || !reflect.DeepEqual(prev, value) {
+			changes[field] = FieldChange{Before: before[field], After: value}
+		}
+	}
+
+	for field, value := range before {
+		if _, ok := after[field]; !ok {
+			changes[field] = FieldChange{Before: value}
+		}
+	}
+
+	return RevisionDetail{
+		Revision: revision,
+		Changes:  changes,
+	}
+}
+
+type RevisionList struct {
+	Items []RevisionDetail
+}
+
+func CreateRevisionList(revisions []model.Revision) (result RevisionList) {
+	result.Items = make([]RevisionDetail, 0, len(revisions))
+	for _, revision := range revisions {
+		result.Items = append(result.Items, CreateRevisionDetail(revision))
+	}
+
+	return
+}
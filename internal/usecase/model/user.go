@@ -19,6 +19,9 @@ func CreateUserDetail(user model.User, role model.Role) UserDetail {
 
 type UserList struct {
 	Items []UserDetail
+
+	// Total Количество пользователей, удовлетворяющих фильтру, без учета страницы
+	Total int
 }
 
 func CreateUserList(users []model.User, roles map[model.RoleID]*model.Role) (result UserList) {
@@ -29,3 +32,35 @@ func CreateUserList(users []model.User, roles map[model.RoleID]*model.Role) (res
 
 	return
 }
+
+// UserProfileDetail Профиль пользователя вместе с данными его населенного пункта
+type UserProfileDetail struct {
+	model.User
+	City *CityDetail
+}
+
+func CreateUserProfileDetail(user model.User, city *CityDetail) UserProfileDetail {
+	return UserProfileDetail{
+		User: user,
+		City: city,
+	}
+}
+
+// LoginResult Результат входа. Если User не заполнен, вход нужно завершить вторым шагом с кодом
+// из приложения-аутентификатора по токену TwoFactorToken.
+type LoginResult struct {
+	User           *UserDetail
+	TwoFactorToken string
+
+	// TwoFactorSetup Заполняется, если пользователь обязан настроить двухфакторную аутентификацию при входе
+	TwoFactorSetup *TwoFactorSetup
+
+	// RecoveryCodes Резервные коды, выданные при настройке во время входа. Показываются один раз.
+	RecoveryCodes []string
+}
+
+// TwoFactorSetup Данные для добавления секрета в приложение-аутентификатор
+type TwoFactorSetup struct {
+	Secret string
+	URI    string
+}
@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"palback/internal/usecase/port"
+)
+
+const (
+	// passwordMaxBytes bcrypt учитывает только первые 72 байта пароля
+	passwordMaxBytes = 72
+
+	// personalDataMinLength Более короткие имена и части e-mail в пароле не ищутся,
+	// иначе под запрет попадет слишком много паролей
+	personalDataMinLength = 3
+)
+
+// PasswordPolicyConfig Требования к паролям
+type PasswordPolicyConfig struct {
+	// MinLength Наименьшая длина пароля в символах
+	MinLength int
+
+	// MinCharClasses Сколько типов символов должно быть в пароле: строчные и заглавные буквы,
+	// цифры и прочие символы
+	MinCharClasses int
+}
+
+type PasswordPolicyUseCase struct {
+	config   PasswordPolicyConfig
+	breached port.BreachedPasswords
+}
+
+// NewPasswordPolicyUseCase Если список утекших паролей не задан, пароли по нему не проверяются
+func NewPasswordPolicyUseCase(config PasswordPolicyConfig, breached port.BreachedPasswords) *PasswordPolicyUseCase {
+	return &PasswordPolicyUseCase{
+		config:   config,
+		breached: breached,
+	}
+}
+
+// Check Проверить новый пароль пользователя: длину, типы символов, отсутствие в пароле имени
+// пользователя и e-mail, а также отсутствие пароля в списке утекших
+func (s *PasswordPolicyUseCase) Check(_ context.Context, password, userName, email string) error {
+	if utf8.RuneCountInString(password) < s.config.MinLength {
+		return ErrPasswordTooShort
+	}
+
+	if len(password) > passwordMaxBytes {
+		return ErrPasswordTooLong
+	}
+
+	if passwordCharClasses(password) < s.config.MinCharClasses {
+		return ErrPasswordTooSimple
+	}
+
+	if containsPersonalData(password, userName, email) {
+		return ErrPasswordContainsPersonalData
+	}
+
+	if s.breached != nil && s.breached.Contains(password) {
+		return ErrPasswordBreached
+	}
+
+	return nil
+}
+
+// passwordCharClasses Количество типов символов в пароле
+func passwordCharClasses(password string) int {
+	var lower, upper, digit, other bool
+
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			other = true
+		}
+	}
+
+	count := 0
+	for _, ok := range []bool{lower, upper, digit, other} {
+		if ok {
+			count++
+		}
+	}
+
+	return count
+}
+
+// containsPersonalData Проверить, есть ли в пароле имя пользователя, e-mail или имя ящика из e-mail
+func containsPersonalData(password, userName, email string) bool {
+	password = strings.ToLower(password)
+	localPart, _, _ := strings.Cut(email, "@")
+
+	for _, value := range []string{userName, email, localPart} {
+		value = strings.ToLower(strings.TrimSpace(value))
+
+		if utf8.RuneCountInString(value) >= personalDataMinLength && strings.Contains(password, value) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"palback/internal/infra/breach"
+)
+
+func TestPasswordPolicyCheck(t *testing.T) {
+	corpus, err := breach.Load("")
+	if err != nil {
+		t.Fatalf("ошибка загрузки встроенного списка утекших паролей: %v", err)
+	}
+
+	simple := PasswordPolicyConfig{MinLength: 6, MinCharClasses: 1}
+	strict := PasswordPolicyConfig{MinLength: 10, MinCharClasses: 3}
+
+	tests := []struct {
+		name     string
+		config   PasswordPolicyConfig
+		password string
+		userName string
+		email    string
+		want     error
+	}{
+		{name: "подходящий пароль", config: strict, password: "Kx9#mPq2Lw", want: nil},
+		{name: "короче минимума", config: strict, password: "Kx9#mPq2L", want: ErrPasswordTooShort},
+		{name: "длина в символах, а не в байтах", config: strict, password: "Пароль№1Яx", want: nil},
+		{name: "кириллица короче минимума", config: strict, password: "Пароль№1Я", want: ErrPasswordTooShort},
+		{name: "длиннее 72 байт", config: strict, password: "Kx9#" + strings.Repeat("ж", 35), want: ErrPasswordTooLong},
+		{name: "мало типов символов", config: strict, password: "kx9mpq2lwz", want: ErrPasswordTooSimple},
+		{name: "утекший пароль", config: simple, password: "qwerty123", want: ErrPasswordBreached},
+		{name: "утекший пароль на кириллице", config: simple, password: "йцукен", want: ErrPasswordBreached},
+		{name: "утекший пароль с цифрами на кириллице", config: simple, password: "йцукен123", want: ErrPasswordBreached},
+		{name: "регистр важен для списка утечек", config: simple, password: "ЙЦУКЕН", want: nil},
+		{
+			name: "имя пользователя в пароле", config: strict, password: "Kx9#Pilgrim2",
+			userName: "pilgrim", email: "someone@example.com", want: ErrPasswordContainsPersonalData,
+		},
+		{
+			name: "имя ящика в пароле", config: strict, password: "Kx9#Wanderer2",
+			userName: "pilgrim", email: "wanderer@example.com", want: ErrPasswordContainsPersonalData,
+		},
+		{
+			name: "e-mail в пароле", config: strict, password: "W@example.com1",
+			userName: "pilgrim", email: "w@example.com", want: ErrPasswordContainsPersonalData,
+		},
+		{
+			name: "короткое имя не проверяется", config: strict, password: "Kx9#Ab2Lwz",
+			userName: "ab", email: "ab@example.com", want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := NewPasswordPolicyUseCase(tt.config, corpus)
+
+			err := policy.Check(context.Background(), tt.password, tt.userName, tt.email)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("ожидалась %v, получено %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,330 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"palback/internal/domain/model"
+	localErrors "palback/internal/pkg/errors"
+	"palback/internal/pkg/helpers"
+	ucModel "palback/internal/usecase/model"
+	"palback/internal/usecase/port"
+)
+
+const (
+	defaultPlaceListLimit = 50
+	maxPlaceListLimit     = 200
+	maxPlaceNearbyRadius  = 500
+)
+
+type PlaceUseCase struct {
+	placeTypeService PlaceTypeService
+	cityService      CityService
+	revisionService  RevisionService
+	repo             port.PlaceRepo
+}
+
+func NewPlaceUseCase(
+	placeTypeService PlaceTypeService,
+	cityService CityService,
+	revisionService RevisionService,
+	repo port.PlaceRepo,
+) *PlaceUseCase {
+	return &PlaceUseCase{
+		placeTypeService: placeTypeService,
+		cityService:      cityService,
+		revisionService:  revisionService,
+		repo:             repo,
+	}
+}
+
+func (s *PlaceUseCase) Get(ctx context.Context, id int) (*ucModel.PlaceDetail, error) {
+	place, err := s.getPlace(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.detail(ctx, helpers.FromPtr(place))
+}
+
+func (s *PlaceUseCase) GetList(ctx context.Context, filter model.PlaceFilter) (result ucModel.PlaceList, err error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultPlaceListLimit
+	}
+
+	if filter.Limit > maxPlaceListLimit {
+		filter.Limit = maxPlaceListLimit
+	}
+
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	if filter.BoundingBox != nil && !filter.BoundingBox.IsValid() {
+		return result, ErrInvalidBoundingBox
+	}
+
+	places, err := s.repo.GetList(ctx, filter)
+	if err != nil {
+		return result, fmt.Errorf("ошибка при получении списка святых мест: %w", err)
+	}
+
+	placeTypes, cities, err := s.references(ctx, places)
+	if err != nil {
+		return result, err
+	}
+
+	result = ucModel.CreatePlaceList(places, placeTypes, cities)
+
+	return result, nil
+}
+
+func (s *PlaceUseCase) GetNearby(
+	ctx context.Context,
+	filter model.PlaceNearbyFilter,
+) (result ucModel.PlaceNearbyList, err error) {
+	if !validCoordinates(filter.Latitude, filter.Longitude) {
+		return result, ErrInvalidCoordinates
+	}
+
+	if filter.RadiusKm <= 0 || filter.RadiusKm > maxPlaceNearbyRadius {
+		return result, ErrPlaceInvalidRadius
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultPlaceListLimit
+	}
+
+	if filter.Limit > maxPlaceListLimit {
+		filter.Limit = maxPlaceListLimit
+	}
+
+	places, err := s.repo.GetNearby(ctx, filter)
+	if err != nil {
+		return result, fmt.Errorf("ошибка при поиске святых мест поблизости: %w", err)
+	}
+
+	plain := make([]model.Place, 0, len(places))
+	for _, place := range places {
+		plain = append(plain, place.Place)
+	}
+
+	placeTypes, cities, err := s.references(ctx, plain)
+	if err != nil {
+		return result, err
+	}
+
+	result = ucModel.CreatePlaceNearbyList(places, placeTypes, cities)
+
+	return result, nil
+}
+
+func (s *PlaceUseCase) Create(ctx context.Context, place model.Place) (*ucModel.PlaceDetail, error) {
+	if place.Status == "" {
+		place.Status = model.PlaceStatusActive
+	}
+
+	placeType, city, err := s.checkPlace(ctx, place)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.repo.Create(ctx, place)
+
+	if err != nil {
+		switch {
+		default:
+			return nil, fmt.Errorf("ошибка добавления святого места: %w", err)
+		}
+	}
+
+	s.revisionService.Record(ctx, model.RevisionEntityPlace, strconv.Itoa(created.ID), model.RevisionActionCreate, nil, created)
+
+	result := ucModel.CreatePlaceDetail(helpers.FromPtr(created), helpers.FromPtr(placeType), helpers.FromPtr(city))
+
+	return &result, nil
+}
+
+func (s *PlaceUseCase) Update(ctx context.Context, id int, place model.Place) error {
+	return s.update(ctx, id, place, model.RevisionActionUpdate)
+}
+
+func (s *PlaceUseCase) update(ctx context.Context, id int, place model.Place, action model.RevisionAction) error {
+	if place.Status == "" {
+		place.Status = model.PlaceStatusActive
+	}
+
+	before, err := s.getPlace(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = s.checkPlace(ctx, place)
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.Update(ctx, id, place)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, localErrors.ErrNotFound):
+			return ErrPlaceNotFound
+		default:
+			return fmt.Errorf("ошибка обновления святого места: %w", err)
+		}
+	}
+
+	place.ID = id
+	s.revisionService.Record(ctx, model.RevisionEntityPlace, strconv.Itoa(id), action, before, place)
+
+	return nil
+}
+
+func (s *PlaceUseCase) Delete(ctx context.Context, id int) error {
+	before, err := s.getPlace(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.Delete(ctx, id)
+
+	if errors.Is(err, localErrors.ErrNotFound) {
+		return ErrPlaceNotFound
+	}
+
+	if err != nil {
+		return fmt.Errorf("ошибка удаления святого места: %w", err)
+	}
+
+	s.revisionService.Record(ctx, model.RevisionEntityPlace, strconv.Itoa(id), model.RevisionActionDelete, before, nil)
+
+	return nil
+}
+
+func (s *PlaceUseCase) GetRevisions(ctx context.Context, id int) (ucModel.RevisionList, error) {
+	return s.revisionService.GetByEntity(ctx, model.RevisionEntityPlace, strconv.Itoa(id))
+}
+
+// Restore Вернуть святое место к состоянию после указанной ревизии. Удаленное место так не восстанавливается.
+func (s *PlaceUseCase) Restore(ctx context.Context, id int, revisionID int) error {
+	place, err := revisionSnapshot[model.Place](
+		ctx,
+		s.revisionService,
+		model.RevisionEntityPlace,
+		strconv.Itoa(id),
+		revisionID,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = s.update(ctx, id, *place, model.RevisionActionRestore)
+	if errors.Is(err, ErrPlaceNotFound) {
+		return ErrRevisionEntityDeleted
+	}
+
+	return err
+}
+
+func (s *PlaceUseCase) getPlace(ctx context.Context, id int) (*model.Place, error) {
+	place, err := s.repo.Get(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, localErrors.ErrNotFound):
+			return nil, ErrPlaceNotFound
+		default:
+			return nil, fmt.Errorf("ошибка получения святого места по id: %w", err)
+		}
+	}
+
+	return place, nil
+}
+
+// Check Проверить корректность данных святого места без сохранения
+func (s *PlaceUseCase) Check(ctx context.Context, place model.Place) error {
+	if place.Status == "" {
+		place.Status = model.PlaceStatusActive
+	}
+
+	_, _, err := s.checkPlace(ctx, place)
+
+	return err
+}
+
+// checkPlace Проверить данные святого места и получить его тип и населенный пункт
+func (s *PlaceUseCase) checkPlace(
+	ctx context.Context,
+	place model.Place,
+) (*model.PlaceType, *ucModel.CityDetail, error) {
+	if !place.Status.IsValid() {
+		return nil, nil, ErrPlaceInvalidStatus
+	}
+
+	if !validCoordinates(place.Latitude, place.Longitude) {
+		return nil, nil, ErrInvalidCoordinates
+	}
+
+	if place.FoundingYear != nil && *place.FoundingYear > time.Now().Year() {
+		return nil, nil, ErrPlaceInvalidFoundingYear
+	}
+
+	placeType, err := s.placeTypeService.Get(ctx, place.PlaceTypeID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ошибка проверки типа святого места: %w", err)
+	}
+
+	city, err := s.cityService.Get(ctx, place.CityID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ошибка проверки населенного пункта святого места: %w", err)
+	}
+
+	return placeType, city, nil
+}
+
+// detail Собрать детальную информацию о святом месте
+func (s *PlaceUseCase) detail(ctx context.Context, place model.Place) (*ucModel.PlaceDetail, error) {
+	placeType, err := s.placeTypeService.Get(ctx, place.PlaceTypeID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения типа святого места по id: %w", err)
+	}
+
+	city, err := s.cityService.Get(ctx, place.CityID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения населенного пункта по id: %w", err)
+	}
+
+	result := ucModel.CreatePlaceDetail(place, helpers.FromPtr(placeType), helpers.FromPtr(city))
+
+	return &result, nil
+}
+
+// references Получить типы и населенные пункты, на которые ссылаются святые места
+func (s *PlaceUseCase) references(
+	ctx context.Context,
+	places []model.Place,
+) ([]model.PlaceType, map[int]ucModel.CityDetail, error) {
+	placeTypes, err := s.placeTypeService.GetAll(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ошибка при получении списка типов святых мест: %w", err)
+	}
+
+	cities := make(map[int]ucModel.CityDetail)
+	for _, place := range places {
+		if _, ok := cities[place.CityID]; ok {
+			continue
+		}
+
+		city, err := s.cityService.Get(ctx, place.CityID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("ошибка получения населенного пункта по id: %w", err)
+		}
+
+		cities[place.CityID] = helpers.FromPtr(city)
+	}
+
+	return placeTypes, cities, nil
+}
@@ -0,0 +1,263 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+
+	"palback/internal/domain/model"
+	localErrors "palback/internal/pkg/errors"
+	"palback/internal/pkg/token"
+	"palback/internal/usecase/port"
+)
+
+type PlacePhotoUseCase struct {
+	placeService PlaceService
+	userService  UserService
+	storage      port.FileStorage
+	images       port.ImageProcessor
+	maxSize      int64
+	repo         port.PlacePhotoRepo
+}
+
+func NewPlacePhotoUseCase(
+	placeService PlaceService,
+	userService UserService,
+	storage port.FileStorage,
+	images port.ImageProcessor,
+	maxSize int64,
+	repo port.PlacePhotoRepo,
+) *PlacePhotoUseCase {
+	return &PlacePhotoUseCase{
+		placeService: placeService,
+		userService:  userService,
+		storage:      storage,
+		images:       images,
+		maxSize:      maxSize,
+		repo:         repo,
+	}
+}
+
+func (s *PlacePhotoUseCase) GetByPlace(ctx context.Context, placeID int) ([]model.PlacePhoto, error) {
+	if _, err := s.placeService.Get(ctx, placeID); err != nil {
+		return nil, fmt.Errorf("ошибка получения святого места: %w", err)
+	}
+
+	photos, err := s.repo.GetByPlace(ctx, placeID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении фотографий святого места: %w", err)
+	}
+
+	return photos, nil
+}
+
+// Upload Загрузить фотографию святого места. Тип файла определяется по содержимому, а не по заголовкам запроса.
+// Кроме исходного файла в хранилище сохраняются уменьшенные варианты без метаданных.
+func (s *PlacePhotoUseCase) Upload(
+	ctx context.Context,
+	photo model.PlacePhoto,
+	data io.Reader,
+) (*model.PlacePhoto, error) {
+	if _, err := s.placeService.Get(ctx, photo.PlaceID); err != nil {
+		return nil, fmt.Errorf("ошибка получения святого места: %w", err)
+	}
+
+	img, err := readImage(data, photo.Size, s.maxSize)
+	if err != nil {
+		return nil, err
+	}
+
+	photo.Size = int64(len(img.Data))
+	photo.ContentType = img.ContentType
+
+	variants, err := s.images.Variants(img.Data, model.PhotoVariantSpecs)
+	if err != nil {
+		return nil, variantsError(err)
+	}
+
+	name, err := token.GenerateVerificationToken()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка генерации имени файла: %w", err)
+	}
+
+	photo.ObjectKey = fmt.Sprintf("places/%d/%s%s", photo.PlaceID, name, img.Extension)
+
+	err = s.storage.Save(ctx, photo.ObjectKey, bytes.NewReader(img.Data), photo.Size)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка сохранения фотографии в хранилище: %w", err)
+	}
+
+	for _, variant := range variants {
+		key := photo.VariantKey(variant.Name, variant.Format)
+
+		err = s.storage.Save(ctx, key, bytes.NewReader(variant.Data), int64(len(variant.Data)))
+		if err != nil {
+			s.deleteObjects(ctx, photo)
+			return nil, fmt.Errorf("ошибка сохранения варианта фотографии в хранилище: %w", err)
+		}
+	}
+
+	created, err := s.repo.Create(ctx, photo)
+	if err != nil {
+		s.deleteObjects(ctx, photo)
+		return nil, fmt.Errorf("ошибка добавления фотографии: %w", err)
+	}
+
+	return created, nil
+}
+
+// Download Получить вариант фотографии вместе с потоком его содержимого. Поток должен закрыть вызывающий.
+// Исходный файл не отдается, так как может содержать метаданные с координатами съемки.
+func (s *PlacePhotoUseCase) Download(
+	ctx context.Context,
+	placeID, id int,
+	variant model.ImageVariantName,
+	format model.ImageFormat,
+) (io.ReadCloser, error) {
+	if !model.IsPhotoVariant(variant) {
+		return nil, ErrPhotoNotFound
+	}
+
+	photo, err := s.get(ctx, placeID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := s.storage.Get(ctx, photo.VariantKey(variant, format))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения фотографии из хранилища: %w", err)
+	}
+
+	return data, nil
+}
+
+// Update Изменить подпись, лицензию и порядок фотографии. Доступно автору или администратору.
+func (s *PlacePhotoUseCase) Update(ctx context.Context, placeID, id, userID int, data model.PlacePhoto) error {
+	photo, err := s.getEditable(ctx, placeID, id, userID)
+	if err != nil {
+		return err
+	}
+
+	photo.Caption = data.Caption
+	photo.License = data.License
+
+	if data.Position > 0 {
+		photo.Position = data.Position
+	}
+
+	err = s.repo.Update(ctx, id, *photo)
+	if err != nil {
+		switch {
+		case errors.Is(err, localErrors.ErrNotFound):
+			return ErrPhotoNotFound
+		default:
+			return fmt.Errorf("ошибка обновления фотографии: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// SetCover Сделать фотографию обложкой святого места. Доступно автору или администратору.
+func (s *PlacePhotoUseCase) SetCover(ctx context.Context, placeID, id, userID int) error {
+	if _, err := s.getEditable(ctx, placeID, id, userID); err != nil {
+		return err
+	}
+
+	err := s.repo.SetCover(ctx, placeID, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, localErrors.ErrNotFound):
+			return ErrPhotoNotFound
+		default:
+			return fmt.Errorf("ошибка установки обложки святого места: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Delete Удалить фотографию из галереи и файл из хранилища. Доступно автору или администратору.
+func (s *PlacePhotoUseCase) Delete(ctx context.Context, placeID, id, userID int) error {
+	photo, err := s.getEditable(ctx, placeID, id, userID)
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.Delete(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, localErrors.ErrNotFound):
+			return ErrPhotoNotFound
+		default:
+			return fmt.Errorf("ошибка удаления фотографии: %w", err)
+		}
+	}
+
+	s.deleteObjects(ctx, *photo)
+
+	return nil
+}
+
+// get Получить фотографию, проверив, что она относится к указанному святому месту
+func (s *PlacePhotoUseCase) get(ctx context.Context, placeID, id int) (*model.PlacePhoto, error) {
+	photo, err := s.repo.Get(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, localErrors.ErrNotFound):
+			return nil, ErrPhotoNotFound
+		default:
+			return nil, fmt.Errorf("ошибка получения фотографии по id: %w", err)
+		}
+	}
+
+	if photo.PlaceID != placeID {
+		return nil, ErrPhotoNotFound
+	}
+
+	return photo, nil
+}
+
+// getEditable Получить фотографию, которую пользователь может изменять
+func (s *PlacePhotoUseCase) getEditable(ctx context.Context, placeID, id, userID int) (*model.PlacePhoto, error) {
+	photo, err := s.get(ctx, placeID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if photo.AuthorID != nil && *photo.AuthorID == userID {
+		return photo, nil
+	}
+
+	role, err := s.userService.GetRole(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения роли пользователя: %w", err)
+	}
+
+	if role == nil || !role.HasPermission(model.PermissionPlacesModerate) {
+		return nil, ErrPhotoForbidden
+	}
+
+	return photo, nil
+}
+
+// deleteObjects Удалить из хранилища исходный файл фотографии и все его варианты.
+// Ошибки только логируются: запись о фотографии к этому моменту уже удалена или не создана.
+func (s *PlacePhotoUseCase) deleteObjects(ctx context.Context, photo model.PlacePhoto) {
+	keys := []string{photo.ObjectKey}
+
+	for _, spec := range model.PhotoVariantSpecs {
+		for _, format := range model.ImageFormats {
+			keys = append(keys, photo.VariantKey(spec.Name, format))
+		}
+	}
+
+	for _, key := range keys {
+		if err := s.storage.Delete(ctx, key); err != nil {
+			log.Printf("Ошибка удаления файла %s из хранилища: %v", key, err)
+		}
+	}
+}
@@ -0,0 +1,300 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"palback/internal/domain/model"
+	localErrors "palback/internal/pkg/errors"
+	ucModel "palback/internal/usecase/model"
+	"palback/internal/usecase/port"
+)
+
+type PlaceSubmissionUseCase struct {
+	placeService    PlaceService
+	userService     UserService
+	revisionService RevisionService
+	outbox          EmailOutboxService
+	repo            port.PlaceSubmissionRepo
+}
+
+func NewPlaceSubmissionUseCase(
+	placeService PlaceService,
+	userService UserService,
+	revisionService RevisionService,
+	outbox EmailOutboxService,
+	repo port.PlaceSubmissionRepo,
+) *PlaceSubmissionUseCase {
+	return &PlaceSubmissionUseCase{
+		placeService:    placeService,
+		userService:     userService,
+		revisionService: revisionService,
+		outbox:          outbox,
+		repo:            repo,
+	}
+}
+
+// Get Получить предложение. Просматривать его может автор или администратор.
+func (s *PlaceSubmissionUseCase) Get(ctx context.Context, id, userID int) (*model.PlaceSubmission, error) {
+	submission, err := s.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if submission.AuthorID != nil && *submission.AuthorID == userID {
+		return submission, nil
+	}
+
+	role, err := s.userService.GetRole(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения роли пользователя: %w", err)
+	}
+
+	if role == nil || !role.HasPermission(model.PermissionPlacesModerate) {
+		return nil, ErrSubmissionForbidden
+	}
+
+	return submission, nil
+}
+
+func (s *PlaceSubmissionUseCase) GetByAuthor(ctx context.Context, authorID int) ([]model.PlaceSubmission, error) {
+	submissions, err := s.repo.GetByAuthor(ctx, authorID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении списка предложений пользователя: %w", err)
+	}
+
+	return submissions, nil
+}
+
+// GetQueue Получить очередь предложений, ожидающих модерации
+func (s *PlaceSubmissionUseCase) GetQueue(ctx context.Context) ([]model.PlaceSubmission, error) {
+	submissions, err := s.repo.GetByState(ctx, model.SubmissionStatePending)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении очереди модерации: %w", err)
+	}
+
+	return submissions, nil
+}
+
+// Create Создать черновик предложения нового святого места или правки существующего
+func (s *PlaceSubmissionUseCase) Create(
+	ctx context.Context,
+	submission model.PlaceSubmission,
+) (*model.PlaceSubmission, error) {
+	if submission.PlaceID != nil {
+		if _, err := s.placeService.Get(ctx, *submission.PlaceID); err != nil {
+			return nil, fmt.Errorf("ошибка проверки изменяемого святого места: %w", err)
+		}
+	}
+
+	if submission.Place.Status == "" {
+		submission.Place.Status = model.PlaceStatusActive
+	}
+
+	submission.State = model.SubmissionStateDraft
+
+	result, err := s.repo.Create(ctx, submission)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка добавления предложения: %w", err)
+	}
+
+	return result, nil
+}
+
+// Update Изменить данные предложения. Отклоненное предложение после изменения снова становится черновиком.
+func (s *PlaceSubmissionUseCase) Update(ctx context.Context, id, userID int, place model.Place) error {
+	submission, err := s.getOwn(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if !submission.State.IsEditable() {
+		return ErrSubmissionNotEditable
+	}
+
+	if place.Status == "" {
+		place.Status = model.PlaceStatusActive
+	}
+
+	from := submission.State
+	submission.Place = place
+	submission.State = model.SubmissionStateDraft
+	submission.RejectReason = ""
+
+	return s.update(ctx, id, from, *submission)
+}
+
+// Submit Отправить черновик на модерацию
+func (s *PlaceSubmissionUseCase) Submit(ctx context.Context, id, userID int) error {
+	submission, err := s.getOwn(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if !submission.State.CanTransitionTo(model.SubmissionStatePending) {
+		return ErrSubmissionInvalidState
+	}
+
+	err = s.placeService.Check(ctx, submission.Place)
+	if err != nil {
+		return err
+	}
+
+	from := submission.State
+	submission.State = model.SubmissionStatePending
+
+	if err = s.update(ctx, id, from, *submission); err != nil {
+		return err
+	}
+
+	s.notify(ctx, *submission)
+
+	return nil
+}
+
+// Approve Одобрить предложение и опубликовать святое место. Место и состояние предложения сохраняются
+// в одной транзакции, поэтому при ошибке предложение остается на модерации и его можно одобрить повторно.
+func (s *PlaceSubmissionUseCase) Approve(ctx context.Context, id, moderatorID int) (*ucModel.PlaceDetail, error) {
+	submission, err := s.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !submission.State.CanTransitionTo(model.SubmissionStatePublished) {
+		return nil, ErrSubmissionInvalidState
+	}
+
+	err = s.placeService.Check(ctx, submission.Place)
+	if err != nil {
+		return nil, err
+	}
+
+	published, before, err := s.repo.Publish(ctx, id, moderatorID, submission.State)
+	if err != nil {
+		switch {
+		case errors.Is(err, localErrors.ErrNotFound):
+			// Предложение успели одобрить, отклонить или изменить, либо удалено святое место
+			return nil, ErrSubmissionInvalidState
+		default:
+			return nil, fmt.Errorf("ошибка публикации святого места: %w", err)
+		}
+	}
+
+	placeID := strconv.Itoa(*published.PlaceID)
+	if before == nil {
+		s.revisionService.Record(ctx, model.RevisionEntityPlace, placeID, model.RevisionActionCreate, nil, published.Place)
+	} else {
+		s.revisionService.Record(ctx, model.RevisionEntityPlace, placeID, model.RevisionActionUpdate, before, published.Place)
+	}
+
+	s.notify(ctx, *published)
+
+	place, err := s.placeService.Get(ctx, *published.PlaceID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения опубликованного святого места: %w", err)
+	}
+
+	return place, nil
+}
+
+// Reject Отклонить предложение с указанием причины
+func (s *PlaceSubmissionUseCase) Reject(ctx context.Context, id, moderatorID int, reason string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ErrSubmissionReasonRequired
+	}
+
+	submission, err := s.get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if !submission.State.CanTransitionTo(model.SubmissionStateRejected) {
+		return ErrSubmissionInvalidState
+	}
+
+	from := submission.State
+	submission.State = model.SubmissionStateRejected
+	submission.RejectReason = reason
+	submission.ModeratorID = &moderatorID
+
+	if err = s.update(ctx, id, from, *submission); err != nil {
+		return err
+	}
+
+	s.notify(ctx, *submission)
+
+	return nil
+}
+
+func (s *PlaceSubmissionUseCase) get(ctx context.Context, id int) (*model.PlaceSubmission, error) {
+	submission, err := s.repo.Get(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, localErrors.ErrNotFound):
+			return nil, ErrSubmissionNotFound
+		default:
+			return nil, fmt.Errorf("ошибка получения предложения по id: %w", err)
+		}
+	}
+
+	return submission, nil
+}
+
+// update Сохранить предложение, если с момента чтения его состояние не изменилось.
+// Иначе одновременное одобрение и отклонение могли бы перезаписать друг друга.
+func (s *PlaceSubmissionUseCase) update(
+	ctx context.Context,
+	id int,
+	from model.SubmissionState,
+	submission model.PlaceSubmission,
+) error {
+	err := s.repo.Update(ctx, id, from, submission)
+	if err != nil {
+		switch {
+		case errors.Is(err, localErrors.ErrNotFound):
+			return ErrSubmissionInvalidState
+		default:
+			return fmt.Errorf("ошибка обновления предложения: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// getOwn Получить предложение, проверив, что его автор — указанный пользователь
+func (s *PlaceSubmissionUseCase) getOwn(ctx context.Context, id, userID int) (*model.PlaceSubmission, error) {
+	submission, err := s.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if submission.AuthorID == nil || *submission.AuthorID != userID {
+		return nil, ErrSubmissionForbidden
+	}
+
+	return submission, nil
+}
+
+// notify Уведомить автора об изменении состояния предложения.
+// Ошибка постановки письма в очередь не отменяет смену состояния.
+func (s *PlaceSubmissionUseCase) notify(ctx context.Context, submission model.PlaceSubmission) {
+	if submission.AuthorID == nil {
+		return
+	}
+
+	author, err := s.userService.Get(ctx, *submission.AuthorID)
+	if err != nil {
+		log.Printf("Ошибка получения автора предложения %d: %v", submission.ID, err)
+		return
+	}
+
+	err = s.outbox.Enqueue(ctx, submissionStateEmail(author.Email, submission))
+	if err != nil {
+		log.Printf("Ошибка постановки уведомления о предложении %d на %s в очередь: %v", submission.ID, author.Email, err)
+	}
+}
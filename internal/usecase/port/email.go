@@ -1,6 +1,16 @@
 package port
 
+import (
+	"time"
+
+	"palback/internal/domain/model"
+)
+
 type EmailSender interface {
 	SendVerificationEmail(toEmail, token string) error
 	SendPasswordResetEmail(toEmail, token string) error
+	SendEmailChangeEmail(toEmail, token string) error
+	SendSubmissionStateEmail(toEmail, placeName string, state model.SubmissionState, reason string) error
+	SendAccountDeletionEmail(toEmail string, deleteAt time.Time) error
+	SendAccountLockedEmail(toEmail, token string, lockedUntil time.Time) error
 }
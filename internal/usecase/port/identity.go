@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+
+	"palback/internal/domain/model"
+)
+
+// IdentityProvider Внешний провайдер входа по OAuth2 authorization code с PKCE
+type IdentityProvider interface {
+	// Name Короткое имя провайдера, используемое в адресах
+	Name() string
+
+	// AuthCodeURL Адрес страницы входа провайдера
+	AuthCodeURL(state, nonce, codeVerifier string) string
+
+	// Exchange Обменять код авторизации на подтвержденные данные пользователя
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*model.ExternalIdentity, error)
+}
@@ -0,0 +1,7 @@
+package port
+
+import "palback/internal/domain/model"
+
+type ImageProcessor interface {
+	Variants(data []byte, specs []model.ImageVariantSpec) ([]model.ImageVariant, error)
+}
@@ -0,0 +1,6 @@
+package port
+
+// BreachedPasswords Список паролей из утечек данных
+type BreachedPasswords interface {
+	Contains(password string) bool
+}
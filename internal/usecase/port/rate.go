@@ -1,7 +1,11 @@
 package port
 
-import "context"
+import (
+	"context"
+
+	"palback/internal/domain/model"
+)
 
 type RateLimiter interface {
-	Allow(ctx context.Context, key string, limit int, windowSeconds int) (bool, error)
+	Allow(ctx context.Context, key string, limit int, windowSeconds int) (model.RateLimitResult, error)
 }
@@ -2,6 +2,7 @@ package port
 
 import (
 	"context"
+	"time"
 
 	"palback/internal/domain/model"
 )
@@ -28,25 +29,124 @@ type CityTypeRepo interface {
 	GetAll(context.Context) ([]model.CityType, error)
 }
 
+type CityRepo interface {
+	Get(context.Context, int) (*model.City, error)
+	GetByCountry(context.Context, string) ([]model.City, error)
+	Create(context.Context, model.City) (*model.City, error)
+	Update(context.Context, int, model.City) error
+	Delete(context.Context, int) error
+}
+
 type PlaceTypeRepo interface {
 	Get(context.Context, int) (*model.PlaceType, error)
 	GetAll(context.Context) ([]model.PlaceType, error)
 }
 
+type PlaceRepo interface {
+	Get(context.Context, int) (*model.Place, error)
+	GetList(context.Context, model.PlaceFilter) ([]model.Place, error)
+	GetNearby(context.Context, model.PlaceNearbyFilter) ([]model.PlaceWithDistance, error)
+	Create(context.Context, model.Place) (*model.Place, error)
+	Update(context.Context, int, model.Place) error
+	Delete(context.Context, int) error
+}
+
+type PlaceSubmissionRepo interface {
+	Get(context.Context, int) (*model.PlaceSubmission, error)
+	GetByAuthor(context.Context, int) ([]model.PlaceSubmission, error)
+	GetByState(context.Context, model.SubmissionState) ([]model.PlaceSubmission, error)
+	Create(context.Context, model.PlaceSubmission) (*model.PlaceSubmission, error)
+	Update(ctx context.Context, id int, from model.SubmissionState, submission model.PlaceSubmission) error
+	Publish(ctx context.Context, id, moderatorID int, from model.SubmissionState) (*model.PlaceSubmission, *model.Place, error)
+}
+
+type PlacePhotoRepo interface {
+	Get(context.Context, int) (*model.PlacePhoto, error)
+	GetByPlace(context.Context, int) ([]model.PlacePhoto, error)
+	Create(context.Context, model.PlacePhoto) (*model.PlacePhoto, error)
+	Update(context.Context, int, model.PlacePhoto) error
+	SetCover(ctx context.Context, placeID, id int) error
+	Delete(context.Context, int) error
+}
+
+type RevisionRepo interface {
+	Get(context.Context, int) (*model.Revision, error)
+	GetByEntity(context.Context, model.RevisionEntity, string) ([]model.Revision, error)
+	Create(context.Context, model.Revision) (*model.Revision, error)
+}
+
 type UserRepo interface {
 	Get(context.Context, int) (*model.User, error)
 	GetByIdentifier(ctx context.Context, identifier string) (*model.User, error)
 	GetByEmail(ctx context.Context, email string) (*model.User, error)
-	GetAll(context.Context) ([]model.User, error)
+	GetList(context.Context, model.UserFilter) ([]model.User, error)
+	Count(context.Context, model.UserFilter) (int, error)
+	GetDeletionDue(ctx context.Context, requestedBefore time.Time) ([]model.User, error)
+	GetWithLoginFailures(context.Context) ([]model.User, error)
 	Create(context.Context, model.User) (*model.User, error)
+	CreateWithEmail(ctx context.Context, user model.User, message model.EmailMessage) (*model.User, error)
 	Delete(context.Context, int) error
+	DeleteDeletionDue(ctx context.Context, id int, requestedBefore time.Time) error
 	UpdateEmailVerified(ctx context.Context, email string) error
 	UpdatePassword(ctx context.Context, email, hashedPassword string) error
+	UpdateEmail(ctx context.Context, id int, email string) error
 	IncrementSessionVersion(ctx context.Context, email string) error
+	UpdateRole(ctx context.Context, id int, roleID model.RoleID) error
+	UpdateBlocked(ctx context.Context, id int, blockedAt *time.Time) error
+	UpdateProfile(ctx context.Context, id int, profile model.UserProfile) error
+	UpdateAvatar(ctx context.Context, id int, avatarKey string) error
+	UpdateDeletionRequested(ctx context.Context, id int, requestedAt *time.Time) error
+	IncrementLoginFailures(ctx context.Context, id int) (int, error)
+	UpdateLockedUntil(ctx context.Context, id int, lockedUntil time.Time) error
+	ResetLoginFailures(ctx context.Context, id int) error
+	UpdateTwoFactor(ctx context.Context, id int, secret string, recoveryCodeHashes []string) error
+	UpdateRecoveryCodes(ctx context.Context, id int, recoveryCodeHashes []string) error
+	UseRecoveryCode(ctx context.Context, id int, codeHash string) error
+}
+
+type AccessTokenRepo interface {
+	GetByHash(ctx context.Context, tokenHash string) (*model.AccessToken, error)
+	GetByUser(ctx context.Context, userID int) ([]model.AccessToken, error)
+	Create(context.Context, model.AccessToken) (*model.AccessToken, error)
+	UpdateLastUsed(ctx context.Context, id int) error
+	Delete(ctx context.Context, userID, id int) error
+}
+
+type IdentityRepo interface {
+	Get(context.Context, int) (*model.Identity, error)
+	GetBySubject(ctx context.Context, provider, subject string) (*model.Identity, error)
+	GetByUser(ctx context.Context, userID int) ([]model.Identity, error)
+	Create(context.Context, model.Identity) (*model.Identity, error)
+	Delete(context.Context, int) error
 }
 
 type RoleRepo interface {
 	Get(context.Context, model.RoleID) (*model.Role, error)
 	GetAll(context.Context) ([]model.Role, error)
 	GetAllMap(context.Context) (map[model.RoleID]*model.Role, error)
+	UpdatePermissions(ctx context.Context, id model.RoleID, permissions []model.Permission) error
+}
+
+type AuditRepo interface {
+	GetList(context.Context, model.AuditFilter) ([]model.AuditEntry, error)
+	Count(context.Context, model.AuditFilter) (int, error)
+	Create(context.Context, model.AuditEntry) error
+}
+
+type TranslationRepo interface {
+	GetByEntity(ctx context.Context, entity model.TranslationEntity, entityID string) ([]model.Translation, error)
+	Save(context.Context, model.Translation) error
+	Delete(ctx context.Context, entity model.TranslationEntity, entityID, lang string) error
+}
+
+type EmailOutboxRepo interface {
+	Get(context.Context, int) (*model.EmailMessage, error)
+	GetList(context.Context, model.EmailFilter) ([]model.EmailMessage, error)
+	Count(context.Context, model.EmailFilter) (int, error)
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]model.EmailMessage, error)
+	Create(context.Context, model.EmailMessage) error
+	MarkSent(ctx context.Context, id int) error
+	MarkFailed(ctx context.Context, id int, lastError string, retryAfter time.Duration) error
+	MarkDead(ctx context.Context, id int, lastError string) error
+	Requeue(ctx context.Context, id int) error
 }
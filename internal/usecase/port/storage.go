@@ -16,4 +16,14 @@ type KeyValueStorage interface {
 	Set(ctx context.Context, key, value string, expireSeconds int) error
 	Del(ctx context.Context, key string) error
 	Exists(ctx context.Context, key string) (bool, error)
+
+	// SetNX Записать значение, только если ключа еще нет. Возвращает false, если ключ уже был.
+	SetNX(ctx context.Context, key, value string, expireSeconds int) (bool, error)
+
+	// GetDel Получить значение и удалить ключ одной операцией, чтобы его не получили два запроса сразу
+	GetDel(ctx context.Context, key string) (string, error)
+
+	// Incr Увеличить счетчик на единицу и вернуть новое значение. Время жизни задается
+	// при создании ключа и дальнейшими вызовами не продлевается.
+	Incr(ctx context.Context, key string, expireSeconds int) (int, error)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 
 	"palback/internal/domain/model"
 	localErrors "palback/internal/pkg/errors"
@@ -13,14 +14,20 @@ import (
 )
 
 type RegionUseCase struct {
-	countryService CountryService
-	repo           port.RegionRepo
+	countryService  CountryService
+	revisionService RevisionService
+	repo            port.RegionRepo
 }
 
-func NewRegionUseCase(countryService CountryService, repo port.RegionRepo) *RegionUseCase {
+func NewRegionUseCase(
+	countryService CountryService,
+	revisionService RevisionService,
+	repo port.RegionRepo,
+) *RegionUseCase {
 	return &RegionUseCase{
-		countryService: countryService,
-		repo:           repo,
+		countryService:  countryService,
+		revisionService: revisionService,
+		repo:            repo,
 	}
 }
 
@@ -83,12 +90,28 @@ func (s *RegionUseCase) Create(ctx context.Context, region model.Region) (*ucMod
 		}
 	}
 
+	s.revisionService.Record(ctx, model.RevisionEntityRegion, strconv.Itoa(reg.ID), model.RevisionActionCreate, nil, reg)
+
 	result := ucModel.CreateRegionDetail(helpers.FromPtr(reg), helpers.FromPtr(country))
 
 	return &result, nil
 }
 
 func (s *RegionUseCase) Update(ctx context.Context, id int, region model.Region) error {
+	return s.update(ctx, id, region, model.RevisionActionUpdate)
+}
+
+func (s *RegionUseCase) update(ctx context.Context, id int, region model.Region, action model.RevisionAction) error {
+	before, err := s.repo.Get(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, localErrors.ErrNotFound):
+			return ErrRegionNotFound
+		default:
+			return fmt.Errorf("ошибка получения региона по id: %w", err)
+		}
+	}
+
 	country, err := s.countryService.Get(ctx, region.CountryID)
 	if err != nil {
 		return fmt.Errorf("ошибка проверки страны на возможность добавления регионов: %w", err)
@@ -109,11 +132,24 @@ func (s *RegionUseCase) Update(ctx context.Context, id int, region model.Region)
 		}
 	}
 
+	region.ID = id
+	s.revisionService.Record(ctx, model.RevisionEntityRegion, strconv.Itoa(id), action, before, region)
+
 	return nil
 }
 
 func (s *RegionUseCase) Delete(ctx context.Context, id int) error {
-	err := s.repo.Delete(ctx, id)
+	before, err := s.repo.Get(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, localErrors.ErrNotFound):
+			return ErrRegionNotFound
+		default:
+			return fmt.Errorf("ошибка получения региона по id: %w", err)
+		}
+	}
+
+	err = s.repo.Delete(ctx, id)
 
 	if errors.Is(err, localErrors.ErrNotFound) {
 		return ErrRegionNotFound
@@ -123,5 +159,32 @@ func (s *RegionUseCase) Delete(ctx context.Context, id int) error {
 		return fmt.Errorf("ошибка удаления региона: %w", err)
 	}
 
+	s.revisionService.Record(ctx, model.RevisionEntityRegion, strconv.Itoa(id), model.RevisionActionDelete, before, nil)
+
 	return nil
 }
+
+func (s *RegionUseCase) GetRevisions(ctx context.Context, id int) (ucModel.RevisionList, error) {
+	return s.revisionService.GetByEntity(ctx, model.RevisionEntityRegion, strconv.Itoa(id))
+}
+
+// Restore Вернуть регион к состоянию после указанной ревизии. Удаленный регион так не восстанавливается.
+func (s *RegionUseCase) Restore(ctx context.Context, id int, revisionID int) error {
+	region, err := revisionSnapshot[model.Region](
+		ctx,
+		s.revisionService,
+		model.RevisionEntityRegion,
+		strconv.Itoa(id),
+		revisionID,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = s.update(ctx, id, *region, model.RevisionActionRestore)
+	if errors.Is(err, ErrRegionNotFound) {
+		return ErrRevisionEntityDeleted
+	}
+
+	return err
+}
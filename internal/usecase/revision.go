@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"palback/internal/domain/model"
+	"palback/internal/pkg/actor"
+	localErrors "palback/internal/pkg/errors"
+	ucModel "palback/internal/usecase/model"
+	"palback/internal/usecase/port"
+)
+
+// revisionAuditActions Действие в журнале аудита для каждого вида изменения справочника
+var revisionAuditActions = map[model.RevisionAction]model.AuditAction{
+	model.RevisionActionCreate:  model.AuditActionCatalogCreate,
+	model.RevisionActionUpdate:  model.AuditActionCatalogUpdate,
+	model.RevisionActionDelete:  model.AuditActionCatalogDelete,
+	model.RevisionActionRestore: model.AuditActionCatalogRestore,
+}
+
+// revisionAuditPayload Подробности изменения справочника для журнала аудита
+type revisionAuditPayload struct {
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+}
+
+type RevisionUseCase struct {
+	audit AuditService
+	repo  port.RevisionRepo
+}
+
+func NewRevisionUseCase(audit AuditService, repo port.RevisionRepo) *RevisionUseCase {
+	return &RevisionUseCase{
+		audit: audit,
+		repo:  repo,
+	}
+}
+
+// Record Записать изменение справочника в историю и в журнал аудита. Автором считается пользователь
+// из контекста запроса. Ошибка записи ревизии не отменяет само изменение, поэтому только логируется.
+func (s *RevisionUseCase) Record(
+	ctx context.Context,
+	entity model.RevisionEntity,
+	entityID string,
+	action model.RevisionAction,
+	before, after any,
+) {
+	revision := model.Revision{
+		Entity:   entity,
+		EntityID: entityID,
+		Action:   action,
+	}
+
+	if userID, ok := actor.UserID(ctx); ok {
+		revision.AuthorID = &userID
+	}
+
+	var err error
+
+	if before != nil {
+		if revision.Before, err = json.Marshal(before); err != nil {
+			log.Printf("Ошибка сериализации ревизии %s %s: %v", entity, entityID, err)
+			return
+		}
+	}
+
+	if after != nil {
+		if revision.After, err = json.Marshal(after); err != nil {
+			log.Printf("Ошибка сериализации ревизии %s %s: %v", entity, entityID, err)
+			return
+		}
+	}
+
+	s.audit.Record(ctx, revisionAuditActions[action], string(entity), entityID, revisionAuditPayload{
+		Before: revision.Before,
+		After:  revision.After,
+	})
+
+	if _, err = s.repo.Create(ctx, revision); err != nil {
+		log.Printf("Ошибка записи ревизии %s %s: %v", entity, entityID, err)
+	}
+}
+
+func (s *RevisionUseCase) GetByEntity(
+	ctx context.Context,
+	entity model.RevisionEntity,
+	entityID string,
+) (result ucModel.RevisionList, err error) {
+	revisions, err := s.repo.GetByEntity(ctx, entity, entityID)
+	if err != nil {
+		return result, fmt.Errorf("ошибка при получении истории изменений: %w", err)
+	}
+
+	result = ucModel.CreateRevisionList(revisions)
+
+	return result, nil
+}
+
+// Get Получить ревизию, проверив, что она относится к указанной записи
+func (s *RevisionUseCase) Get(
+	ctx context.Context,
+	entity model.RevisionEntity,
+	entityID string,
+	id int,
+) (*model.Revision, error) {
+	revision, err := s.repo.Get(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, localErrors.ErrNotFound):
+			return nil, ErrRevisionNotFound
+		default:
+			return nil, fmt.Errorf("ошибка получения ревизии по id: %w", err)
+		}
+	}
+
+	if revision.Entity != entity || revision.EntityID != entityID {
+		return nil, ErrRevisionNotFound
+	}
+
+	return revision, nil
+}
+
+// revisionSnapshot Получить состояние записи, сохраненное в ревизии после изменения.
+// Возврат к ревизии только обновляет существующую запись: удаленные записи из истории не создаются заново,
+// так как вместе с ними удалены и зависящие от них данные, например фотографии святого места.
+func revisionSnapshot[T any](
+	ctx context.Context,
+	revisions RevisionService,
+	entity model.RevisionEntity,
+	entityID string,
+	revisionID int,
+) (*T, error) {
+	revision, err := revisions.Get(ctx, entity, entityID, revisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if revision.Action == model.RevisionActionDelete {
+		return nil, ErrRevisionEntityDeleted
+	}
+
+	if len(revision.After) == 0 {
+		return nil, ErrRevisionNotRestorable
+	}
+
+	var result T
+	if err = json.Unmarshal(revision.After, &result); err != nil {
+		return nil, fmt.Errorf("ошибка чтения состояния из ревизии: %w", err)
+	}
+
+	return &result, nil
+}
@@ -2,22 +2,38 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"palback/internal/domain/model"
+	localErrors "palback/internal/pkg/errors"
 	"palback/internal/usecase/port"
+	"slices"
 )
 
 type RoleUseCase struct {
-	repo port.RoleRepo
+	audit AuditService
+	repo  port.RoleRepo
 }
 
-func NewRoleUseCase(repo port.RoleRepo) *RoleUseCase {
+func NewRoleUseCase(audit AuditService, repo port.RoleRepo) *RoleUseCase {
 	return &RoleUseCase{
-		repo: repo,
+		audit: audit,
+		repo:  repo,
 	}
 }
 
 func (s *RoleUseCase) Get(ctx context.Context, id model.RoleID) (*model.Role, error) {
-	return s.repo.Get(ctx, id)
+	role, err := s.repo.Get(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, localErrors.ErrNotFound):
+			return nil, ErrRoleNotFound
+		default:
+			return nil, fmt.Errorf("ошибка получения роли: %w", err)
+		}
+	}
+
+	return role, nil
 }
 
 func (s *RoleUseCase) GetAll(ctx context.Context) ([]model.Role, error) {
@@ -27,3 +43,59 @@ func (s *RoleUseCase) GetAll(ctx context.Context) ([]model.Role, error) {
 func (s *RoleUseCase) GetAllMap(ctx context.Context) (map[model.RoleID]*model.Role, error) {
 	return s.repo.GetAllMap(ctx)
 }
+
+// HasPermission Проверить, выдано ли роли право
+func (s *RoleUseCase) HasPermission(ctx context.Context, id model.RoleID, permission model.Permission) (bool, error) {
+	role, err := s.Get(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	return role.HasPermission(permission), nil
+}
+
+// UpdatePermissions Заменить права роли. Права администратора не меняются,
+// чтобы нельзя было лишить доступа всех, кто управляет ролями.
+func (s *RoleUseCase) UpdatePermissions(
+	ctx context.Context,
+	id model.RoleID,
+	permissions []model.Permission,
+) (*model.Role, error) {
+	if id == model.RoleAdmin {
+		return nil, ErrRoleNotEditable
+	}
+
+	unique := make([]model.Permission, 0, len(permissions))
+
+	for _, permission := range permissions {
+		if !permission.IsValid() {
+			return nil, ErrRoleInvalidPermission
+		}
+
+		if !slices.Contains(unique, permission) {
+			unique = append(unique, permission)
+		}
+	}
+
+	before, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repo.UpdatePermissions(ctx, id, unique)
+	if err != nil {
+		switch {
+		case errors.Is(err, localErrors.ErrNotFound):
+			return nil, ErrRoleNotFound
+		default:
+			return nil, fmt.Errorf("ошибка изменения прав роли: %w", err)
+		}
+	}
+
+	s.audit.Record(ctx, model.AuditActionRolePermissionsChange, model.AuditEntityRole, string(id), map[string]any{
+		"before": before.Permissions,
+		"after":  unique,
+	})
+
+	return s.Get(ctx, id)
+}
@@ -2,6 +2,9 @@ package usecase
 
 import (
 	"context"
+	"io"
+	"time"
+
 	"palback/internal/domain/model"
 	ucModel "palback/internal/usecase/model"
 )
@@ -13,6 +16,8 @@ type CountryService interface {
 	Update(ctx context.Context, id string, country model.Country) error
 	Delete(ctx context.Context, id string) error
 	Order(ctx context.Context, ids []string) error
+	GetRevisions(ctx context.Context, id string) (ucModel.RevisionList, error)
+	Restore(ctx context.Context, id string, revisionID int) error
 }
 
 type RegionService interface {
@@ -21,6 +26,8 @@ type RegionService interface {
 	Create(ctx context.Context, region model.Region) (*ucModel.RegionDetail, error)
 	Update(ctx context.Context, id int, region model.Region) error
 	Delete(ctx context.Context, id int) error
+	GetRevisions(ctx context.Context, id int) (ucModel.RevisionList, error)
+	Restore(ctx context.Context, id int, revisionID int) error
 }
 
 type CityTypeService interface {
@@ -28,24 +35,163 @@ type CityTypeService interface {
 	GetAll(ctx context.Context) ([]model.CityType, error)
 }
 
+type CityService interface {
+	Get(ctx context.Context, id int) (*ucModel.CityDetail, error)
+	GetByCountry(ctx context.Context, countryID string) (ucModel.CityList, error)
+	Create(ctx context.Context, city model.City) (*ucModel.CityDetail, error)
+	Update(ctx context.Context, id int, city model.City) error
+	Delete(ctx context.Context, id int) error
+	GetRevisions(ctx context.Context, id int) (ucModel.RevisionList, error)
+	Restore(ctx context.Context, id int, revisionID int) error
+}
+
 type PlaceTypeService interface {
 	Get(ctx context.Context, id int) (*model.PlaceType, error)
 	GetAll(ctx context.Context) ([]model.PlaceType, error)
 }
 
+type PlaceService interface {
+	Get(ctx context.Context, id int) (*ucModel.PlaceDetail, error)
+	GetList(ctx context.Context, filter model.PlaceFilter) (ucModel.PlaceList, error)
+	GetNearby(ctx context.Context, filter model.PlaceNearbyFilter) (ucModel.PlaceNearbyList, error)
+	Create(ctx context.Context, place model.Place) (*ucModel.PlaceDetail, error)
+	Update(ctx context.Context, id int, place model.Place) error
+	Delete(ctx context.Context, id int) error
+	Check(ctx context.Context, place model.Place) error
+	GetRevisions(ctx context.Context, id int) (ucModel.RevisionList, error)
+	Restore(ctx context.Context, id int, revisionID int) error
+}
+
+type PlaceSubmissionService interface {
+	Get(ctx context.Context, id, userID int) (*model.PlaceSubmission, error)
+	GetByAuthor(ctx context.Context, authorID int) ([]model.PlaceSubmission, error)
+	GetQueue(ctx context.Context) ([]model.PlaceSubmission, error)
+	Create(ctx context.Context, submission model.PlaceSubmission) (*model.PlaceSubmission, error)
+	Update(ctx context.Context, id, userID int, place model.Place) error
+	Submit(ctx context.Context, id, userID int) error
+	Approve(ctx context.Context, id, moderatorID int) (*ucModel.PlaceDetail, error)
+	Reject(ctx context.Context, id, moderatorID int, reason string) error
+}
+
+type PlacePhotoService interface {
+	GetByPlace(ctx context.Context, placeID int) ([]model.PlacePhoto, error)
+	Upload(ctx context.Context, photo model.PlacePhoto, data io.Reader) (*model.PlacePhoto, error)
+	Download(
+		ctx context.Context,
+		placeID, id int,
+		variant model.ImageVariantName,
+		format model.ImageFormat,
+	) (io.ReadCloser, error)
+	Update(ctx context.Context, placeID, id, userID int, photo model.PlacePhoto) error
+	SetCover(ctx context.Context, placeID, id, userID int) error
+	Delete(ctx context.Context, placeID, id, userID int) error
+}
+
+type RevisionService interface {
+	Record(
+		ctx context.Context,
+		entity model.RevisionEntity,
+		entityID string,
+		action model.RevisionAction,
+		before, after any,
+	)
+	GetByEntity(ctx context.Context, entity model.RevisionEntity, entityID string) (ucModel.RevisionList, error)
+	Get(ctx context.Context, entity model.RevisionEntity, entityID string, id int) (*model.Revision, error)
+}
+
+type AuditService interface {
+	Record(ctx context.Context, action model.AuditAction, entityType, entityID string, payload any)
+	GetList(ctx context.Context, filter model.AuditFilter) (ucModel.AuditList, error)
+	Export(ctx context.Context, filter model.AuditFilter) ([]model.AuditEntry, error)
+}
+
+type TranslationService interface {
+	GetByEntity(ctx context.Context, entity model.TranslationEntity, entityID string) ([]model.Translation, error)
+	Save(ctx context.Context, translation model.Translation) (*model.Translation, error)
+	Delete(ctx context.Context, entity model.TranslationEntity, entityID, lang string) error
+}
+
+type EmailOutboxService interface {
+	Enqueue(ctx context.Context, message model.EmailMessage) error
+	DeliverDue(ctx context.Context) error
+	GetList(ctx context.Context, filter model.EmailFilter) (ucModel.EmailMessageList, error)
+	Get(ctx context.Context, id int) (*model.EmailMessage, error)
+	Resend(ctx context.Context, id int) error
+}
+
+type PasswordPolicyService interface {
+	Check(ctx context.Context, password, userName, email string) error
+}
+
 type RoleService interface {
 	Get(ctx context.Context, id model.RoleID) (*model.Role, error)
 	GetAll(ctx context.Context) ([]model.Role, error)
 	GetAllMap(ctx context.Context) (map[model.RoleID]*model.Role, error)
+	HasPermission(ctx context.Context, id model.RoleID, permission model.Permission) (bool, error)
+	UpdatePermissions(ctx context.Context, id model.RoleID, permissions []model.Permission) (*model.Role, error)
 }
 
 type UserService interface {
 	Get(ctx context.Context, id int) (*ucModel.UserDetail, error)
-	GetAll(ctx context.Context) (ucModel.UserList, error)
+	GetRole(ctx context.Context, id int) (*model.Role, error)
+	HasPermission(ctx context.Context, id int, permission model.Permission) (bool, error)
+	GetList(ctx context.Context, filter model.UserFilter) (ucModel.UserList, error)
 	Register(ctx context.Context, userName, email, password string) (*ucModel.UserDetail, error)
 	VerifyEmail(ctx context.Context, token string) error
 	ResendVerificationEmail(ctx context.Context, email string) error
-	Login(ctx context.Context, identifier, password string) (*ucModel.UserDetail, error)
+	Login(ctx context.Context, identifier, password string) (*ucModel.LoginResult, error)
+	LoginTwoFactor(ctx context.Context, token, code string) (*ucModel.LoginResult, error)
+	LoginExternal(ctx context.Context, userID int) (*ucModel.LoginResult, error)
+	UnlockAccount(ctx context.Context, token string) error
+	GetLoginLockouts(ctx context.Context) ([]model.User, error)
+	ClearLoginLockout(ctx context.Context, userID int) error
 	RequestPasswordReset(ctx context.Context, email string) error
 	ConfirmPasswordReset(ctx context.Context, token, newPassword string) error
+	ChangePassword(ctx context.Context, userID int, currentPassword, newPassword string) (*ucModel.UserDetail, error)
+	RequestEmailChange(ctx context.Context, userID int, password, newEmail string) error
+	ConfirmEmailChange(ctx context.Context, token string) error
+	SetupTwoFactor(ctx context.Context, userID int) (*ucModel.TwoFactorSetup, error)
+	EnableTwoFactor(ctx context.Context, userID int, code string) ([]string, error)
+	DisableTwoFactor(ctx context.Context, userID int, password, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, userID int, code string) ([]string, error)
+	ChangeRole(ctx context.Context, id int, roleID model.RoleID) (*ucModel.UserDetail, error)
+	ForceVerifyEmail(ctx context.Context, id int) error
+	Block(ctx context.Context, id int) error
+	Unblock(ctx context.Context, id int) error
+	ForceLogout(ctx context.Context, id int) error
+}
+
+type AccessTokenService interface {
+	GetByUser(ctx context.Context, userID int) ([]model.AccessToken, error)
+	Create(
+		ctx context.Context,
+		userID int,
+		name string,
+		scopes []model.AccessTokenScope,
+		expiresAt *time.Time,
+	) (*ucModel.CreatedAccessToken, error)
+	Revoke(ctx context.Context, userID, id int) error
+}
+
+type ExternalLoginService interface {
+	Providers() []string
+	Start(ctx context.Context, provider string, linkUserID *int) (url, binding string, err error)
+	Callback(ctx context.Context, provider, state, code, binding string) (*ucModel.LoginResult, error)
+	GetIdentities(ctx context.Context, userID int) ([]model.Identity, error)
+	Unlink(ctx context.Context, userID, id int) error
+}
+
+type UserProfileService interface {
+	Get(ctx context.Context, userID int) (*ucModel.UserProfileDetail, error)
+	Update(ctx context.Context, userID int, profile model.UserProfile) error
+	UploadAvatar(ctx context.Context, userID int, size int64, data io.Reader) error
+	DeleteAvatar(ctx context.Context, userID int) error
+	DownloadAvatar(ctx context.Context, userID int, format model.ImageFormat) (data io.ReadCloser, version string, err error)
+	DeleteAvatarFiles(ctx context.Context, user model.User)
+}
+
+type AccountDeletionService interface {
+	RequestDeletion(ctx context.Context, userID int, password string) (time.Time, error)
+	PurgeExpired(ctx context.Context) error
+	Delete(ctx context.Context, userID int) error
 }
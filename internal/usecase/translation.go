@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"palback/internal/domain/model"
+	localErrors "palback/internal/pkg/errors"
+	"palback/internal/pkg/locale"
+	"palback/internal/usecase/port"
+)
+
+type TranslationUseCase struct {
+	audit AuditService
+	repo  port.TranslationRepo
+}
+
+func NewTranslationUseCase(audit AuditService, repo port.TranslationRepo) *TranslationUseCase {
+	return &TranslationUseCase{
+		audit: audit,
+		repo:  repo,
+	}
+}
+
+// GetByEntity Получить переводы записи справочника на все языки
+func (s *TranslationUseCase) GetByEntity(
+	ctx context.Context,
+	entity model.TranslationEntity,
+	entityID string,
+) ([]model.Translation, error) {
+	if err := checkTranslationEntity(entity, entityID); err != nil {
+		return nil, err
+	}
+
+	translations, err := s.repo.GetByEntity(ctx, entity, entityID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения переводов: %w", err)
+	}
+
+	return translations, nil
+}
+
+// Save Добавить или заменить перевод записи справочника. Основное название на языке
+// по умолчанию хранится в самой записи, поэтому перевод на этот язык не допускается.
+func (s *TranslationUseCase) Save(ctx context.Context, translation model.Translation) (*model.Translation, error) {
+	if err := checkTranslationEntity(translation.Entity, translation.EntityID); err != nil {
+		return nil, err
+	}
+
+	lang, err := normalizeTranslationLang(translation.Lang)
+	if err != nil {
+		return nil, err
+	}
+
+	translation.Lang = lang
+	translation.Name = strings.TrimSpace(translation.Name)
+	translation.ShortName = strings.TrimSpace(translation.ShortName)
+
+	if translation.Name == "" {
+		return nil, ErrTranslationNameRequired
+	}
+
+	if !translation.Entity.HasShortName() {
+		translation.ShortName = ""
+	}
+
+	err = s.repo.Save(ctx, translation)
+	if err != nil {
+		switch {
+		case localErrors.IsOneOf(err, ErrTranslationEntityNotFound, ErrTranslationLangNotSupported):
+			return nil, err
+		default:
+			return nil, fmt.Errorf("ошибка сохранения перевода: %w", err)
+		}
+	}
+
+	s.audit.Record(ctx, model.AuditActionTranslationSave, string(translation.Entity), translation.EntityID, map[string]any{
+		"lang":       translation.Lang,
+		"name":       translation.Name,
+		"short_name": translation.ShortName,
+	})
+
+	return &translation, nil
+}
+
+// Delete Удалить перевод записи справочника; после этого название возвращается на языке по умолчанию
+func (s *TranslationUseCase) Delete(ctx context.Context, entity model.TranslationEntity, entityID, lang string) error {
+	if err := checkTranslationEntity(entity, entityID); err != nil {
+		return err
+	}
+
+	lang, err := normalizeTranslationLang(lang)
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.Delete(ctx, entity, entityID, lang)
+	if err != nil {
+		switch {
+		case errors.Is(err, localErrors.ErrNotFound):
+			return ErrTranslationNotFound
+		default:
+			return fmt.Errorf("ошибка удаления перевода: %w", err)
+		}
+	}
+
+	s.audit.Record(ctx, model.AuditActionTranslationDelete, string(entity), entityID, map[string]any{
+		"lang": lang,
+	})
+
+	return nil
+}
+
+// checkTranslationEntity Проверить справочник и формат id записи: у стран id строковый, у остальных — число
+func checkTranslationEntity(entity model.TranslationEntity, entityID string) error {
+	if !entity.IsValid() {
+		return ErrTranslationInvalidEntity
+	}
+
+	if entity == model.TranslationEntityCountry {
+		if entityID == "" {
+			return ErrTranslationEntityNotFound
+		}
+
+		return nil
+	}
+
+	if id, err := strconv.Atoi(entityID); err != nil || id <= 0 {
+		return ErrTranslationEntityNotFound
+	}
+
+	return nil
+}
+
+func normalizeTranslationLang(lang string) (string, error) {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+
+	if !locale.IsValid(lang) {
+		return "", ErrTranslationLangNotSupported
+	}
+
+	if lang == locale.Default {
+		return "", ErrTranslationDefaultLang
+	}
+
+	return lang, nil
+}
@@ -0,0 +1,395 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"palback/internal/domain/model"
+	localErrors "palback/internal/pkg/errors"
+	"palback/internal/pkg/helpers"
+	tokens "palback/internal/pkg/token"
+	"palback/internal/pkg/totp"
+	ucModel "palback/internal/usecase/model"
+)
+
+const (
+	// pendingLoginTTL Сколько секунд ждать второй шаг входа
+	pendingLoginTTL = 300
+
+	// twoFactorMaxAttempts Сколько раз можно проверить код пользователя за twoFactorAttemptsWindow
+	twoFactorMaxAttempts = 5
+
+	// twoFactorAttemptsWindow Сколько секунд учитываются проверки кода, считая от первой после сброса счетчика
+	twoFactorAttemptsWindow = 900
+
+	// twoFactorSetupTTL Сколько секунд ждать подтверждения настройки двухфакторной аутентификации
+	twoFactorSetupTTL = 600
+
+	recoveryCodesCount = 10
+)
+
+// TwoFactorConfig Настройки двухфакторной аутентификации
+type TwoFactorConfig struct {
+	// Issuer Название сервиса, которое показывается в приложении-аутентификаторе
+	Issuer string
+
+	// RequiredForAdmins Администраторы не могут войти без двухфакторной аутентификации
+	RequiredForAdmins bool
+}
+
+// pendingLogin Незавершенный вход, хранящийся в key-value хранилище до ввода кода.
+// Если Secret заполнен, пользователь настраивает двухфакторную аутентификацию во время входа.
+type pendingLogin struct {
+	UserID int    `json:"user_id"`
+	Secret string `json:"secret,omitempty"`
+}
+
+// LoginTwoFactor Второй шаг входа: проверить код из приложения или резервный код по токену первого шага
+func (s *UserUseCase) LoginTwoFactor(ctx context.Context, token, code string) (*ucModel.LoginResult, error) {
+	pending, err := s.getPendingLogin(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.getUser(ctx, pending.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var recoveryCodes []string
+
+	if pending.Secret != "" {
+		if !s.checkTOTP(ctx, user.ID, pending.Secret, code) {
+			s.auditLoginFailure(ctx, user.ID, "", ErrTwoFactorInvalidCode)
+			return nil, ErrTwoFactorInvalidCode
+		}
+
+		recoveryCodes, err = s.enableTwoFactor(ctx, user.ID, pending.Secret)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		err = s.checkCode(ctx, *user, code)
+		if localErrors.IsOneOf(err, ErrTwoFactorInvalidCode, ErrTwoFactorTooManyAttempts) {
+			s.auditLoginFailure(ctx, user.ID, "", err)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_ = s.kvStorage.Del(ctx, "login_2fa:"+token)
+
+	role, err := s.roleService.Get(ctx, user.RoleID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения роли по id: %w", err)
+	}
+
+	userDetail, err := s.completeLogin(ctx, *user, helpers.FromPtr(role))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ucModel.LoginResult{
+		User:          userDetail,
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+// SetupTwoFactor Начать настройку двухфакторной аутентификации. Секрет действует до подтверждения кодом
+// в EnableTwoFactor и до этого не влияет на вход.
+func (s *UserUseCase) SetupTwoFactor(ctx context.Context, userID int) (*ucModel.TwoFactorSetup, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TwoFactorEnabled() {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка генерации секрета: %w", err)
+	}
+
+	err = s.kvStorage.Set(ctx, twoFactorSetupKey(userID), secret, twoFactorSetupTTL)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка сохранения секрета: %w", err)
+	}
+
+	return s.twoFactorSetup(*user, secret), nil
+}
+
+// EnableTwoFactor Подтвердить настройку кодом из приложения. Возвращает резервные коды, которые показываются один раз.
+func (s *UserUseCase) EnableTwoFactor(ctx context.Context, userID int, code string) ([]string, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TwoFactorEnabled() {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := s.kvStorage.Get(ctx, twoFactorSetupKey(userID))
+	if err != nil {
+		switch {
+		case localErrors.IsOneOf(err, ErrNoReplyFromKeyValueStorage, ErrKeyNotFound):
+			return nil, ErrTwoFactorSetupNotStarted
+		default:
+			return nil, fmt.Errorf("ошибка получения секрета: %w", err)
+		}
+	}
+
+	if !s.checkTOTP(ctx, userID, secret, code) {
+		return nil, ErrTwoFactorInvalidCode
+	}
+
+	recoveryCodes, err := s.enableTwoFactor(ctx, userID, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.kvStorage.Del(ctx, twoFactorSetupKey(userID))
+
+	return recoveryCodes, nil
+}
+
+// DisableTwoFactor Выключить двухфакторную аутентификацию. Требуются пароль и действующий код.
+func (s *UserUseCase) DisableTwoFactor(ctx context.Context, userID int, password, code string) error {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.TwoFactorEnabled() {
+		return ErrTwoFactorNotEnabled
+	}
+
+	if s.twoFactor.RequiredForAdmins && user.RoleID == model.RoleAdmin {
+		return ErrTwoFactorRequired
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
+	if err != nil {
+		return ErrInvalidPassword
+	}
+
+	if err = s.checkCode(ctx, *user, code); err != nil {
+		return err
+	}
+
+	err = s.repo.UpdateTwoFactor(ctx, userID, "", nil)
+	if err != nil {
+		return fmt.Errorf("ошибка выключения двухфакторной аутентификации: %w", err)
+	}
+
+	return nil
+}
+
+// RegenerateRecoveryCodes Выдать новые резервные коды взамен прежних
+func (s *UserUseCase) RegenerateRecoveryCodes(ctx context.Context, userID int, code string) ([]string, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.TwoFactorEnabled() {
+		return nil, ErrTwoFactorNotEnabled
+	}
+
+	if err = s.checkCode(ctx, *user, code); err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repo.UpdateRecoveryCodes(ctx, userID, hashes)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка сохранения резервных кодов: %w", err)
+	}
+
+	return recoveryCodes, nil
+}
+
+// startTwoFactorLogin Сохранить незавершенный вход и выдать токен для второго шага
+func (s *UserUseCase) startTwoFactorLogin(
+	ctx context.Context,
+	pending pendingLogin,
+	setup *ucModel.TwoFactorSetup,
+) (*ucModel.LoginResult, error) {
+	token, err := tokens.GenerateVerificationToken()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка генерации токена: %w", err)
+	}
+
+	if err = s.savePendingLogin(ctx, token, pending); err != nil {
+		return nil, err
+	}
+
+	return &ucModel.LoginResult{
+		TwoFactorToken: token,
+		TwoFactorSetup: setup,
+	}, nil
+}
+
+// startTwoFactorSetupLogin Вход пользователя, обязанного настроить двухфакторную аутентификацию:
+// секрет выдается сразу, а включается после ввода первого кода на втором шаге
+func (s *UserUseCase) startTwoFactorSetupLogin(ctx context.Context, user model.User) (*ucModel.LoginResult, error) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка генерации секрета: %w", err)
+	}
+
+	return s.startTwoFactorLogin(
+		ctx,
+		pendingLogin{UserID: user.ID, Secret: secret},
+		s.twoFactorSetup(user, secret),
+	)
+}
+
+func (s *UserUseCase) getPendingLogin(ctx context.Context, token string) (*pendingLogin, error) {
+	if token == "" {
+		return nil, ErrInvalidToken
+	}
+
+	value, err := s.kvStorage.Get(ctx, "login_2fa:"+token)
+	if err != nil {
+		switch {
+		case localErrors.IsOneOf(err, ErrNoReplyFromKeyValueStorage, ErrKeyNotFound):
+			return nil, ErrInvalidToken
+		default:
+			return nil, fmt.Errorf("ошибка получения токена: %w", err)
+		}
+	}
+
+	var pending pendingLogin
+	if err = json.Unmarshal([]byte(value), &pending); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	return &pending, nil
+}
+
+func (s *UserUseCase) savePendingLogin(ctx context.Context, token string, pending pendingLogin) error {
+	value, err := json.Marshal(pending)
+	if err != nil {
+		return fmt.Errorf("ошибка подготовки токена: %w", err)
+	}
+
+	err = s.kvStorage.Set(ctx, "login_2fa:"+token, string(value), pendingLoginTTL)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения токена: %w", err)
+	}
+
+	return nil
+}
+
+// checkCode Проверить код из приложения или, если он не подошел, резервный код.
+// Попытки считаются на пользователя, а не на токен входа, и учитываются до проверки атомарным
+// счетчиком: ни новый вход по паролю, ни одновременные запросы не дают больше twoFactorMaxAttempts
+// проверок за окно. Верный код сбрасывает счетчик.
+func (s *UserUseCase) checkCode(ctx context.Context, user model.User, code string) error {
+	key := twoFactorAttemptsKey(user.ID)
+
+	attempts, err := s.kvStorage.Incr(ctx, key, twoFactorAttemptsWindow)
+	if err != nil {
+		return fmt.Errorf("ошибка учета попытки ввода кода: %w", err)
+	}
+
+	if attempts > twoFactorMaxAttempts {
+		return ErrTwoFactorTooManyAttempts
+	}
+
+	if !s.checkTOTP(ctx, user.ID, user.TOTPSecret, code) {
+		err = s.repo.UseRecoveryCode(ctx, user.ID, totp.HashRecoveryCode(code))
+		if err != nil {
+			switch {
+			case errors.Is(err, localErrors.ErrNotFound):
+				return ErrTwoFactorInvalidCode
+			default:
+				return fmt.Errorf("ошибка проверки резервного кода: %w", err)
+			}
+		}
+	}
+
+	_ = s.kvStorage.Del(ctx, key)
+
+	return nil
+}
+
+// checkTOTP Проверить код из приложения. Каждый код принимается только один раз.
+func (s *UserUseCase) checkTOTP(ctx context.Context, userID int, secret, code string) bool {
+	step, ok := totp.Validate(secret, code, time.Now())
+	if !ok {
+		return false
+	}
+
+	key := "totp_used:" + strconv.Itoa(userID) + ":" + strconv.FormatInt(step, 10)
+
+	// Код отмечается использованным одной атомарной командой: если два запроса пришли с одним кодом
+	// одновременно, принят будет только один из них
+	first, err := s.kvStorage.SetNX(ctx, key, "1", totp.Period*(2*totp.Skew+1))
+
+	return err == nil && first
+}
+
+func (s *UserUseCase) enableTwoFactor(ctx context.Context, userID int, secret string) ([]string, error) {
+	recoveryCodes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repo.UpdateTwoFactor(ctx, userID, secret, hashes)
+	if err != nil {
+		switch {
+		case errors.Is(err, localErrors.ErrNotFound):
+			return nil, ErrUserNotFound
+		default:
+			return nil, fmt.Errorf("ошибка включения двухфакторной аутентификации: %w", err)
+		}
+	}
+
+	return recoveryCodes, nil
+}
+
+func (s *UserUseCase) twoFactorSetup(user model.User, secret string) *ucModel.TwoFactorSetup {
+	return &ucModel.TwoFactorSetup{
+		Secret: secret,
+		URI:    totp.ProvisioningURI(s.twoFactor.Issuer, user.Email, secret),
+	}
+}
+
+func twoFactorSetupKey(userID int) string {
+	return "totp_setup:" + strconv.Itoa(userID)
+}
+
+func twoFactorAttemptsKey(userID int) string {
+	return "totp_attempts:" + strconv.Itoa(userID)
+}
+
+// generateRecoveryCodes Сгенерировать резервные коды вместе с их хэшами для хранения
+func generateRecoveryCodes() ([]string, []string, error) {
+	recoveryCodes, err := totp.GenerateRecoveryCodes(recoveryCodesCount)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ошибка генерации резервных кодов: %w", err)
+	}
+
+	hashes := make([]string, 0, len(recoveryCodes))
+	for _, code := range recoveryCodes {
+		hashes = append(hashes, totp.HashRecoveryCode(code))
+	}
+
+	return recoveryCodes, hashes, nil
+}
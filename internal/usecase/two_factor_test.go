@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"palback/internal/domain/model"
+	localErrors "palback/internal/pkg/errors"
+	"palback/internal/pkg/totp"
+	"palback/internal/usecase/port"
+)
+
+// fakeKeyValueStorage Хранилище в памяти без учета времени жизни. Реализованы только Set, SetNX, GetDel, Incr и Del.
+type fakeKeyValueStorage struct {
+	port.KeyValueStorage
+
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func (s *fakeKeyValueStorage) Set(_ context.Context, key, value string, _ int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.values[key] = value
+
+	return nil
+}
+
+func (s *fakeKeyValueStorage) Incr(_ context.Context, key string, _ int) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	count, _ := strconv.Atoi(s.values[key])
+	count++
+	s.values[key] = strconv.Itoa(count)
+
+	return count, nil
+}
+
+func (s *fakeKeyValueStorage) Del(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.values, key)
+
+	return nil
+}
+
+func (s *fakeKeyValueStorage) GetDel(_ context.Context, key string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	value, ok := s.values[key]
+	if !ok {
+		return "", ErrKeyNotFound
+	}
+
+	delete(s.values, key)
+
+	return value, nil
+}
+
+func (s *fakeKeyValueStorage) SetNX(_ context.Context, key, value string, _ int) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.values[key]; ok {
+		return false, nil
+	}
+
+	s.values[key] = value
+
+	return true, nil
+}
+
+func TestCheckTOTP_AcceptsCodeOnce(t *testing.T) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret: %v", err)
+	}
+
+	code, err := totp.Code(secret, totp.Step(time.Now()))
+	if err != nil {
+		t.Fatalf("Code: %v", err)
+	}
+
+	s := &UserUseCase{kvStorage: &fakeKeyValueStorage{values: make(map[string]string)}}
+
+	var (
+		accepted atomic.Int32
+		wg       sync.WaitGroup
+	)
+
+	for range 10 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if s.checkTOTP(context.Background(), 1, secret, code) {
+				accepted.Add(1)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if n := accepted.Load(); n != 1 {
+		t.Fatalf("код принят %d раз, ожидался один", n)
+	}
+
+	if !s.checkTOTP(context.Background(), 2, secret, code) {
+		t.Fatal("код другого пользователя отклонен")
+	}
+}
+
+// fakeTwoFactorRepo Пользователь с двухфакторной аутентификацией без резервных кодов
+type fakeTwoFactorRepo struct {
+	port.UserRepo
+
+	user model.User
+}
+
+func (r *fakeTwoFactorRepo) Get(context.Context, int) (*model.User, error) {
+	user := r.user
+	return &user, nil
+}
+
+func (r *fakeTwoFactorRepo) UseRecoveryCode(context.Context, int, string) error {
+	return localErrors.ErrNotFound
+}
+
+func TestCheckCode_LimitsAttemptsPerUser(t *testing.T) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret: %v", err)
+	}
+
+	user := model.User{ID: 1, TOTPSecret: secret}
+	s := &UserUseCase{
+		kvStorage: &fakeKeyValueStorage{values: make(map[string]string)},
+		repo:      &fakeTwoFactorRepo{user: user},
+	}
+
+	var (
+		invalid, limited atomic.Int32
+		wg               sync.WaitGroup
+	)
+
+	// Одновременные попытки, как с разных токенов входа одного пользователя
+	for range 20 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			switch err := s.checkCode(context.Background(), user, "000000x"); {
+			case errors.Is(err, ErrTwoFactorInvalidCode):
+				invalid.Add(1)
+			case errors.Is(err, ErrTwoFactorTooManyAttempts):
+				limited.Add(1)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if invalid.Load() != twoFactorMaxAttempts || limited.Load() != 20-twoFactorMaxAttempts {
+		t.Fatalf("проверено кодов %d, отклонено по лимиту %d, ожидалось %d и %d",
+			invalid.Load(), limited.Load(), twoFactorMaxAttempts, 20-twoFactorMaxAttempts)
+	}
+
+	code, err := totp.Code(secret, totp.Step(time.Now()))
+	if err != nil {
+		t.Fatalf("Code: %v", err)
+	}
+
+	// Исчерпав попытки, нельзя проверить и верный код, в том числе при выдаче новых резервных кодов
+	if _, err = s.RegenerateRecoveryCodes(context.Background(), user.ID, code); !errors.Is(err, ErrTwoFactorTooManyAttempts) {
+		t.Fatalf("RegenerateRecoveryCodes: ожидалась %v, получено %v", ErrTwoFactorTooManyAttempts, err)
+	}
+}
@@ -2,13 +2,16 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
+	"strconv"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
 
 	"palback/internal/domain/model"
+	"palback/internal/pkg/actor"
 	localErrors "palback/internal/pkg/errors"
 	"palback/internal/pkg/helpers"
 	tokens "palback/internal/pkg/token"
@@ -17,23 +20,35 @@ import (
 )
 
 type UserUseCase struct {
-	roleService RoleService
-	mailer      port.EmailSender
-	kvStorage   port.KeyValueStorage
-	repo        port.UserRepo
+	roleService    RoleService
+	audit          AuditService
+	passwordPolicy PasswordPolicyService
+	outbox         EmailOutboxService
+	kvStorage      port.KeyValueStorage
+	twoFactor      TwoFactorConfig
+	lockout        LockoutConfig
+	repo           port.UserRepo
 }
 
 func NewUserUseCase(
 	roleService RoleService,
-	mailer port.EmailSender,
+	audit AuditService,
+	passwordPolicy PasswordPolicyService,
+	outbox EmailOutboxService,
 	kvStorage port.KeyValueStorage,
+	twoFactor TwoFactorConfig,
+	lockout LockoutConfig,
 	repo port.UserRepo,
 ) *UserUseCase {
 	return &UserUseCase{
-		roleService: roleService,
-		mailer:      mailer,
-		kvStorage:   kvStorage,
-		repo:        repo,
+		roleService:    roleService,
+		audit:          audit,
+		passwordPolicy: passwordPolicy,
+		outbox:         outbox,
+		kvStorage:      kvStorage,
+		twoFactor:      twoFactor,
+		lockout:        lockout,
+		repo:           repo,
 	}
 }
 
@@ -55,39 +70,40 @@ func (s *UserUseCase) Get(ctx context.Context, id int) (*ucModel.UserDetail, err
 	return &userDetail, nil
 }
 
-func (s *UserUseCase) GetAll(ctx context.Context) (result ucModel.UserList, err error) {
-	users, err := s.repo.GetAll(ctx)
+func (s *UserUseCase) GetRole(ctx context.Context, id int) (*model.Role, error) {
+	user, err := s.repo.Get(ctx, id)
 
 	if err != nil {
-		return result, fmt.Errorf("ошибка при получении списка регионов: %w", err)
+		return nil, fmt.Errorf("ошибка получения пользователя по id: %w", err)
 	}
 
-	rolesMap, err := s.roleService.GetAllMap(ctx)
+	role, err := s.roleService.Get(ctx, user.RoleID)
+
 	if err != nil {
-		return result, fmt.Errorf("ошибка при получении информации о ролях: %w", err)
+		return nil, fmt.Errorf("ошибка получения роли по id: %w", err)
 	}
 
-	result = ucModel.CreateUserList(users, rolesMap)
+	return role, nil
+}
+
+// HasPermission Проверить, есть ли у пользователя право через его роль
+func (s *UserUseCase) HasPermission(ctx context.Context, id int, permission model.Permission) (bool, error) {
+	user, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return false, fmt.Errorf("ошибка получения пользователя по id: %w", err)
+	}
 
-	return result, nil
+	return s.roleService.HasPermission(ctx, user.RoleID, permission)
 }
 
 func (s *UserUseCase) Register(
 	ctx context.Context,
 	userName, email, password string,
-) (_ *ucModel.UserDetail, err error) {
-	var (
-		user  *model.User
-		token string
-	)
-
-	defer func() {
-		// Если возникли проблемы с регистрацией, то созданная запись удаляется
-		if err != nil && user != nil {
-			err = s.repo.Delete(ctx, user.ID)
-			err = s.kvStorage.Del(ctx, "verify_email:"+token)
-		}
-	}()
+) (*ucModel.UserDetail, error) {
+	err := s.passwordPolicy.Check(ctx, password, userName, email)
+	if err != nil {
+		return nil, err
+	}
 
 	// Сгенерировать хэш пароля
 	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -95,38 +111,35 @@ func (s *UserUseCase) Register(
 		return nil, fmt.Errorf("ошибка генерации пароля: %w", err)
 	}
 
-	// Добавить пользователя
-	user, err = s.repo.Create(ctx, model.User{
-		RoleID:        model.RoleUser,
-		Username:      userName,
-		Email:         email,
-		Password:      string(hashed),
-		EmailVerified: false,
-	})
-
-	if err != nil {
-		switch {
-		default:
-			return nil, fmt.Errorf("ошибка добавления пользователя: %w", err)
-		}
-	}
-
 	// Создать токен для проверки и поместить его в key-value хранилище
-	token, err = tokens.GenerateVerificationToken()
+	token, err := tokens.GenerateVerificationToken()
 	if err != nil {
 		return nil, fmt.Errorf("ошибка генерации токена: %w", err)
 	}
 
-	err = s.kvStorage.Set(ctx, "verify_email:"+token, email, 3600)
+	err = s.kvStorage.Set(ctx, "verify_email:"+token, email, emailTokenTTL)
 	if err != nil {
 		return nil, fmt.Errorf("ошибка записи токена в хранилище: %w", err)
 	}
 
-	// Создать и отправить проверочное письмо
-	err = s.mailer.SendVerificationEmail(email, token)
+	// Добавить пользователя вместе с проверочным письмом: письмо отправится в фоне,
+	// а если пользователя добавить не удалось, не отправится вовсе
+	user, err := s.repo.CreateWithEmail(ctx, model.User{
+		RoleID:        model.RoleUser,
+		Username:      userName,
+		Email:         email,
+		Password:      string(hashed),
+		EmailVerified: false,
+	}, verificationEmail(email, token))
 	if err != nil {
-		log.Printf("Ошибка отправки проверочного письма на %s: %v", email, err)
-		return nil, fmt.Errorf("ошибка отправки проверочного письма: %w", err)
+		_ = s.kvStorage.Del(ctx, "verify_email:"+token)
+
+		switch {
+		case localErrors.IsOneOf(err, ErrUserNameNotUnique, ErrUserEmailNotUnique):
+			return nil, err
+		default:
+			return nil, fmt.Errorf("ошибка добавления пользователя: %w", err)
+		}
 	}
 
 	// Собрать детальную информацию о пользователе
@@ -179,51 +192,129 @@ func (s *UserUseCase) ResendVerificationEmail(ctx context.Context, email string)
 		return fmt.Errorf("ошибка генерации токена: %w", err)
 	}
 
-	err = s.kvStorage.Set(ctx, "verify_email:"+tokenStr, email, 3600)
+	err = s.kvStorage.Set(ctx, "verify_email:"+tokenStr, email, emailTokenTTL)
 	if err != nil {
 		return fmt.Errorf("ошибка сохранения токена: %w", err)
 	}
 
-	err = s.mailer.SendVerificationEmail(email, tokenStr)
-	if err != nil {
-		log.Println("ошибка отправки письма пользователю", err)
-	}
-
-	return nil
-
+	return s.outbox.Enqueue(ctx, verificationEmail(email, tokenStr))
 }
 
-func (s *UserUseCase) Login(ctx context.Context, identifier, password string) (*ucModel.UserDetail, error) {
+// Login Первый шаг входа по логину и паролю. Если у пользователя включена двухфакторная аутентификация
+// или она обязательна для его роли, сессию можно создать только после LoginTwoFactor.
+func (s *UserUseCase) Login(ctx context.Context, identifier, password string) (*ucModel.LoginResult, error) {
 	user, err := s.repo.GetByIdentifier(ctx, identifier)
 	if err != nil || user == nil {
 		switch {
 		case errors.Is(err, localErrors.ErrNotFound):
+			s.auditLoginFailure(ctx, 0, identifier, ErrUserInvalidCredentials)
 			return nil, ErrUserInvalidCredentials
 		default:
 			return nil, fmt.Errorf("ошибка получения пользователя: %w", err)
 		}
 	}
 
+	if err = s.checkLoginAllowed(*user, time.Now()); err != nil {
+		s.auditLoginFailure(ctx, user.ID, identifier, err)
+		return nil, err
+	}
+
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
 	if err != nil {
-		return nil, ErrUserInvalidCredentials
+		err = s.registerLoginFailure(ctx, *user)
+		s.auditLoginFailure(ctx, user.ID, identifier, err)
+
+		return nil, err
+	}
+
+	if err = s.resetLoginFailures(ctx, *user); err != nil {
+		return nil, err
 	}
 
 	if !user.EmailVerified {
 		return nil, ErrUncheckedEmail
 	}
 
-	// Собрать детальную информацию о пользователе
+	return s.login(ctx, *user)
+}
+
+// LoginExternal Вход пользователя, личность которого подтвердил внешний провайдер.
+// Двухфакторная аутентификация проверяется так же, как при входе по паролю.
+func (s *UserUseCase) LoginExternal(ctx context.Context, userID int) (*ucModel.LoginResult, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.login(ctx, *user)
+}
+
+// login Вход после проверки первого фактора
+func (s *UserUseCase) login(ctx context.Context, user model.User) (*ucModel.LoginResult, error) {
+	if user.IsBlocked() {
+		s.auditLoginFailure(ctx, user.ID, "", ErrUserBlocked)
+		return nil, ErrUserBlocked
+	}
+
 	role, err := s.roleService.Get(ctx, user.RoleID)
 	if err != nil {
 		return nil, fmt.Errorf("ошибка получения роли по id: %w", err)
 	}
 
-	userDetail := ucModel.CreateUserDetail(helpers.FromPtr(user), helpers.FromPtr(role))
+	switch {
+	case user.TwoFactorEnabled():
+		return s.startTwoFactorLogin(ctx, pendingLogin{UserID: user.ID}, nil)
+	case s.twoFactor.RequiredForAdmins && role.IsAdmin():
+		return s.startTwoFactorSetupLogin(ctx, user)
+	}
+
+	userDetail, err := s.completeLogin(ctx, user, *role)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ucModel.LoginResult{User: userDetail}, nil
+}
+
+// completeLogin Действия после успешной проверки всех факторов
+func (s *UserUseCase) completeLogin(ctx context.Context, user model.User, role model.Role) (*ucModel.UserDetail, error) {
+	// Пользователя могли заблокировать между первым и вторым шагом входа
+	if user.IsBlocked() {
+		return nil, ErrUserBlocked
+	}
+
+	// Вход в течение льготного срока отменяет запрошенное удаление аккаунта
+	if user.DeletionRequestedAt != nil {
+		err := s.repo.UpdateDeletionRequested(ctx, user.ID, nil)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка отмены удаления аккаунта: %w", err)
+		}
+
+		user.DeletionRequestedAt = nil
+	}
+
+	// Пользователь еще не аутентифицирован, поэтому исполнителем записывается он сам
+	s.audit.Record(actor.WithUserID(ctx, user.ID), model.AuditActionLoginSuccess, model.AuditEntityUser,
+		strconv.Itoa(user.ID), nil)
+
+	userDetail := ucModel.CreateUserDetail(user, role)
 
 	return &userDetail, nil
 }
 
+// auditLoginFailure Записать неудачную попытку входа. Нулевой userID означает вход по несуществующему логину.
+func (s *UserUseCase) auditLoginFailure(ctx context.Context, userID int, identifier string, reason error) {
+	var entityID string
+	if userID > 0 {
+		entityID = strconv.Itoa(userID)
+	}
+
+	s.audit.Record(ctx, model.AuditActionLoginFailure, model.AuditEntityUser, entityID, map[string]any{
+		"identifier": identifier,
+		"reason":     reason.Error(),
+	})
+}
+
 func (s *UserUseCase) RequestPasswordReset(ctx context.Context, email string) error {
 	// Проверяем, существует ли пользователь
 	_, err := s.repo.GetByEmail(ctx, email)
@@ -239,17 +330,12 @@ func (s *UserUseCase) RequestPasswordReset(ctx context.Context, email string) er
 	}
 
 	// Сохраняем токен в хранилище
-	err = s.kvStorage.Set(ctx, "verify_email:"+tokenStr, email, 3600)
+	err = s.kvStorage.Set(ctx, "reset_token:"+tokenStr, email, emailTokenTTL)
 	if err != nil {
 		return fmt.Errorf("ошибка сохранения токена: %w", err)
 	}
 
-	err = s.mailer.SendPasswordResetEmail(email, tokenStr)
-	if err != nil {
-		log.Println("ошибка отправки письма пользователю", err)
-	}
-
-	return nil
+	return s.outbox.Enqueue(ctx, passwordResetEmail(email, tokenStr))
 }
 
 func (s *UserUseCase) ConfirmPasswordReset(ctx context.Context, token, newPassword string) error {
@@ -268,6 +354,21 @@ func (s *UserUseCase) ConfirmPasswordReset(ctx context.Context, token, newPasswo
 		}
 	}
 
+	user, err := s.repo.GetByEmail(ctx, email)
+	if err != nil {
+		switch {
+		case errors.Is(err, localErrors.ErrNotFound):
+			return ErrInvalidToken
+		default:
+			return fmt.Errorf("ошибка получения пользователя по e-mail: %w", err)
+		}
+	}
+
+	err = s.passwordPolicy.Check(ctx, newPassword, user.Username, user.Email)
+	if err != nil {
+		return err
+	}
+
 	hashed, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
 	if err != nil {
 		return err
@@ -278,7 +379,156 @@ func (s *UserUseCase) ConfirmPasswordReset(ctx context.Context, token, newPasswo
 		return fmt.Errorf("ошибка обновления пароля: %w", err)
 	}
 
+	// После смены пароля все сессии пользователя становятся недействительными
+	err = s.repo.IncrementSessionVersion(ctx, email)
+	if err != nil {
+		return fmt.Errorf("ошибка завершения сессий пользователя: %w", err)
+	}
+
 	_ = s.kvStorage.Del(ctx, "reset_token:"+token)
 
+	s.audit.Record(actor.WithUserID(ctx, user.ID), model.AuditActionPasswordReset, model.AuditEntityUser,
+		strconv.Itoa(user.ID), nil)
+
+	return nil
+}
+
+// ChangePassword Сменить пароль текущего пользователя. Все сессии, кроме новой, становятся недействительными,
+// поэтому возвращается пользователь с новой версией сессии для повторного входа.
+func (s *UserUseCase) ChangePassword(
+	ctx context.Context,
+	userID int,
+	currentPassword, newPassword string,
+) (*ucModel.UserDetail, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(currentPassword))
+	if err != nil {
+		return nil, ErrInvalidPassword
+	}
+
+	err = s.passwordPolicy.Check(ctx, newPassword, user.Username, user.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка генерации пароля: %w", err)
+	}
+
+	err = s.repo.UpdatePassword(ctx, user.Email, string(hashed))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка обновления пароля: %w", err)
+	}
+
+	err = s.repo.IncrementSessionVersion(ctx, user.Email)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка завершения сессий пользователя: %w", err)
+	}
+
+	s.audit.Record(ctx, model.AuditActionPasswordChange, model.AuditEntityUser, strconv.Itoa(userID), nil)
+
+	return s.Get(ctx, userID)
+}
+
+// RequestEmailChange Запросить смену e-mail. Ссылка для подтверждения отправляется на новый адрес,
+// а сам адрес меняется только после перехода по ней.
+func (s *UserUseCase) RequestEmailChange(ctx context.Context, userID int, password, newEmail string) error {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
+	if err != nil {
+		return ErrInvalidPassword
+	}
+
+	if newEmail == user.Email {
+		return ErrEmailNotChanged
+	}
+
+	_, err = s.repo.GetByEmail(ctx, newEmail)
+	switch {
+	case err == nil:
+		return ErrUserEmailNotUnique
+	case !errors.Is(err, localErrors.ErrNotFound):
+		return fmt.Errorf("ошибка проверки e-mail: %w", err)
+	}
+
+	tokenStr, err := tokens.GenerateVerificationToken()
+	if err != nil {
+		return fmt.Errorf("ошибка генерации токена: %w", err)
+	}
+
+	value, err := json.Marshal(emailChange{UserID: userID, Email: newEmail})
+	if err != nil {
+		return fmt.Errorf("ошибка подготовки токена: %w", err)
+	}
+
+	err = s.kvStorage.Set(ctx, "change_email:"+tokenStr, string(value), emailTokenTTL)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения токена: %w", err)
+	}
+
+	return s.outbox.Enqueue(ctx, emailChangeEmail(newEmail, tokenStr))
+}
+
+// ConfirmEmailChange Подтвердить смену e-mail по токену из письма. Токен одноразовый и удаляется
+// при получении, поэтому одновременные подтверждения одним токеном не пройдут оба.
+func (s *UserUseCase) ConfirmEmailChange(ctx context.Context, token string) error {
+	if token == "" {
+		return ErrInvalidToken
+	}
+
+	value, err := s.kvStorage.GetDel(ctx, "change_email:"+token)
+	if err != nil {
+		switch {
+		case localErrors.IsOneOf(err, ErrNoReplyFromKeyValueStorage, ErrKeyNotFound):
+			return ErrInvalidToken
+		default:
+			return fmt.Errorf("ошибка получения токена: %w", err)
+		}
+	}
+
+	var change emailChange
+	if err = json.Unmarshal([]byte(value), &change); err != nil {
+		return ErrInvalidToken
+	}
+
+	err = s.repo.UpdateEmail(ctx, change.UserID, change.Email)
+	if err != nil {
+		switch {
+		case errors.Is(err, localErrors.ErrNotFound):
+			return ErrUserNotFound
+		default:
+			return fmt.Errorf("ошибка обновления e-mail: %w", err)
+		}
+	}
+
 	return nil
 }
+
+// emailChange Запрос на смену e-mail, хранящийся в key-value хранилище до подтверждения
+type emailChange struct {
+	UserID int    `json:"user_id"`
+	Email  string `json:"email"`
+}
+
+func (s *UserUseCase) getUser(ctx context.Context, id int) (*model.User, error) {
+	user, err := s.repo.Get(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, localErrors.ErrNotFound):
+			return nil, ErrUserNotFound
+		default:
+			return nil, fmt.Errorf("ошибка получения пользователя по id: %w", err)
+		}
+	}
+
+	return user, nil
+}
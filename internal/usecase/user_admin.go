@@ -0,0 +1,222 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"palback/internal/domain/model"
+	"palback/internal/pkg/actor"
+	localErrors "palback/internal/pkg/errors"
+	"palback/internal/pkg/helpers"
+	ucModel "palback/internal/usecase/model"
+)
+
+const (
+	defaultUserListLimit = 50
+	maxUserListLimit     = 200
+)
+
+// GetList Получить страницу пользователей для администратора
+func (s *UserUseCase) GetList(ctx context.Context, filter model.UserFilter) (result ucModel.UserList, err error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultUserListLimit
+	}
+
+	if filter.Limit > maxUserListLimit {
+		filter.Limit = maxUserListLimit
+	}
+
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	filter.Search = strings.TrimSpace(filter.Search)
+
+	users, err := s.repo.GetList(ctx, filter)
+	if err != nil {
+		return result, fmt.Errorf("ошибка при получении списка пользователей: %w", err)
+	}
+
+	total, err := s.repo.Count(ctx, filter)
+	if err != nil {
+		return result, fmt.Errorf("ошибка при подсчете пользователей: %w", err)
+	}
+
+	rolesMap, err := s.roleService.GetAllMap(ctx)
+	if err != nil {
+		return result, fmt.Errorf("ошибка при получении информации о ролях: %w", err)
+	}
+
+	result = ucModel.CreateUserList(users, rolesMap)
+	result.Total = total
+
+	return result, nil
+}
+
+// ChangeRole Изменить роль пользователя. Собственную роль администратор изменить не может,
+// чтобы случайно не лишить себя доступа.
+func (s *UserUseCase) ChangeRole(ctx context.Context, id int, roleID model.RoleID) (*ucModel.UserDetail, error) {
+	if err := checkNotSelf(ctx, id); err != nil {
+		return nil, err
+	}
+
+	role, err := s.roleService.Get(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.getUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Иначе право управления пользователями позволило бы выдать себе или другим все права
+	if roleID == model.RoleAdmin || user.RoleID == model.RoleAdmin {
+		if err = s.checkActorIsAdmin(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if user.RoleID != roleID {
+		err = s.repo.UpdateRole(ctx, id, roleID)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка изменения роли пользователя: %w", err)
+		}
+
+		s.audit.Record(ctx, model.AuditActionUserRoleChange, model.AuditEntityUser, strconv.Itoa(id), map[string]any{
+			"before": user.RoleID,
+			"after":  roleID,
+		})
+
+		user.RoleID = roleID
+	}
+
+	userDetail := ucModel.CreateUserDetail(helpers.FromPtr(user), helpers.FromPtr(role))
+
+	return &userDetail, nil
+}
+
+// ForceVerifyEmail Считать e-mail пользователя подтвержденным без письма
+func (s *UserUseCase) ForceVerifyEmail(ctx context.Context, id int) error {
+	user, err := s.getUser(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if user.EmailVerified {
+		return nil
+	}
+
+	err = s.repo.UpdateEmailVerified(ctx, user.Email)
+	if err != nil && !errors.Is(err, localErrors.ErrNotFound) {
+		return fmt.Errorf("ошибка подтверждения e-mail: %w", err)
+	}
+
+	return nil
+}
+
+// Block Заблокировать пользователя. Заблокированный пользователь не может войти,
+// а его сессии и токены доступа перестают приниматься.
+func (s *UserUseCase) Block(ctx context.Context, id int) error {
+	if err := checkNotSelf(ctx, id); err != nil {
+		return err
+	}
+
+	user, err := s.getUser(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if user.IsBlocked() {
+		return nil
+	}
+
+	err = s.updateBlocked(ctx, id, helpers.ToPtr(time.Now()))
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, model.AuditActionUserBlock, model.AuditEntityUser, strconv.Itoa(id), nil)
+
+	return nil
+}
+
+// Unblock Снять блокировку пользователя
+func (s *UserUseCase) Unblock(ctx context.Context, id int) error {
+	err := s.updateBlocked(ctx, id, nil)
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, model.AuditActionUserUnblock, model.AuditEntityUser, strconv.Itoa(id), nil)
+
+	return nil
+}
+
+// ForceLogout Завершить все сессии пользователя
+func (s *UserUseCase) ForceLogout(ctx context.Context, id int) error {
+	user, err := s.getUser(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.IncrementSessionVersion(ctx, user.Email)
+	if err != nil {
+		switch {
+		case errors.Is(err, localErrors.ErrNotFound):
+			return ErrUserNotFound
+		default:
+			return fmt.Errorf("ошибка завершения сессий пользователя: %w", err)
+		}
+	}
+
+	s.audit.Record(ctx, model.AuditActionUserLogout, model.AuditEntityUser, strconv.Itoa(id), nil)
+
+	return nil
+}
+
+func (s *UserUseCase) updateBlocked(ctx context.Context, id int, blockedAt *time.Time) error {
+	err := s.repo.UpdateBlocked(ctx, id, blockedAt)
+	if err != nil {
+		switch {
+		case errors.Is(err, localErrors.ErrNotFound):
+			return ErrUserNotFound
+		default:
+			return fmt.Errorf("ошибка изменения блокировки пользователя: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// checkActorIsAdmin Проверить, что действие выполняет администратор
+func (s *UserUseCase) checkActorIsAdmin(ctx context.Context) error {
+	userID, ok := actor.UserID(ctx)
+	if !ok {
+		return ErrRoleAssignForbidden
+	}
+
+	role, err := s.GetRole(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !role.IsAdmin() {
+		return ErrRoleAssignForbidden
+	}
+
+	return nil
+}
+
+// checkNotSelf Проверить, что администратор выполняет действие не над собственной учетной записью
+func checkNotSelf(ctx context.Context, id int) error {
+	if userID, ok := actor.UserID(ctx); ok && userID == id {
+		return ErrUserSelfAdministration
+	}
+
+	return nil
+}
@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"unicode/utf8"
+
+	"palback/internal/domain/model"
+	localErrors "palback/internal/pkg/errors"
+	"palback/internal/pkg/token"
+	ucModel "palback/internal/usecase/model"
+	"palback/internal/usecase/port"
+)
+
+const (
+	maxDisplayNameLength = 100
+	maxAboutLength       = 2000
+)
+
+type UserProfileUseCase struct {
+	cityService CityService
+	storage     port.FileStorage
+	images      port.ImageProcessor
+	maxSize     int64
+	repo        port.UserRepo
+}
+
+func NewUserProfileUseCase(
+	cityService CityService,
+	storage port.FileStorage,
+	images port.ImageProcessor,
+	maxSize int64,
+	repo port.UserRepo,
+) *UserProfileUseCase {
+	return &UserProfileUseCase{
+		cityService: cityService,
+		storage:     storage,
+		images:      images,
+		maxSize:     maxSize,
+		repo:        repo,
+	}
+}
+
+func (s *UserProfileUseCase) Get(ctx context.Context, userID int) (*ucModel.UserProfileDetail, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var city *ucModel.CityDetail
+	if user.Profile.CityID != nil {
+		city, err = s.cityService.Get(ctx, *user.Profile.CityID)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка получения населенного пункта пользователя: %w", err)
+		}
+	}
+
+	result := ucModel.CreateUserProfileDetail(*user, city)
+
+	return &result, nil
+}
+
+func (s *UserProfileUseCase) Update(ctx context.Context, userID int, profile model.UserProfile) error {
+	if utf8.RuneCountInString(profile.DisplayName) > maxDisplayNameLength {
+		return ErrProfileDisplayNameTooLong
+	}
+
+	if utf8.RuneCountInString(profile.About) > maxAboutLength {
+		return ErrProfileAboutTooLong
+	}
+
+	if profile.CityID != nil {
+		if _, err := s.cityService.Get(ctx, *profile.CityID); err != nil {
+			return fmt.Errorf("ошибка проверки населенного пункта пользователя: %w", err)
+		}
+	}
+
+	err := s.repo.UpdateProfile(ctx, userID, profile)
+	if err != nil {
+		switch {
+		case errors.Is(err, localErrors.ErrNotFound):
+			return ErrUserNotFound
+		default:
+			return fmt.Errorf("ошибка обновления профиля: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// UploadAvatar Загрузить аватар. Изображение обрезается до квадрата, предыдущий аватар удаляется из хранилища.
+func (s *UserProfileUseCase) UploadAvatar(ctx context.Context, userID int, size int64, data io.Reader) error {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	img, err := readImage(data, size, s.maxSize)
+	if err != nil {
+		return err
+	}
+
+	variants, err := s.images.Variants(img.Data, model.AvatarVariantSpecs)
+	if err != nil {
+		return variantsError(err)
+	}
+
+	name, err := token.GenerateVerificationToken()
+	if err != nil {
+		return fmt.Errorf("ошибка генерации имени файла: %w", err)
+	}
+
+	// Каждый новый аватар сохраняется под новым ключом. Последняя часть ключа входит в ссылку на аватар,
+	// поэтому после загрузки нового аватара ссылка меняется и старый не отдается из кэша.
+	avatar := model.User{AvatarKey: fmt.Sprintf("users/%d/%s", userID, name)}
+
+	for _, variant := range variants {
+		key := avatar.AvatarFileKey(variant.Format)
+
+		err = s.storage.Save(ctx, key, bytes.NewReader(variant.Data), int64(len(variant.Data)))
+		if err != nil {
+			s.deleteAvatarFiles(ctx, avatar)
+			return fmt.Errorf("ошибка сохранения аватара в хранилище: %w", err)
+		}
+	}
+
+	err = s.repo.UpdateAvatar(ctx, userID, avatar.AvatarKey)
+	if err != nil {
+		s.deleteAvatarFiles(ctx, avatar)
+		return fmt.Errorf("ошибка сохранения аватара: %w", err)
+	}
+
+	s.deleteAvatarFiles(ctx, *user)
+
+	return nil
+}
+
+func (s *UserProfileUseCase) DeleteAvatar(ctx context.Context, userID int) error {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if user.AvatarKey == "" {
+		return ErrAvatarNotFound
+	}
+
+	err = s.repo.UpdateAvatar(ctx, userID, "")
+	if err != nil {
+		return fmt.Errorf("ошибка удаления аватара: %w", err)
+	}
+
+	s.deleteAvatarFiles(ctx, *user)
+
+	return nil
+}
+
+// DownloadAvatar Получить поток с текущим аватаром пользователя и версию аватара. Поток должен закрыть вызывающий.
+func (s *UserProfileUseCase) DownloadAvatar(
+	ctx context.Context,
+	userID int,
+	format model.ImageFormat,
+) (io.ReadCloser, string, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if user.AvatarKey == "" {
+		return nil, "", ErrAvatarNotFound
+	}
+
+	data, err := s.storage.Get(ctx, user.AvatarFileKey(format))
+	if err != nil {
+		return nil, "", fmt.Errorf("ошибка получения аватара из хранилища: %w", err)
+	}
+
+	return data, user.AvatarVersion(), nil
+}
+
+func (s *UserProfileUseCase) getUser(ctx context.Context, userID int) (*model.User, error) {
+	user, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, localErrors.ErrNotFound):
+			return nil, ErrUserNotFound
+		default:
+			return nil, fmt.Errorf("ошибка получения пользователя по id: %w", err)
+		}
+	}
+
+	return user, nil
+}
+
+// DeleteAvatarFiles Удалить файлы аватара из хранилища, не меняя пользователя. Нужно, когда самой записи
+// пользователя уже нет. Ошибки только логируются.
+func (s *UserProfileUseCase) DeleteAvatarFiles(ctx context.Context, user model.User) {
+	s.deleteAvatarFiles(ctx, user)
+}
+
+// deleteAvatarFiles Удалить файлы аватара из хранилища. Ошибки только логируются.
+func (s *UserProfileUseCase) deleteAvatarFiles(ctx context.Context, user model.User) {
+	if user.AvatarKey == "" {
+		return
+	}
+
+	for _, format := range model.ImageFormats {
+		key := user.AvatarFileKey(format)
+
+		if err := s.storage.Delete(ctx, key); err != nil {
+			log.Printf("Ошибка удаления файла %s из хранилища: %v", key, err)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"palback/internal/usecase/port"
+)
+
+// fakeEmailRepo Считает смены e-mail
+type fakeEmailRepo struct {
+	port.UserRepo
+
+	updates atomic.Int32
+}
+
+func (r *fakeEmailRepo) UpdateEmail(context.Context, int, string) error {
+	r.updates.Add(1)
+	return nil
+}
+
+func TestConfirmEmailChange_TokenUsedOnce(t *testing.T) {
+	kvStorage := &fakeKeyValueStorage{values: map[string]string{
+		"change_email:token": `{"user_id":1,"email":"new@example.com"}`,
+	}}
+	repo := &fakeEmailRepo{}
+
+	s := &UserUseCase{kvStorage: kvStorage, repo: repo}
+
+	var (
+		confirmed atomic.Int32
+		wg        sync.WaitGroup
+	)
+
+	for range 10 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if s.ConfirmEmailChange(context.Background(), "token") == nil {
+				confirmed.Add(1)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if confirmed.Load() != 1 || repo.updates.Load() != 1 {
+		t.Fatalf("подтверждений %d, смен e-mail %d, ожидалось по одному", confirmed.Load(), repo.updates.Load())
+	}
+}